@@ -0,0 +1,107 @@
+package bus
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// هدرهای استاندارد پیام
+const (
+	HeaderContentType   = "content-type"
+	HeaderEventType     = "event-type"
+	HeaderTraceID       = "trace-id"
+	HeaderCorrelationID = "correlation-id"
+)
+
+// DeadLetterSuffix پسوند تاپیک پیام‌هایی که بعد از MaxDeliveries تحویل موفق نداشتند
+const DeadLetterSuffix = ".dlq"
+
+const (
+	DefaultPartitions      = 16
+	DefaultMaxDeliveries   = 5
+	DefaultRedeliveryDelay = 200 * time.Millisecond
+)
+
+// Message واحد انتقال داده روی bus
+type Message struct {
+	ID          string
+	Topic       string
+	Key         string // کلید پارتیشن؛ پیام‌های با کلید یکسان به ترتیب تحویل داده می‌شوند (مثلاً PairID)
+	Headers     map[string]string
+	Body        []byte
+	PublishedAt time.Time
+}
+
+// Publisher انتشار پیام روی یک تاپیک
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Handler پردازش یک پیام؛ بازگرداندن nil بدون فراخوانی Nack یعنی ack،
+// و بازگرداندن خطا (یا panic) یعنی nack و تحویل دوباره.
+type Handler func(ctx context.Context, d *Delivery) error
+
+// Subscriber عضویت یک مصرف‌کننده در گروه.
+// هر پیام به ازای هر گروه فقط به یک عضو آن گروه تحویل داده می‌شود؛ لغو ctx داده‌شده به Subscribe اشتراک را می‌بندد.
+type Subscriber interface {
+	Subscribe(ctx context.Context, topic, group string, h Handler) (Subscription, error)
+	Close() error
+}
+
+// Subscription با Close عضو از گروه خارج می‌شود
+type Subscription interface {
+	Close() error
+}
+
+// Delivery پیام تحویل‌شده به همراه کنترل ack/nack
+type Delivery struct {
+	Message
+	Attempt int // شماره‌ی تلاش تحویل (از ۱)
+	Group   string
+
+	mu      sync.Mutex
+	settled bool
+	acked   bool
+	delay   time.Duration
+}
+
+// Ack پردازش موفق را اعلام می‌کند
+func (d *Delivery) Ack() {
+	d.settle(true, 0)
+}
+
+// Nack پیام را برای تحویل دوباره پس از delay برمی‌گرداند (delay صفر یعنی تأخیر پیش‌فرض)
+func (d *Delivery) Nack(delay time.Duration) {
+	d.settle(false, delay)
+}
+
+func (d *Delivery) settle(ack bool, delay time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.settled {
+		return
+	}
+	d.settled = true
+	d.acked = ack
+	d.delay = delay
+}
+
+// outcome نتیجه‌ی نهایی پس از اجرای handler
+func (d *Delivery) outcome(err error) (bool, time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.settled {
+		return d.acked, d.delay
+	}
+	return err == nil, 0
+}
+
+// Header مقدار یک هدر (در نبود هدر رشته‌ی خالی)
+func (m Message) Header(name string) string {
+	if m.Headers == nil {
+		return ""
+	}
+	return m.Headers[name]
+}
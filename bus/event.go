@@ -0,0 +1,70 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/alisiahmansouri/exchange-common/event"
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+// EventPublisher انتشار رویدادهای سفارش و تسویه با codec تعیین‌شده برای هر تاپیک
+// و کلید پارتیشن PairID تا ترتیب رویدادهای هر جفت ارز حفظ شود.
+type EventPublisher struct {
+	pub    Publisher
+	codecs *event.Codecs
+}
+
+func NewEventPublisher(pub Publisher, codecs *event.Codecs) *EventPublisher {
+	if codecs == nil {
+		codecs = event.NewCodecs(nil)
+	}
+	return &EventPublisher{pub: pub, codecs: codecs}
+}
+
+// PublishEnqueueOrder سفارش جدید را برای موتور تطبیق ارسال می‌کند
+func (p *EventPublisher) PublishEnqueueOrder(ctx context.Context, e *model.EnqueueOrderEvent) error {
+	return p.publish(ctx, event.TopicOrderEnqueue, event.TypeEnqueueOrder, e.Order.PairID.String(), e, "", "")
+}
+
+// PublishCancelOrder درخواست لغو را ارسال می‌کند؛ pairKey باید PairID سفارش باشد
+// تا لغو پس از ثبت همان سفارش پردازش شود.
+func (p *EventPublisher) PublishCancelOrder(ctx context.Context, pairKey string, e *model.CancelOrderEvent) error {
+	return p.publish(ctx, event.TopicOrderCancel, event.TypeCancelOrder, pairKey, e, "", "")
+}
+
+// PublishSettleTrade رویداد تسویه را ارسال می‌کند
+func (p *EventPublisher) PublishSettleTrade(ctx context.Context, e *model.SettleTradeEvent) error {
+	return p.publish(ctx, event.TopicTradeSettle, event.TypeSettleTrade, e.PairID.String(), e, e.TraceID, e.CorrelationID)
+}
+
+func (p *EventPublisher) publish(ctx context.Context, topic, eventType, key string, v interface{}, traceID, correlationID string) error {
+	codec := p.codecs.For(topic)
+	body, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		HeaderContentType: codec.ContentType(),
+		HeaderEventType:   eventType,
+	}
+	if traceID != "" {
+		headers[HeaderTraceID] = traceID
+	}
+	if correlationID != "" {
+		headers[HeaderCorrelationID] = correlationID
+	}
+	return p.pub.Publish(ctx, Message{
+		Topic:   topic,
+		Key:     key,
+		Headers: headers,
+		Body:    body,
+	})
+}
+
+// Decode بدنه‌ی پیام را بر اساس هدر content-type (یا codec تاپیک) در v دیکد می‌کند
+func Decode(codecs *event.Codecs, d *Delivery, v interface{}) error {
+	if codecs == nil {
+		codecs = event.NewCodecs(nil)
+	}
+	return codecs.ForContentType(d.Topic, d.Header(HeaderContentType)).Unmarshal(d.Body, v)
+}
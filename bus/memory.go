@@ -0,0 +1,327 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/util"
+)
+
+// MemoryConfig تنظیمات بروکر درون‌حافظه‌ای
+type MemoryConfig struct {
+	Partitions      int           // تعداد پارتیشن هر تاپیک
+	MaxDeliveries   int           // پس از این تعداد تلاش ناموفق، پیام به تاپیک dlq می‌رود
+	RedeliveryDelay time.Duration // تأخیر پیش‌فرض قبل از تحویل دوباره
+	Logger          *slog.Logger  // برای panic handlerها؛ پیش‌فرض: slog.Default()
+}
+
+// MemoryBroker پیاده‌سازی درون‌پردازه‌ای Publisher/Subscriber با همان قواعد بروکر واقعی:
+//   - ترتیب پیام‌ها در هر پارتیشن (کلید یکسان) حفظ می‌شود؛
+//   - هر گروه مصرف‌کننده offset مستقل دارد و گروه جدید از ابتدای لاگ شروع می‌کند؛
+//   - nack، خطا یا panic در handler باعث تحویل دوباره‌ی همان پیام (و توقف پارتیشن تا آن زمان) می‌شود.
+//
+// مناسب تست‌های یکپارچه بدون زیرساخت خارجی؛ لاگ‌ها در حافظه نگه داشته می‌شوند.
+type MemoryBroker struct {
+	cfg    MemoryConfig
+	mu     sync.Mutex
+	cond   *sync.Cond
+	topics map[string]*memTopic
+	closed bool
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type memTopic struct {
+	partitions [][]Message
+	groups     map[string]*memGroup
+}
+
+type memGroup struct {
+	offsets  []int
+	attempts []int
+	members  []*memSubscription
+	started  bool
+}
+
+type memSubscription struct {
+	broker *MemoryBroker
+	topic  string
+	group  string
+	h      Handler
+	once   sync.Once
+	stop   func() bool // لغو AfterFunc روی ctx اشتراک
+}
+
+func NewMemoryBroker(cfg MemoryConfig) *MemoryBroker {
+	if cfg.Partitions <= 0 {
+		cfg.Partitions = DefaultPartitions
+	}
+	if cfg.MaxDeliveries <= 0 {
+		cfg.MaxDeliveries = DefaultMaxDeliveries
+	}
+	if cfg.RedeliveryDelay <= 0 {
+		cfg.RedeliveryDelay = DefaultRedeliveryDelay
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &MemoryBroker{
+		cfg:    cfg,
+		topics: make(map[string]*memTopic),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, msg Message) error {
+	if msg.Topic == "" {
+		return model.ErrBusTopicRequired
+	}
+	if msg.ID == "" {
+		msg.ID = util.GenerateUUID()
+	}
+	if msg.PublishedAt.IsZero() {
+		msg.PublishedAt = util.NowUTC()
+	}
+	msg.Headers = cloneHeaders(msg.Headers)
+	msg.Body = append([]byte(nil), msg.Body...)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return model.ErrBusClosed
+	}
+	b.appendLocked(msg)
+	b.cond.Broadcast()
+	return nil
+}
+
+// Subscribe عضو را به گروه اضافه می‌کند؛ لغو ctx مانند Close عضو را از گروه خارج می‌کند
+func (b *MemoryBroker) Subscribe(ctx context.Context, topic, group string, h Handler) (Subscription, error) {
+	if topic == "" {
+		return nil, model.ErrBusTopicRequired
+	}
+	if group == "" {
+		return nil, model.ErrBusGroupRequired
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, model.ErrBusClosed
+	}
+	t := b.topicLocked(topic)
+	g, ok := t.groups[group]
+	if !ok {
+		g = &memGroup{
+			offsets:  make([]int, b.cfg.Partitions),
+			attempts: make([]int, b.cfg.Partitions),
+		}
+		t.groups[group] = g
+	}
+	sub := &memSubscription{broker: b, topic: topic, group: group, h: h}
+	g.members = append(g.members, sub)
+	sub.stop = context.AfterFunc(ctx, func() { _ = sub.Close() })
+
+	if !g.started {
+		g.started = true
+		for p := 0; p < b.cfg.Partitions; p++ {
+			b.wg.Add(1)
+			go b.worker(topic, group, p)
+		}
+	}
+	b.cond.Broadcast()
+	return sub, nil
+}
+
+// Close همه‌ی workerها را متوقف و منتظر اتمام handlerهای در حال اجرا می‌ماند
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.cancel()
+	b.cond.Broadcast()
+	b.mu.Unlock()
+	b.wg.Wait()
+	return nil
+}
+
+// Messages کپی پیام‌های منتشرشده روی یک تاپیک (برای assertion در تست‌ها)
+func (b *MemoryBroker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.topics[topic]
+	if !ok {
+		return nil
+	}
+	var out []Message
+	for _, part := range t.partitions {
+		out = append(out, part...)
+	}
+	return out
+}
+
+// Lag تعداد پیام‌های تحویل‌نشده‌ی یک گروه روی تاپیک
+func (b *MemoryBroker) Lag(topic, group string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.topics[topic]
+	if !ok {
+		return 0
+	}
+	g, ok := t.groups[group]
+	lag := 0
+	for p, part := range t.partitions {
+		if ok {
+			lag += len(part) - g.offsets[p]
+		} else {
+			lag += len(part)
+		}
+	}
+	return lag
+}
+
+// PartitionFor شماره‌ی پارتیشن یک کلید
+func (b *MemoryBroker) PartitionFor(key string) int {
+	return partitionFor(key, b.cfg.Partitions)
+}
+
+func (b *MemoryBroker) worker(topic, group string, p int) {
+	defer b.wg.Done()
+	for {
+		b.mu.Lock()
+		t := b.topics[topic]
+		g := t.groups[group]
+		for !b.closed && (g.offsets[p] >= len(t.partitions[p]) || len(g.members) == 0) {
+			b.cond.Wait()
+		}
+		if b.closed {
+			b.mu.Unlock()
+			return
+		}
+		msg := t.partitions[p][g.offsets[p]]
+		sub := g.members[p%len(g.members)]
+		g.attempts[p]++
+		d := &Delivery{Message: msg, Attempt: g.attempts[p], Group: group}
+		b.mu.Unlock()
+
+		ack, delay, err := b.handle(sub, d, p)
+
+		b.mu.Lock()
+		switch {
+		case ack:
+			g.offsets[p]++
+			g.attempts[p] = 0
+		case d.Attempt >= b.cfg.MaxDeliveries:
+			dead := msg
+			dead.Topic = topic + DeadLetterSuffix
+			dead.Headers = cloneHeaders(msg.Headers)
+			dead.Headers["dlq-group"] = group
+			if err != nil {
+				dead.Headers["dlq-error"] = err.Error()
+			}
+			b.appendLocked(dead)
+			g.offsets[p]++
+			g.attempts[p] = 0
+			b.cond.Broadcast()
+		}
+		b.mu.Unlock()
+
+		if !ack && d.Attempt < b.cfg.MaxDeliveries {
+			if delay <= 0 {
+				delay = b.cfg.RedeliveryDelay
+			}
+			select {
+			case <-time.After(delay):
+			case <-b.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// handle handler را اجرا می‌کند؛ panic لاگ و مانند خطا nack می‌شود تا worker پارتیشن متوقف نشود
+func (b *MemoryBroker) handle(sub *memSubscription, d *Delivery, p int) (ack bool, delay time.Duration, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", model.ErrBusHandlerPanic, r)
+			ack, delay = false, 0
+			b.cfg.Logger.Error("bus handler panic",
+				"topic", d.Topic, "group", d.Group, "partition", p,
+				"message_id", d.ID, "attempt", d.Attempt,
+				"panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+	err = sub.h(b.ctx, d)
+	ack, delay = d.outcome(err)
+	return ack, delay, err
+}
+
+func (b *MemoryBroker) topicLocked(name string) *memTopic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &memTopic{
+			partitions: make([][]Message, b.cfg.Partitions),
+			groups:     make(map[string]*memGroup),
+		}
+		b.topics[name] = t
+	}
+	return t
+}
+
+func (b *MemoryBroker) appendLocked(msg Message) {
+	t := b.topicLocked(msg.Topic)
+	p := partitionFor(msg.Key, b.cfg.Partitions)
+	t.partitions[p] = append(t.partitions[p], msg)
+}
+
+func (s *memSubscription) Close() error {
+	s.once.Do(func() {
+		b := s.broker
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		s.stop()
+		g := b.topics[s.topic].groups[s.group]
+		for i, m := range g.members {
+			if m == s {
+				g.members = append(g.members[:i], g.members[i+1:]...)
+				break
+			}
+		}
+		b.cond.Broadcast()
+	})
+	return nil
+}
+
+func partitionFor(key string, partitions int) int {
+	if key == "" || partitions <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(partitions))
+}
+
+func cloneHeaders(h map[string]string) map[string]string {
+	out := make(map[string]string, len(h)+2)
+	for k, v := range h {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,258 @@
+package bus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/event"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+func newTestBroker(t *testing.T, logs *bytes.Buffer) *MemoryBroker {
+	t.Helper()
+	cfg := MemoryConfig{Partitions: 4, MaxDeliveries: 3, RedeliveryDelay: time.Millisecond}
+	if logs != nil {
+		cfg.Logger = slog.New(slog.NewTextHandler(logs, nil))
+	}
+	b := NewMemoryBroker(cfg)
+	t.Cleanup(func() { _ = b.Close() })
+	return b
+}
+
+// waitFor تا برقراری cond (حداکثر یک ثانیه) صبر می‌کند
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMemoryBrokerValidation(t *testing.T) {
+	b := newTestBroker(t, nil)
+	ctx := context.Background()
+	noop := func(context.Context, *Delivery) error { return nil }
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"publish without topic", b.Publish(ctx, Message{}), model.ErrBusTopicRequired},
+		{"subscribe without topic", subscribeErr(b.Subscribe(ctx, "", "g", noop)), model.ErrBusTopicRequired},
+		{"subscribe without group", subscribeErr(b.Subscribe(ctx, "t", "", noop)), model.ErrBusGroupRequired},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, tt.err, tt.wantErr)
+		}
+	}
+	_ = b.Close()
+	if err := b.Publish(ctx, Message{Topic: "t"}); !errors.Is(err, model.ErrBusClosed) {
+		t.Errorf("publish after close: err = %v", err)
+	}
+}
+
+func subscribeErr(_ Subscription, err error) error { return err }
+
+func TestMemoryBrokerOrderingAndGroups(t *testing.T) {
+	b := newTestBroker(t, nil)
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		if err := b.Publish(ctx, Message{Topic: "t", Key: "pair", Body: []byte{byte(i)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var mu sync.Mutex
+	got := map[string][]byte{}
+	for _, group := range []string{"a", "b"} {
+		group := group
+		_, err := b.Subscribe(ctx, "t", group, func(_ context.Context, d *Delivery) error {
+			mu.Lock()
+			defer mu.Unlock()
+			got[group] = append(got[group], d.Body[0])
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, func() bool { return b.Lag("t", "a") == 0 && b.Lag("t", "b") == 0 })
+	mu.Lock()
+	defer mu.Unlock()
+	for _, group := range []string{"a", "b"} {
+		if len(got[group]) != 20 {
+			t.Fatalf("group %s got %d messages", group, len(got[group]))
+		}
+		for i, v := range got[group] {
+			if int(v) != i {
+				t.Fatalf("group %s out of order: %v", group, got[group])
+			}
+		}
+	}
+}
+
+func TestMemoryBrokerSubscribeContext(t *testing.T) {
+	b := newTestBroker(t, nil)
+	noop := func(context.Context, *Delivery) error { return nil }
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := b.Subscribe(cancelled, "t", "g", noop); !errors.Is(err, context.Canceled) {
+		t.Fatalf("subscribe with cancelled ctx: err = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var delivered sync.WaitGroup
+	delivered.Add(1)
+	sub, err := b.Subscribe(ctx, "t", "g", func(context.Context, *Delivery) error {
+		delivered.Done()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Publish(context.Background(), Message{Topic: "t"}); err != nil {
+		t.Fatal(err)
+	}
+	delivered.Wait()
+
+	cancel()
+	waitFor(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return len(b.topics["t"].groups["g"].members) == 0
+	})
+	if err := b.Publish(context.Background(), Message{Topic: "t"}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if lag := b.Lag("t", "g"); lag != 1 {
+		t.Fatalf("lag after cancel = %d, want 1", lag)
+	}
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemoryBrokerRedelivery(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   func(d *Delivery) error
+		wantTries int
+		wantDLQ   string // مقدار هدر dlq-error؛ خالی یعنی پیامی به dlq نمی‌رود
+		wantLog   bool
+	}{
+		{
+			name: "error then success",
+			handler: func(d *Delivery) error {
+				if d.Attempt < 2 {
+					return errors.New("boom")
+				}
+				return nil
+			},
+			wantTries: 2,
+		},
+		{
+			name:      "explicit nack until dlq",
+			handler:   func(d *Delivery) error { d.Nack(0); return nil },
+			wantTries: 3,
+			wantDLQ:   "-",
+		},
+		{
+			name:      "panic is recovered, logged and nacked",
+			handler:   func(d *Delivery) error { panic("handler exploded") },
+			wantTries: 3,
+			wantDLQ:   "handler exploded",
+			wantLog:   true,
+		},
+		{
+			name:      "panic after ack is still nacked",
+			handler:   func(d *Delivery) error { d.Ack(); panic("late") },
+			wantTries: 3,
+			wantDLQ:   "late",
+			wantLog:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			b := newTestBroker(t, &logs)
+			ctx := context.Background()
+			var mu sync.Mutex
+			tries := 0
+			_, err := b.Subscribe(ctx, "t", "g", func(_ context.Context, d *Delivery) error {
+				mu.Lock()
+				tries++
+				mu.Unlock()
+				return tt.handler(d)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Publish(ctx, Message{Topic: "t", Key: "k"}); err != nil {
+				t.Fatal(err)
+			}
+			waitFor(t, func() bool { return b.Lag("t", "g") == 0 })
+			_ = b.Close()
+
+			if tries != tt.wantTries {
+				t.Fatalf("tries = %d, want %d", tries, tt.wantTries)
+			}
+			dead := b.Messages("t" + DeadLetterSuffix)
+			if tt.wantDLQ == "" {
+				if len(dead) != 0 {
+					t.Fatalf("unexpected dlq messages: %+v", dead)
+				}
+			} else {
+				if len(dead) != 1 || dead[0].Header("dlq-group") != "g" {
+					t.Fatalf("dlq = %+v", dead)
+				}
+				if tt.wantDLQ != "-" && !strings.Contains(dead[0].Header("dlq-error"), tt.wantDLQ) {
+					t.Fatalf("dlq-error = %q, want %q", dead[0].Header("dlq-error"), tt.wantDLQ)
+				}
+			}
+			if logged := strings.Contains(logs.String(), "bus handler panic"); logged != tt.wantLog {
+				t.Fatalf("panic logged = %v, want %v\n%s", logged, tt.wantLog, logs.String())
+			}
+		})
+	}
+}
+
+func TestEventPublisherDecode(t *testing.T) {
+	b := newTestBroker(t, nil)
+	codecs := event.NewCodecs(nil)
+	codecs.Set(event.TopicTradeSettle, event.ProtoCodec{})
+	pub := NewEventPublisher(b, codecs)
+
+	in := &model.SettleTradeEvent{EventID: uuid.New(), PairID: uuid.New(), Sequence: 7, TraceID: "trace"}
+	if err := pub.PublishSettleTrade(context.Background(), in); err != nil {
+		t.Fatal(err)
+	}
+	msgs := b.Messages(event.TopicTradeSettle)
+	if len(msgs) != 1 {
+		t.Fatalf("messages = %d", len(msgs))
+	}
+	m := msgs[0]
+	if m.Key != in.PairID.String() || m.Header(HeaderContentType) != event.ContentTypeProtobuf ||
+		m.Header(HeaderEventType) != event.TypeSettleTrade || m.Header(HeaderTraceID) != "trace" {
+		t.Fatalf("message = %+v", m)
+	}
+	var out model.SettleTradeEvent
+	// مصرف‌کننده‌ای که codec تاپیک را نمی‌شناسد با هدر content-type دیکد می‌کند
+	if err := Decode(nil, &Delivery{Message: m}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.EventID != in.EventID || out.Sequence != in.Sequence {
+		t.Fatalf("decoded = %+v", out)
+	}
+}
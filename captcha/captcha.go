@@ -0,0 +1,157 @@
+package captcha
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/alisiahmansouri/exchange-common/textnorm"
+	"github.com/alisiahmansouri/exchange-common/util"
+	"github.com/google/uuid"
+)
+
+// DefaultLength طول پیش‌فرض متن کپچا؛ consts.CaptchaLength (۲ نویسه) برای سرویس جدید بسیار کوتاه است
+const DefaultLength = 6
+
+type Config struct {
+	TTL    time.Duration // پیش‌فرض: consts.CaptchaTTL
+	Length int           // پیش‌فرض: DefaultLength
+	Source string        // نویسه‌های مجاز متن؛ پیش‌فرض: consts.CaptchaSource
+	Width  int           // پیش‌فرض: consts.CaptchaWidth
+	Height int           // پیش‌فرض: consts.CaptchaHeight
+	Fonts  [][]byte      // فایل‌های TTF/OTF؛ پیش‌فرض: DefaultFonts
+	Pepper []byte        // کلید سرور برای HMAC پاسخ‌ها؛ الزامی
+	Now    func() time.Time
+}
+
+// Service تولید کپچای تصویری و بررسی پاسخ آن
+type Service struct {
+	store    Store
+	renderer *Renderer
+	cfg      Config
+}
+
+// NewService بدون Pepper خطای ErrPepperRequired می‌دهد
+func NewService(store Store, cfg Config) (*Service, error) {
+	if len(cfg.Pepper) == 0 {
+		return nil, model.ErrPepperRequired
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = consts.CaptchaTTL
+	}
+	if cfg.Length <= 0 {
+		cfg.Length = DefaultLength
+	}
+	if cfg.Source == "" {
+		cfg.Source = consts.CaptchaSource
+	}
+	if cfg.Width <= 0 {
+		cfg.Width = consts.CaptchaWidth
+	}
+	if cfg.Height <= 0 {
+		cfg.Height = consts.CaptchaHeight
+	}
+	if cfg.Now == nil {
+		cfg.Now = util.NowUTC
+	}
+	renderer, err := NewRenderer(cfg.Width, cfg.Height, cfg.Fonts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{store: store, renderer: renderer, cfg: cfg}, nil
+}
+
+// Generate کپچای جدید می‌سازد؛ تصویر به صورت PNG با کدگذاری base64 در Captcha برگردانده می‌شود
+func (s *Service) Generate(ctx context.Context) (*model.CaptchaResponse, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, richerror.New(consts.OpCaptchaGet, consts.ErrCaptchaIDGenFail, consts.CodeCaptchaIDGenerationFailed, richerror.KindInternal, err)
+	}
+	answer, err := util.GenerateRandomString(s.cfg.Source, s.cfg.Length)
+	if err != nil {
+		return nil, richerror.New(consts.OpCaptchaGet, consts.ErrCaptchaGenFail, consts.CodeCaptchaGenerationFailed, richerror.KindInternal, err)
+	}
+	img, err := s.renderer.PNG(answer)
+	if err != nil {
+		return nil, richerror.New(consts.OpCaptchaGet, consts.ErrCaptchaGenFail, consts.CodeCaptchaGenerationFailed, richerror.KindInternal, err)
+	}
+	if err := s.store.Set(ctx, id.String(), s.hash(id.String(), answer), s.cfg.Now().Add(s.cfg.TTL)); err != nil {
+		return nil, richerror.Wrap(consts.OpCaptchaGet, err, consts.ErrCaptchaStoreFail, consts.CodeCaptchaStorageFailed, richerror.KindInternal)
+	}
+	return &model.CaptchaResponse{
+		RequestID: id.String(),
+		Captcha:   base64.StdEncoding.EncodeToString(img),
+	}, nil
+}
+
+// Verify پاسخ را بدون حساسیت به حروف بزرگ/کوچک و با پذیرش ارقام فارسی بررسی می‌کند؛
+// کپچا در هر حال (پاسخ درست، نادرست، خالی یا با طول نادرست) مصرف می‌شود
+func (s *Service) Verify(ctx context.Context, id, answer string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return verifyErr(model.ErrCaptchaIDInvalid)
+	}
+	stored, err := s.store.Take(ctx, id)
+	if errors.Is(err, model.ErrCaptchaNotFound) {
+		return verifyErr(err)
+	}
+	if err != nil {
+		return richerror.Wrap(consts.OpCaptchaVerify, err, consts.ErrCaptchaStoreFail, consts.CodeCaptchaStorageFailed, richerror.KindInternal)
+	}
+
+	answer = normalize(answer)
+	if answer == "" {
+		return verifyErr(model.ErrCaptchaEmpty)
+	}
+	if utf8.RuneCountInString(answer) != s.cfg.Length {
+		return verifyErr(model.ErrCaptchaMalformed)
+	}
+	if !hmac.Equal([]byte(s.hash(id, answer)), []byte(stored)) {
+		return verifyErr(model.ErrCaptchaWrong)
+	}
+	return nil
+}
+
+// VerifyRequest همان Verify برای بدنه‌ی model.VerifyCaptchaRequest
+func (s *Service) VerifyRequest(ctx context.Context, req model.VerifyCaptchaRequest) error {
+	return s.Verify(ctx, req.RequestID, req.UserInput)
+}
+
+// hash پاسخ نرمال‌شده را وابسته به شناسه‌ی کپچا با HMAC-SHA256 هش می‌کند
+func (s *Service) hash(id, answer string) string {
+	mac := hmac.New(sha256.New, s.cfg.Pepper)
+	mac.Write([]byte(id))
+	mac.Write([]byte{0})
+	mac.Write([]byte(normalize(answer)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalize ارقام فارسی/عربی را لاتین، فاصله‌ها را حذف و حروف را کوچک می‌کند
+func normalize(answer string) string {
+	answer = textnorm.Digits(answer)
+	answer = strings.Join(strings.Fields(answer), "")
+	return strings.ToLower(answer)
+}
+
+func verifyErr(err error) error {
+	switch {
+	case errors.Is(err, model.ErrCaptchaIDInvalid):
+		return richerror.New(consts.OpCaptchaVerify, consts.ErrCaptchaInvalidID, consts.CodeInvalidCaptchaID, richerror.KindValidation, err)
+	case errors.Is(err, model.ErrCaptchaEmpty):
+		return richerror.New(consts.OpCaptchaVerify, consts.ErrCaptchaInvalid, consts.CodeCaptchaEmpty, richerror.KindValidation, err)
+	case errors.Is(err, model.ErrCaptchaNotFound):
+		return richerror.New(consts.OpCaptchaVerify, consts.ErrCaptchaNotFound, consts.CodeCaptchaNotFound, richerror.KindNotFound, err)
+	case errors.Is(err, model.ErrCaptchaWrong):
+		return richerror.New(consts.OpCaptchaVerify, consts.ErrCaptchaInvalid, consts.CodeCaptchaWrong, richerror.KindInvalid, err)
+	default:
+		return richerror.New(consts.OpCaptchaVerify, consts.ErrCaptchaInvalid, consts.CodeInvalidCaptcha, richerror.KindValidation, err)
+	}
+}
@@ -0,0 +1,145 @@
+package captcha
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/google/uuid"
+)
+
+func newTestService(t *testing.T) (*Service, *MemoryStore, *time.Time) {
+	t.Helper()
+	now := time.Unix(1_700_000_000, 0)
+	clock := func() time.Time { return now }
+	store := NewMemoryStore()
+	store.now = clock
+	s, err := NewService(store, Config{Length: 4, Pepper: []byte("pepper"), Now: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, store, &now
+}
+
+// seed کپچایی با پاسخ معلوم بدون رندر تصویر ذخیره می‌کند
+func seed(t *testing.T, s *Service, store *MemoryStore, answer string) string {
+	t.Helper()
+	id := uuid.NewString()
+	if err := store.Set(context.Background(), id, s.hash(id, answer), s.cfg.Now().Add(s.cfg.TTL)); err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestNewServiceRequiresPepper(t *testing.T) {
+	if _, err := NewService(NewMemoryStore(), Config{}); !errors.Is(err, model.ErrPepperRequired) {
+		t.Fatalf("err = %v, want ErrPepperRequired", err)
+	}
+}
+
+func TestNewServiceDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		want   int
+	}{
+		{"default", 0, DefaultLength},
+		{"negative", -1, DefaultLength},
+		{"explicit", 5, 5},
+	}
+	for _, tt := range tests {
+		s, err := NewService(NewMemoryStore(), Config{Length: tt.length, Pepper: []byte("pepper")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.cfg.Length != tt.want {
+			t.Errorf("%s: Length = %d, want %d", tt.name, s.cfg.Length, tt.want)
+		}
+	}
+	if DefaultLength < 5 {
+		t.Fatalf("DefaultLength = %d, want at least 5", DefaultLength)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		code   string // خالی یعنی موفق
+	}{
+		{"exact", "ab12", ""},
+		{"case and spaces", " A B 1 2 ", ""},
+		{"persian digits", "ab۱۲", ""},
+		{"wrong", "ab13", consts.CodeCaptchaWrong},
+		{"empty", "  ", consts.CodeCaptchaEmpty},
+		{"too short", "ab1", consts.CodeInvalidCaptcha},
+		{"too long", "ab123", consts.CodeInvalidCaptcha},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, store, _ := newTestService(t)
+			id := seed(t, s, store, "aB12")
+			err := s.Verify(context.Background(), id, tt.answer)
+			if got := code(err); got != tt.code {
+				t.Fatalf("code = %q (%v), want %q", got, err, tt.code)
+			}
+			// کپچا در هر حال مصرف می‌شود؛ تلاش دوم با پاسخ درست هم پذیرفته نمی‌شود
+			if got := code(s.Verify(context.Background(), id, "ab12")); got != consts.CodeCaptchaNotFound {
+				t.Fatalf("second verify code = %q, want %q", got, consts.CodeCaptchaNotFound)
+			}
+		})
+	}
+}
+
+func TestVerifyExpiredAndInvalidID(t *testing.T) {
+	s, store, now := newTestService(t)
+	id := seed(t, s, store, "ab12")
+	*now = now.Add(consts.CaptchaTTL)
+	if got := code(s.Verify(context.Background(), id, "ab12")); got != consts.CodeCaptchaNotFound {
+		t.Fatalf("expired code = %q", got)
+	}
+	if got := code(s.Verify(context.Background(), "not-a-uuid", "ab12")); got != consts.CodeInvalidCaptchaID {
+		t.Fatalf("invalid id code = %q", got)
+	}
+}
+
+func TestHashDependsOnPepperAndID(t *testing.T) {
+	a, _ := NewService(NewMemoryStore(), Config{Pepper: []byte("a")})
+	b, _ := NewService(NewMemoryStore(), Config{Pepper: []byte("b")})
+	if a.hash("id", "ab") == b.hash("id", "ab") {
+		t.Fatal("hash ignores pepper")
+	}
+	if a.hash("id1", "ab") == a.hash("id2", "ab") {
+		t.Fatal("hash ignores id")
+	}
+	if len(a.hash("id", "ab")) != 2*sha256.Size {
+		t.Fatal("unexpected hash length")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s, store, _ := newTestService(t)
+	res, err := s.Generate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uuid.Parse(res.RequestID); err != nil || res.Captcha == "" || store.Len() != 1 {
+		t.Fatalf("Generate = %+v, store len %d", res, store.Len())
+	}
+}
+
+func code(err error) string {
+	if err == nil {
+		return ""
+	}
+	var re *richerror.RichError
+	if !errors.As(err, &re) {
+		return "not a RichError"
+	}
+	return re.Code
+}
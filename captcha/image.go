@@ -0,0 +1,205 @@
+package captcha
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"math/rand/v2"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// DefaultFonts فونت‌های داخلی (خانواده‌ی Go) که بدون فایل خارجی در دسترس‌اند
+func DefaultFonts() [][]byte {
+	return [][]byte{gobold.TTF, goregular.TTF, gomonobold.TTF}
+}
+
+// Renderer متن کپچا را به تصویر PNG با اعوجاج و نویز تبدیل می‌کند
+type Renderer struct {
+	width, height int
+	fonts         []*opentype.Font
+}
+
+// NewRenderer فونت‌ها (TTF/OTF) را تجزیه می‌کند؛ بدون فونت از DefaultFonts استفاده می‌شود
+func NewRenderer(width, height int, fonts ...[]byte) (*Renderer, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("captcha: invalid image size")
+	}
+	if len(fonts) == 0 {
+		fonts = DefaultFonts()
+	}
+	r := &Renderer{width: width, height: height}
+	for _, data := range fonts {
+		f, err := opentype.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		r.fonts = append(r.fonts, f)
+	}
+	return r, nil
+}
+
+// PNG تصویر متن را به صورت PNG برمی‌گرداند
+func (r *Renderer) PNG(text string) ([]byte, error) {
+	img, err := r.Render(text)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render هر نویسه با فونت، اندازه، رنگ و زاویه‌ی تصادفی رسم می‌شود،
+// سپس تصویر موج‌دار شده و خطوط و نقاط نویز روی آن کشیده می‌شوند
+func (r *Renderer) Render(text string) (*image.RGBA, error) {
+	chars := []rune(text)
+	if len(chars) == 0 {
+		return nil, errors.New("captcha: empty text")
+	}
+	bg := color.RGBA{uint8(215 + rand.IntN(40)), uint8(215 + rand.IntN(40)), uint8(215 + rand.IntN(40)), 255}
+	canvas := image.NewRGBA(image.Rect(0, 0, r.width, r.height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+
+	slot := float64(r.width) / float64(len(chars)+1)
+	for i, ch := range chars {
+		tile, err := r.glyph(ch, darkColor())
+		if err != nil {
+			return nil, err
+		}
+		angle := (rand.Float64()*50 - 25) * math.Pi / 180
+		cx := slot*(float64(i)+1) + (rand.Float64()-0.5)*slot*0.3
+		cy := float64(r.height)/2 + (rand.Float64()-0.5)*float64(r.height)*0.2
+		drawRotated(canvas, tile, cx, cy, angle)
+	}
+
+	out := wave(canvas, bg)
+	for i := 0; i < 2+len(chars)/2; i++ {
+		curve(out, darkColor())
+	}
+	for i := 0; i < r.width*r.height/40; i++ {
+		out.Set(rand.IntN(r.width), rand.IntN(r.height), darkColor())
+	}
+	return out, nil
+}
+
+// glyph نویسه را وسط یک کاشی مربعی شفاف رسم می‌کند
+func (r *Renderer) glyph(ch rune, c color.Color) (*image.RGBA, error) {
+	size := float64(r.height) * (0.55 + rand.Float64()*0.2)
+	face, err := opentype.NewFace(r.fonts[rand.IntN(len(r.fonts))], &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	side := r.height
+	tile := image.NewRGBA(image.Rect(0, 0, side, side))
+	d := &font.Drawer{Dst: tile, Src: image.NewUniform(c), Face: face}
+	bounds, _ := d.BoundString(string(ch))
+	w := (bounds.Max.X - bounds.Min.X).Ceil()
+	h := (bounds.Max.Y - bounds.Min.Y).Ceil()
+	d.Dot = fixed.P((side-w)/2, (side-h)/2).Sub(bounds.Min)
+	d.DrawString(string(ch))
+	return tile, nil
+}
+
+// drawRotated کاشی را با زاویه‌ی angle حول مرکزش چرخانده و در (cx, cy) روی dst قرار می‌دهد
+func drawRotated(dst *image.RGBA, src *image.RGBA, cx, cy, angle float64) {
+	sb := src.Bounds()
+	half := float64(sb.Dx()) / 2
+	sin, cos := math.Sincos(angle)
+	reach := int(math.Ceil(half * math.Sqrt2))
+	for y := int(cy) - reach; y <= int(cy)+reach; y++ {
+		for x := int(cx) - reach; x <= int(cx)+reach; x++ {
+			if !(image.Point{x, y}).In(dst.Bounds()) {
+				continue
+			}
+			dx, dy := float64(x)-cx, float64(y)-cy
+			sx := int(cos*dx+sin*dy+half) + sb.Min.X
+			sy := int(-sin*dx+cos*dy+half) + sb.Min.Y
+			if !(image.Point{sx, sy}).In(sb) {
+				continue
+			}
+			blend(dst, x, y, src.RGBAAt(sx, sy))
+		}
+	}
+}
+
+// wave تصویر را با دو موج سینوسی افقی و عمودی تغییر شکل می‌دهد
+func wave(src *image.RGBA, bg color.RGBA) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(b)
+	ampX := float64(b.Dy()) * (0.05 + rand.Float64()*0.05)
+	ampY := float64(b.Dy()) * (0.04 + rand.Float64()*0.04)
+	periodX := float64(b.Dy()) * (0.8 + rand.Float64()*0.6)
+	periodY := float64(b.Dx()) * (0.3 + rand.Float64()*0.3)
+	phaseX, phaseY := rand.Float64()*2*math.Pi, rand.Float64()*2*math.Pi
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			sx := x + int(ampX*math.Sin(2*math.Pi*float64(y)/periodX+phaseX))
+			sy := y + int(ampY*math.Sin(2*math.Pi*float64(x)/periodY+phaseY))
+			if (image.Point{sx, sy}).In(b) {
+				dst.SetRGBA(x, y, src.RGBAAt(sx, sy))
+			} else {
+				dst.SetRGBA(x, y, bg)
+			}
+		}
+	}
+	return dst
+}
+
+// curve یک منحنی بزیه‌ی درجه دو با ضخامت تصادفی از عرض تصویر می‌کشد
+func curve(img *image.RGBA, c color.RGBA) {
+	b := img.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	x0, y0 := 0.0, rand.Float64()*h
+	x1, y1 := w*(0.25+rand.Float64()*0.5), rand.Float64()*h*2-h/2
+	x2, y2 := w, rand.Float64()*h
+	thick := 1 + rand.IntN(2)
+	steps := int(w) * 2
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		u := 1 - t
+		x := int(u*u*x0 + 2*u*t*x1 + t*t*x2)
+		y := int(u*u*y0 + 2*u*t*y1 + t*t*y2)
+		for dy := 0; dy < thick; dy++ {
+			if (image.Point{x, y + dy}).In(b) {
+				img.SetRGBA(x, y+dy, c)
+			}
+		}
+	}
+}
+
+// blend رنگ پیش‌ضرب‌شده‌ی c را روی پیکسل (x, y) ترکیب می‌کند
+func blend(img *image.RGBA, x, y int, c color.RGBA) {
+	if c.A == 0 {
+		return
+	}
+	d := img.RGBAAt(x, y)
+	inv := 255 - uint32(c.A)
+	img.SetRGBA(x, y, color.RGBA{
+		R: uint8(uint32(c.R) + uint32(d.R)*inv/255),
+		G: uint8(uint32(c.G) + uint32(d.G)*inv/255),
+		B: uint8(uint32(c.B) + uint32(d.B)*inv/255),
+		A: 255,
+	})
+}
+
+func darkColor() color.RGBA {
+	return color.RGBA{uint8(rand.IntN(130)), uint8(rand.IntN(130)), uint8(rand.IntN(130)), 255}
+}
@@ -0,0 +1,78 @@
+package captcha
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+// Store نگهداری هش پاسخ کپچاها با زمان انقضا
+type Store interface {
+	// Set هش پاسخ را تا expiresAt ذخیره می‌کند
+	Set(ctx context.Context, id, answerHash string, expiresAt time.Time) error
+	// Take هش پاسخ را برمی‌گرداند و همزمان حذف می‌کند (یک‌بار مصرف)؛
+	// کپچای ناموجود یا منقضی model.ErrCaptchaNotFound برمی‌گرداند
+	Take(ctx context.Context, id string) (string, error)
+}
+
+type memoryEntry struct {
+	hash      string
+	expiresAt time.Time
+}
+
+// MemoryStore پیاده‌سازی درون‌حافظه‌ای Store برای یک نمونه‌ی سرویس و تست
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]memoryEntry), now: time.Now}
+}
+
+func (s *MemoryStore) Set(_ context.Context, id, answerHash string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	s.entries[id] = memoryEntry{hash: answerHash, expiresAt: expiresAt}
+	return nil
+}
+
+func (s *MemoryStore) Take(_ context.Context, id string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return "", model.ErrCaptchaNotFound
+	}
+	delete(s.entries, id)
+	if !s.now().Before(e.expiresAt) {
+		return "", model.ErrCaptchaNotFound
+	}
+	return e.hash, nil
+}
+
+// Len تعداد کپچاهای نگهداری‌شده (شامل منقضی‌های هنوز پاک‌نشده)
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// sweep کپچاهای منقضی را حداکثر هر دقیقه یک بار پاک می‌کند
+func (s *MemoryStore) sweep() {
+	now := s.now()
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for id, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, id)
+		}
+	}
+}
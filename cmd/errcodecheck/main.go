@@ -0,0 +1,36 @@
+// errcodecheck بررسی می‌کند که همه‌ی ثابت‌های Code* در consts در errcode.Default ثبت شده، هم‌مقدار نباشند و
+// در همه‌ی زبان‌ها پیام داشته باشند؛ در صورت وجود مورد ناقص با کد ۱ خارج می‌شود.
+//
+//	go run ./cmd/errcodecheck [consts-dir]
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alisiahmansouri/exchange-common/errcode"
+	"github.com/alisiahmansouri/exchange-common/i18n"
+)
+
+func main() {
+	dir := "consts"
+	if len(os.Args) > 1 {
+		dir = os.Args[1]
+	}
+
+	failed := false
+	if err := errcode.Check(errcode.Default, dir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		failed = true
+	}
+	for _, lang := range i18n.Supported {
+		if codes := errcode.Default.Untranslated(lang); len(codes) > 0 {
+			fmt.Fprintf(os.Stderr, "codes without %s message: %v\n", lang, codes)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	fmt.Printf("%d error codes registered\n", len(errcode.Default.Codes()))
+}
@@ -17,6 +17,18 @@ const (
 	OpAuthSendPhoneVerification   = "AuthHandler.SendPhoneVerification"
 	OpAuthResendPhoneVerification = "AuthHandler.ResendPhoneVerification"
 	OpAuthResendVerification      = "AuthHandler.ResendVerification"
+	OpTokenIssue                  = "token.Manager.Issue"
+	OpTokenVerify                 = "token.Manager.Verify"
+	OpTokenMiddleware             = "token.Middleware"
+	OpTokenRefresh                = "token.Rotator.Refresh"
+	OpTokenLogout                 = "token.Rotator.Logout"
+	OpTwoFATOTPEnroll             = "twofa.TOTP.Enroll"
+	OpTwoFATOTPConfirm            = "twofa.TOTP.Confirm"
+	OpTwoFATOTPVerify             = "twofa.TOTP.Verify"
+	OpTwoFABackupRegenerate       = "twofa.BackupCodes.Regenerate"
+	OpTwoFABackupConsume          = "twofa.BackupCodes.Consume"
+	OpVerificationIssue           = "verification.Service.Issue"
+	OpVerificationVerify          = "verification.Service.Verify"
 )
 
 // ==== Error Messages (Client & Dev Friendly) ====
@@ -38,6 +50,9 @@ const (
 	ErrAuthUserIDTypeInvalid   = "نوع شناسه کاربر نامعتبر است"
 	ErrAuthTokenNotFound       = "توکن در context یافت نشد"
 	ErrAuthRevokeFail          = "خطا در لغو توکن"
+	ErrAuthTokenInvalid        = "توکن نامعتبر است"
+	ErrAuthTokenExpired        = "توکن منقضی شده است"
+	ErrAuthRefreshTokenReused  = "توکن refresh قبلاً استفاده شده است؛ همه‌ی نشست‌های مرتبط لغو شد"
 	Err2FACodeCheckFail        = "خطا در بررسی وضعیت تأیید دو عاملی"
 	Err2FACodeInvalid          = "کد تایید دو عاملی خالی یا نامعتبر است"
 	ErrLoginThrottled          = "محدودیت ورود به دلیل تلاش بیش از حد"
@@ -66,6 +81,16 @@ const (
 	ErrEmailExists                 = "ایمیل وارد شده قبلاً ثبت شده است"
 	ErrPhoneExists                 = "شماره موبایل وارد شده قبلاً ثبت شده است"
 	Err2FAAttemptCheckFail         = "خطا در بررسی محدودیت تلاش دو عاملی"
+	// TOTP
+	ErrTOTPNotEnrolled    = "برنامه احراز هویت برای این حساب فعال نشده است"
+	ErrTOTPAlreadyEnabled = "برنامه احراز هویت قبلاً فعال شده است"
+	ErrTOTPEnrollFail     = "خطا در راه‌اندازی برنامه احراز هویت"
+	ErrBackupCodeGenFail  = "خطا در تولید کدهای پشتیبان"
+	// Verification
+	ErrVerificationIssueFail  = "خطا در صدور کد تایید"
+	ErrVerificationCheckFail  = "خطا در بررسی کد تایید"
+	ErrVerificationExpired    = "کد تایید منقضی شده است، لطفا کد جدید درخواست کنید"
+	ErrVerificationResendWait = "برای ارسال مجدد کد کمی صبر کنید"
 )
 
 // ==== Error Messages Aliases for Compatibility ====
@@ -98,6 +123,9 @@ const (
 	CodeUserIDTypeInvalid      = "USER_ID_TYPE_INVALID"
 	CodeTokenNotFoundInContext = "TOKEN_NOT_FOUND_IN_CONTEXT"
 	CodeJWTRevokeError         = "JWT_REVOKE_ERROR"
+	CodeTokenInvalid           = "TOKEN_INVALID"
+	CodeTokenExpired           = "TOKEN_EXPIRED"
+	CodeRefreshTokenReused     = "REFRESH_TOKEN_REUSED"
 	Code2FACheckError          = "2FA_CHECK_ERROR"
 	CodeInvalid2FACode         = "INVALID_2FA_CODE"
 	CodeLoginThrottled         = "LOGIN_THROTTLED"
@@ -122,6 +150,16 @@ const (
 	CodePhoneAlreadyExists          = "PHONE_ALREADY_EXISTS"
 	Code2FAResendFail               = "2FA_RESEND_FAIL"
 	CodeVerificationUsed            = "VERIFICATION_USED"
+	// TOTP
+	CodeTOTPNotEnrolled    = "TOTP_NOT_ENROLLED"
+	CodeTOTPAlreadyEnabled = "TOTP_ALREADY_ENABLED"
+	CodeTOTPEnrollFail     = "TOTP_ENROLL_FAIL"
+	CodeBackupCodeGenFail  = "BACKUP_CODE_GEN_FAIL"
+	// Verification
+	CodeVerificationIssueFail = "VERIFICATION_ISSUE_FAIL"
+	CodeVerificationCheckFail = "VERIFICATION_CHECK_FAIL"
+	CodeVerificationExpired   = "VERIFICATION_EXPIRED"
+	CodeResendCooldown        = "RESEND_COOLDOWN"
 )
 
 const (
@@ -154,6 +192,9 @@ const (
 	MsgInvalidOrExpiredCode            = "کد بازیابی اشتباه یا منقضی شده"
 	Msg2FAResent                       = "کد تایید دو عاملی مجدداً ارسال شد"
 	Msg2FAVerificationStarted          = "بررسی کد ۲FA آغاز شد"
+	MsgTOTPEnrollStarted               = "کد QR را با برنامه احراز هویت اسکن و کد تولیدشده را وارد کنید"
+	MsgTOTPEnabled                     = "برنامه احراز هویت با موفقیت فعال شد"
+	MsgBackupCodesGenerated            = "کدهای پشتیبان جدید ساخته شد؛ کدهای قبلی دیگر معتبر نیستند"
 )
 
 // ==== Purposes for Verification Codes ====
@@ -169,11 +210,25 @@ const (
 const (
 	ChannelEmail = "email"
 	ChannelSMS   = "sms"
+	ChannelTOTP  = "totp" // برنامه احراز هویت (Google Authenticator و ...)
 )
 
+// ValidChannels کانال‌های ارسال کد تایید
+//
+// Deprecated: کانال‌های مجاز هر هدف در verification.Registry تعریف می‌شوند؛ از Registry.ValidChannel
+// یا Registry.CheckChannel استفاده کنید تا فهرست کانال‌ها فقط یک مرجع داشته باشد.
 var ValidChannels = []string{
 	ChannelEmail,
 	ChannelSMS,
 }
 
+// ValidVerifyChannels کانال‌هایی که کد از طریق آن‌ها قابل تایید است؛ ChannelTOTP فقط تایید می‌شود و ارسالی ندارد
+//
+// Deprecated: مانند ValidChannels از verification.Registry استفاده کنید.
+var ValidVerifyChannels = []string{
+	ChannelEmail,
+	ChannelSMS,
+	ChannelTOTP,
+}
+
 const Default2FAExpireMinutes = 2
@@ -39,6 +39,8 @@ const (
 	CaptchaSource = "1234567890abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 )
 
+// Deprecated: پکیج captcha از فونت‌های embed شده (captcha.DefaultFonts) استفاده می‌کند؛ فقط برای سرویس‌هایی
+// که هنوز این فایل‌ها را خودشان بارگذاری می‌کنند نگه داشته شده است.
 var CaptchaFonts = []string{
 	"wqy-microhei.ttc",
 	"comic.ttf",
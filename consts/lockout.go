@@ -0,0 +1,34 @@
+package consts
+
+import "time"
+
+// --- Operation Identifiers ---
+const (
+	OpLockoutCheck   = "lockout.Guard.Check"
+	OpLockoutFailure = "lockout.Guard.RecordFailure"
+)
+
+// --- Error Messages ---
+const (
+	ErrLoginThrottledWait = "ورود به دلیل تلاش‌های ناموفق متعدد موقتاً محدود شده است؛ %s دیگر دوباره تلاش کنید"
+	ErrLockoutCheckFail   = "خطا در بررسی محدودیت ورود"
+)
+
+// --- Error Codes ---
+const (
+	CodeLockoutCheckFail = "LOCKOUT_CHECK_FAIL"
+)
+
+// --- Lockout Config ---
+const (
+	LockoutIdentifierIPAttempts = MaxLoginAttempts     // تلاش ناموفق مجاز برای یک حساب از یک IP پیش از شروع تأخیر
+	LockoutIdentifierAttempts   = 4 * MaxLoginAttempts // شکست یک حساب از همه‌ی IPها که پس از آن IPهای ناشناس باید چالش حل کنند (حساب قفل نمی‌شود)
+	LockoutIPAttempts           = 30                   // تلاش ناموفق مجاز از یک IP برای همه‌ی حساب‌ها
+	LockoutBaseDelay            = 30 * time.Second     // تأخیر اولین قفل؛ هر شکست بعدی دو برابر
+	LockoutMaxDelay             = LoginAttemptDuration // سقف تأخیر
+	LockoutFailureTTL           = 24 * time.Hour       // شکست‌ها پس از این مدت بدون تلاش جدید فراموش می‌شوند
+	LockoutStuffingIdentifiers  = 10                   // تعداد حساب متمایز از یک IP در LockoutStuffingWindow
+	LockoutStuffingWindow       = 15 * time.Minute
+	LockoutStuffingDuration     = time.Hour
+	LockoutTrustedTTL           = 30 * 24 * time.Hour // اعتماد به IP پس از ورود موفق
+)
@@ -73,5 +73,5 @@ const (
 	CodeOrderInvalidType              = "ORDER_INVALID_TYPE"
 	CodeOrderInvalidStatusFilter      = "ORDER_INVALID_STATUS_FILTER"
 	CodeOrderInvalidPagination        = "ORDER_INVALID_PAGINATION"
-	CodeInternal                      = "INTERNAL_ERROR"
+	CodeInternal                      = CodeInternalError // هم‌ارز CodeInternalError؛ برای سازگاری نگه داشته شده
 )
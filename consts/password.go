@@ -0,0 +1,30 @@
+package consts
+
+// --- Operation Identifiers ---
+const (
+	OpPasswordPolicyCheck = "password.Policy.Validate"
+)
+
+// --- Error Messages ---
+const (
+	ErrPasswordPolicyViolation = "رمز عبور با سیاست امنیتی مطابقت ندارد"
+	ErrPasswordTooShort        = "رمز عبور کوتاه‌تر از حد مجاز است"
+	ErrPasswordTooLong         = "رمز عبور بلندتر از حد مجاز است"
+	ErrPasswordCharClasses     = "رمز عبور باید ترکیبی از حروف بزرگ، حروف کوچک، اعداد و کاراکترهای ویژه باشد"
+	ErrPasswordCommon          = "این رمز عبور بسیار رایج است یا در نشت‌های اطلاعاتی دیده شده است"
+	ErrPasswordHasIdentifier   = "رمز عبور نباید شامل ایمیل یا شماره موبایل شما باشد"
+	ErrPasswordWeak            = "رمز عبور به اندازه کافی قوی نیست"
+	ErrPasswordReused          = "این رمز عبور اخیراً استفاده شده است"
+)
+
+// --- Error Codes ---
+const (
+	CodePasswordPolicyViolation = "PASSWORD_POLICY_VIOLATION"
+	CodePasswordTooShort        = "PASSWORD_TOO_SHORT"
+	CodePasswordTooLong         = "PASSWORD_TOO_LONG"
+	CodePasswordCharClasses     = "PASSWORD_CHAR_CLASSES"
+	CodePasswordCommon          = "PASSWORD_COMMON"
+	CodePasswordHasIdentifier   = "PASSWORD_CONTAINS_IDENTIFIER"
+	CodePasswordWeak            = "PASSWORD_WEAK"
+	CodePasswordReused          = "PASSWORD_REUSED"
+)
@@ -0,0 +1,35 @@
+package consts
+
+import "time"
+
+// --- Operation Identifiers ---
+const (
+	OpPoWIssue  = "pow.Service.Issue"
+	OpPoWVerify = "pow.Service.Verify"
+)
+
+// --- Error Messages ---
+const (
+	ErrPoWIssueFail    = "خطا در تولید چالش اثبات کار"
+	ErrPoWInvalid      = "چالش اثبات کار نامعتبر است"
+	ErrPoWExpired      = "چالش اثبات کار منقضی شده است"
+	ErrPoWInsufficient = "پاسخ چالش اثبات کار نادرست است"
+	ErrPoWReplayed     = "این چالش اثبات کار قبلاً استفاده شده است"
+)
+
+// --- Error Codes ---
+const (
+	CodePoWIssueFail    = "POW_ISSUE_FAILED"
+	CodePoWInvalid      = "POW_INVALID"
+	CodePoWExpired      = "POW_EXPIRED"
+	CodePoWInsufficient = "POW_INSUFFICIENT"
+	CodePoWReplayed     = "POW_REPLAYED"
+)
+
+// --- PoW Config ---
+const (
+	PoWAlgorithm     = "sha256"
+	PoWDifficulty    = 18 // تعداد بیت‌های صفر ابتدای هش؛ حدود ۲۶۰ هزار هش (کسری از ثانیه در مرورگر)
+	PoWMaxDifficulty = 28
+	PoWTTL           = 2 * time.Minute
+)
@@ -0,0 +1,39 @@
+package consts
+
+import "time"
+
+// --- Operation Identifiers ---
+const (
+	OpRateLimitAllow = "ratelimit.Limiter.Allow"
+)
+
+// --- Error Messages ---
+const (
+	ErrTooManyRequests      = "تعداد درخواست‌ها بیش از حد مجاز است، لطفا بعدا تلاش کنید"
+	ErrRateLimitUnavailable = "بررسی محدودیت درخواست‌ها ممکن نشد، لطفا دوباره تلاش کنید"
+)
+
+// --- Error Codes ---
+const (
+	CodeTooManyRequests      = "TOO_MANY_REQUESTS"
+	CodeRateLimitUnavailable = "RATE_LIMIT_UNAVAILABLE"
+)
+
+// --- User Tiers ---
+const (
+	TierAnonymous = "anonymous"
+	TierBasic     = "basic"
+	TierVerified  = "verified"
+	TierVIP       = "vip"
+)
+
+// --- Default Quotas ---
+const (
+	LoginIPAttempts       = 20 // تلاش ورود از یک IP در LoginAttemptDuration
+	ResendCodeLimit       = 5  // ارسال مجدد کد در ResendCodeWindow
+	ResendCodeWindow      = time.Hour
+	OrderRateBasic        = 10 // ثبت سفارش در ثانیه
+	OrderRateVerified     = 30
+	OrderRateVIP          = 100
+	OrderRateBurstSeconds = 2 // ظرفیت سطل برحسب چند ثانیه نرخ
+)
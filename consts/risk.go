@@ -0,0 +1,30 @@
+package consts
+
+import "time"
+
+// --- Operation Identifiers ---
+const (
+	OpRiskAssess = "risk.Evaluator.Assess"
+	OpRiskCheck  = "risk.Evaluator.Check"
+)
+
+// --- Error Messages ---
+const (
+	ErrCaptchaRequired = "برای ادامه، کپچا را حل کنید"
+	ErrRiskAssessFail  = "خطا در ارزیابی ریسک درخواست"
+)
+
+// --- Error Codes ---
+const (
+	CodeCaptchaRequired = "CAPTCHA_REQUIRED"
+)
+
+// --- Risk Config ---
+const (
+	RiskIdentifierFailures = 3                // تلاش ناموفق برای یک شناسه در پنجره تا الزام کپچا
+	RiskIPFailures         = 10               // تلاش ناموفق از یک IP در پنجره تا الزام کپچا
+	RiskFailureWindow      = 15 * time.Minute // پنجره‌ی شمارش تلاش‌های ناموفق
+	RiskIPVelocity         = 20               // تعداد درخواست از یک IP در RiskVelocityWindow
+	RiskVelocityWindow     = time.Minute
+	RiskThreshold          = 60 // امتیاز لازم برای الزام کپچا (از ۱۰۰)
+)
@@ -0,0 +1,57 @@
+package email
+
+import "strings"
+
+// provider قواعد نام مستعار ارائه‌دهنده‌ی ایمیل
+type provider struct {
+	domain     string // دامنه‌ی مرجع
+	ignoreDots bool   // نقطه در بخش محلی نادیده گرفته می‌شود
+	tagSep     string // جداکننده‌ی برچسب (user+tag)
+}
+
+var providers = map[string]provider{
+	"gmail.com":      {domain: "gmail.com", ignoreDots: true, tagSep: "+"},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true, tagSep: "+"},
+	"outlook.com":    {domain: "outlook.com", tagSep: "+"},
+	"hotmail.com":    {domain: "hotmail.com", tagSep: "+"},
+	"live.com":       {domain: "live.com", tagSep: "+"},
+	"icloud.com":     {domain: "icloud.com", tagSep: "+"},
+	"me.com":         {domain: "icloud.com", tagSep: "+"},
+	"mac.com":        {domain: "icloud.com", tagSep: "+"},
+	"protonmail.com": {domain: "proton.me", tagSep: "+"},
+	"proton.me":      {domain: "proton.me", tagSep: "+"},
+	"pm.me":          {domain: "proton.me", tagSep: "+"},
+	"fastmail.com":   {domain: "fastmail.com", tagSep: "+"},
+	"yahoo.com":      {domain: "yahoo.com", tagSep: "-"},
+	"yandex.com":     {domain: "yandex.com", tagSep: "+"},
+	"yandex.ru":      {domain: "yandex.com", tagSep: "+"},
+}
+
+// Canonical شکل یکتای صندوق پستی برای تشخیص حساب تکراری
+// (مثلاً A.Li+promo@googlemail.com و ali@gmail.com یکی هستند).
+// برای ارسال ایمیل همیشه از آدرس واردشده‌ی کاربر استفاده شود، نه این مقدار.
+func (a Address) Canonical() string {
+	p, ok := providers[a.Domain]
+	if !ok {
+		return a.String()
+	}
+	local := a.Local
+	if p.tagSep != "" {
+		if i := strings.Index(local, p.tagSep); i > 0 {
+			local = local[:i]
+		}
+	}
+	if p.ignoreDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local + "@" + p.domain
+}
+
+// Canonical ایمیل ورودی را تجزیه و شکل یکتای آن را برمی‌گرداند
+func Canonical(raw string) (string, error) {
+	a, err := Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	return a.Canonical(), nil
+}
@@ -0,0 +1,80 @@
+package email
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// خطاها فقط اینجا تعریف شده‌اند (نه در model) تا util و identifier بدون چرخه‌ی import از این پکیج استفاده کنند
+var (
+	ErrInvalidFormat = errors.New("فرمت ایمیل نامعتبر است")
+	ErrBlocked       = errors.New("دامنه ایمیل مجاز نیست")
+	ErrDisposable    = errors.New("استفاده از ایمیل موقت مجاز نیست")
+)
+
+const (
+	maxLength      = 254
+	maxLocalLength = 64
+)
+
+var (
+	// dot-atom بخش محلی (RFC 5322) بدون quoted-string
+	localRe = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+/=?^_{|}~-]+(\.[A-Za-z0-9!#$%&'*+/=?^_{|}~-]+)*$`)
+	// دامنه پس از تبدیل به punycode
+	domainRe = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+(xn--[a-z0-9-]{1,59}|[a-z]{2,63})$`)
+)
+
+var idnaProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.Transitional(false))
+
+// Address ایمیل تجزیه‌شده
+type Address struct {
+	Local         string // حروف کوچک
+	Domain        string // ASCII (punycode)
+	UnicodeDomain string // برای نمایش
+}
+
+// String قالب نرمال‌شده local@domain (دامنه به punycode)
+func (a Address) String() string {
+	return a.Local + "@" + a.Domain
+}
+
+// Parse قالب ایمیل را بررسی و نرمال می‌کند (بدون بررسی فهرست دامنه‌ها)
+func Parse(raw string) (Address, error) {
+	s := strings.TrimSpace(raw)
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 || at == len(s)-1 {
+		return Address{}, ErrInvalidFormat
+	}
+	local, domain := s[:at], s[at+1:]
+	if len(local) > maxLocalLength || !localRe.MatchString(local) {
+		return Address{}, ErrInvalidFormat
+	}
+	ascii, err := toASCII(domain)
+	if err != nil || !domainRe.MatchString(ascii) {
+		return Address{}, ErrInvalidFormat
+	}
+	a := Address{Local: strings.ToLower(local), Domain: ascii, UnicodeDomain: ascii}
+	if u, err := idnaProfile.ToUnicode(ascii); err == nil {
+		a.UnicodeDomain = u
+	}
+	if len(a.String()) > maxLength {
+		return Address{}, ErrInvalidFormat
+	}
+	return a, nil
+}
+
+// Normalize ایمیل را به شکل local@domain با حروف کوچک و دامنه‌ی punycode برمی‌گرداند
+func Normalize(raw string) (string, error) {
+	a, err := Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	return a.String(), nil
+}
+
+func toASCII(domain string) (string, error) {
+	return idnaProfile.ToASCII(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+}
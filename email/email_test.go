@@ -0,0 +1,151 @@
+package email
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		unicode string
+		wantErr bool
+	}{
+		{"Ali@Example.COM", "ali@example.com", "example.com", false},
+		{"  a.b+tag@gmail.com ", "a.b+tag@gmail.com", "gmail.com", false},
+		{"user@پست.ایران", "user@xn--pgbr8t.xn--mgba3a4f16a", "پست.ایران", false},
+		{"user@mail.example.com.", "user@mail.example.com", "mail.example.com", false},
+		{"", "", "", true},
+		{"@example.com", "", "", true},
+		{"user@", "", "", true},
+		{"a..b@example.com", "", "", true},
+		{".a@example.com", "", "", true},
+		{"user@localhost", "", "", true},
+		{"user@-bad.com", "", "", true},
+		{"user@example.c", "", "", true},
+		{"a b@example.com", "", "", true},
+		{strings.Repeat("a", 65) + "@example.com", "", "", true},
+		{"a@" + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 63) + "." + strings.Repeat("e", 61) + ".com", "", "", true},
+	}
+	for _, tt := range tests {
+		a, err := Parse(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("Parse(%q) err = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+		if err != nil {
+			if !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("Parse(%q) err = %v, want ErrInvalidFormat", tt.raw, err)
+			}
+			continue
+		}
+		if a.String() != tt.want || a.UnicodeDomain != tt.unicode {
+			t.Fatalf("Parse(%q) = %s (%s), want %s (%s)", tt.raw, a, a.UnicodeDomain, tt.want, tt.unicode)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"A.Li+promo@googlemail.com", "ali@gmail.com"},
+		{"ali@gmail.com", "ali@gmail.com"},
+		{"first.last+x@outlook.com", "first.last@outlook.com"},
+		{"user-news@yahoo.com", "user@yahoo.com"},
+		{"user@pm.me", "user@proton.me"},
+		{"+tag@gmail.com", "+tag@gmail.com"},
+		{"a.b+c@company.ir", "a.b+c@company.ir"},
+	}
+	for _, tt := range tests {
+		got, err := Canonical(tt.raw)
+		if err != nil || got != tt.want {
+			t.Errorf("Canonical(%q) = %q, %v; want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func TestDomainList(t *testing.T) {
+	l := NewDomainList("Mailinator.com", "tempmail.", "")
+	tests := []struct {
+		domain string
+		want   bool
+	}{
+		{"mailinator.com", true},
+		{"MAILINATOR.COM", true},
+		{"eu.mailinator.com", true},
+		{"notmailinator.com", false},
+		{"tempmail", true},
+		{"gmail.com", false},
+	}
+	for _, tt := range tests {
+		if got := l.Contains(tt.domain); got != tt.want {
+			t.Errorf("Contains(%q) = %v, want %v", tt.domain, got, tt.want)
+		}
+	}
+	if l.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", l.Len())
+	}
+
+	l.Remove("mailinator.com")
+	if l.Contains("mailinator.com") {
+		t.Fatal("Remove had no effect")
+	}
+	if err := l.Load(strings.NewReader("# comment\n\n yopmail.com \nguerrillamail.com\n")); err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() != 2 || !l.Contains("yopmail.com") || l.Contains("tempmail") {
+		t.Fatalf("Load did not replace the list (len %d)", l.Len())
+	}
+}
+
+func TestValidator(t *testing.T) {
+	v := &Validator{Blocked: NewDomainList("blocked.ir"), Disposable: NewDomainList("trash.io")}
+	allow := &Validator{Blocked: v.Blocked, Disposable: v.Disposable, AllowDisposable: true}
+	tests := []struct {
+		name    string
+		v       *Validator
+		raw     string
+		wantErr error
+	}{
+		{"ok", v, "a@company.ir", nil},
+		{"blocked", v, "a@blocked.ir", ErrBlocked},
+		{"blocked subdomain", v, "a@mail.blocked.ir", ErrBlocked},
+		{"disposable", v, "a@trash.io", ErrDisposable},
+		{"disposable allowed", allow, "a@trash.io", nil},
+		{"blocked even when disposable allowed", allow, "a@blocked.ir", ErrBlocked},
+		{"bad format", v, "nope", ErrInvalidFormat},
+		{"nil lists", &Validator{}, "a@trash.io", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.v.Validate(tt.raw); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if !v.IsDisposable("x@trash.io") || v.IsDisposable("x@company.ir") || v.IsDisposable("bad") {
+		t.Fatal("IsDisposable mismatch")
+	}
+}
+
+func TestDefaultLists(t *testing.T) {
+	if BlockedDomains.Len() == 0 || DisposableDomains.Len() == 0 {
+		t.Fatal("embedded lists are empty")
+	}
+	tests := []struct {
+		raw     string
+		wantErr error
+	}{
+		{"user@gmail.com", nil},
+		{"user@example.com", ErrBlocked},
+		{"user@10minutemail.com", ErrDisposable},
+	}
+	for _, tt := range tests {
+		if _, err := Validate(tt.raw); !errors.Is(err, tt.wantErr) {
+			t.Errorf("Validate(%q) err = %v, want %v", tt.raw, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package email
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+	"sync"
+)
+
+//go:embed disposable.txt
+var disposableTxt string
+
+//go:embed blocked.txt
+var blockedTxt string
+
+// DomainList مجموعه‌ی دامنه‌ها که در زمان اجرا قابل به‌روزرسانی است؛
+// هر دامنه زیردامنه‌هایش را هم در بر می‌گیرد.
+type DomainList struct {
+	mu      sync.RWMutex
+	domains map[string]struct{}
+}
+
+func NewDomainList(domains ...string) *DomainList {
+	l := &DomainList{domains: make(map[string]struct{}, len(domains))}
+	l.Add(domains...)
+	return l
+}
+
+// Contains دامنه یا یکی از دامنه‌های والد آن در فهرست است یا نه
+func (l *DomainList) Contains(domain string) bool {
+	d := normalizeDomain(domain)
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for d != "" {
+		if _, ok := l.domains[d]; ok {
+			return true
+		}
+		i := strings.IndexByte(d, '.')
+		if i < 0 {
+			break
+		}
+		d = d[i+1:]
+	}
+	return false
+}
+
+func (l *DomainList) Add(domains ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, d := range domains {
+		if d = normalizeDomain(d); d != "" {
+			l.domains[d] = struct{}{}
+		}
+	}
+}
+
+func (l *DomainList) Remove(domains ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, d := range domains {
+		delete(l.domains, normalizeDomain(d))
+	}
+}
+
+// Replace کل فهرست را جایگزین می‌کند (مثلاً پس از دریافت نسخه‌ی جدید)
+func (l *DomainList) Replace(domains []string) {
+	m := make(map[string]struct{}, len(domains))
+	for _, d := range domains {
+		if d = normalizeDomain(d); d != "" {
+			m[d] = struct{}{}
+		}
+	}
+	l.mu.Lock()
+	l.domains = m
+	l.mu.Unlock()
+}
+
+// Load فهرست را از متن خط‌به‌خط (با پشتیبانی از توضیح #) جایگزین می‌کند
+func (l *DomainList) Load(r io.Reader) error {
+	domains, err := readDomains(r)
+	if err != nil {
+		return err
+	}
+	l.Replace(domains)
+	return nil
+}
+
+func (l *DomainList) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.domains)
+}
+
+func readDomains(r io.Reader) ([]string, error) {
+	var out []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out, sc.Err()
+}
+
+func mustList(txt string) *DomainList {
+	domains, _ := readDomains(strings.NewReader(txt))
+	return NewDomainList(domains...)
+}
+
+func normalizeDomain(d string) string {
+	d = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+	if ascii, err := toASCII(d); err == nil {
+		return ascii
+	}
+	return d
+}
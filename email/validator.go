@@ -0,0 +1,49 @@
+package email
+
+// Validator بررسی قالب و دامنه‌ی ایمیل با فهرست‌های قابل به‌روزرسانی
+type Validator struct {
+	Blocked         *DomainList
+	Disposable      *DomainList
+	AllowDisposable bool
+}
+
+// فهرست‌های پیش‌فرض (embed شده)؛ با Add/Replace/Load در زمان اجرا به‌روز می‌شوند
+var (
+	BlockedDomains    = mustList(blockedTxt)
+	DisposableDomains = mustList(disposableTxt)
+)
+
+// DefaultValidator از فهرست‌های پیش‌فرض استفاده می‌کند
+var DefaultValidator = &Validator{Blocked: BlockedDomains, Disposable: DisposableDomains}
+
+// Validate قالب، دامنه‌های مسدود و ایمیل‌های موقت را بررسی می‌کند
+func (v *Validator) Validate(raw string) (Address, error) {
+	a, err := Parse(raw)
+	if err != nil {
+		return Address{}, err
+	}
+	if v.Blocked != nil && v.Blocked.Contains(a.Domain) {
+		return Address{}, ErrBlocked
+	}
+	if !v.AllowDisposable && v.Disposable != nil && v.Disposable.Contains(a.Domain) {
+		return Address{}, ErrDisposable
+	}
+	return a, nil
+}
+
+// IsDisposable دامنه‌ی ایمیل در فهرست ایمیل‌های موقت است یا نه
+func (v *Validator) IsDisposable(raw string) bool {
+	a, err := Parse(raw)
+	return err == nil && v.Disposable != nil && v.Disposable.Contains(a.Domain)
+}
+
+// Validate با DefaultValidator
+func Validate(raw string) (Address, error) {
+	return DefaultValidator.Validate(raw)
+}
+
+// Valid ایمیل از نظر DefaultValidator معتبر است یا نه
+func Valid(raw string) bool {
+	_, err := DefaultValidator.Validate(raw)
+	return err == nil
+}
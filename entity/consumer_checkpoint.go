@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ConsumerCheckpoint آخرین Sequence اعمال‌شده‌ی هر جفت ارز برای هر مصرف‌کننده (checkpoint بافر ترتیب)
+type ConsumerCheckpoint struct {
+	Consumer     string    `gorm:"size:64;primaryKey"`
+	PairID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	LastSequence uint64    `gorm:"not null;default:0"`
+	UpdatedAt    time.Time `gorm:"not null"`
+}
+
+func (ConsumerCheckpoint) TableName() string { return "consumer_checkpoints" }
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// InboxMessage پیام‌های پردازش‌شده توسط هر مصرف‌کننده را ثبت می‌کند (الگوی Inbox).
+// درج این ردیف در همان تراکنش تغییر business انجام می‌شود تا تحویل دوباره‌ی پیام نادیده گرفته شود.
+type InboxMessage struct {
+	Consumer    string    `gorm:"size:64;primaryKey"`                 // نام مصرف‌کننده (مثلاً settlement-service)
+	MessageID   uuid.UUID `gorm:"type:uuid;primaryKey"`               // EventID یا ActionID پیام
+	EventType   string    `gorm:"size:64;not null"`                   // نوع رویداد (برای audit)
+	ProcessedAt time.Time `gorm:"not null;index:idx_inbox_processed"` // برای پاکسازی دوره‌ای
+}
+
+func (InboxMessage) TableName() string { return "inbox_messages" }
@@ -15,10 +15,11 @@ const (
 type OutboxEvent struct {
 	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
 	EventType    string    `gorm:"size:64;not null;index:idx_outbox_type_dedup,priority:1"`
-	DedupKey     string    `gorm:"type:char(64);not null;index:idx_outbox_type_dedup,unique,priority:2"` // SHA-256 hex
+	DedupKey     string    `gorm:"type:char(64);not null;index:idx_outbox_type_dedup,unique,priority:2"` // SHA-256 hex (event.Key.DedupKey)
 	PairID       uuid.UUID `gorm:"type:uuid;index:idx_outbox_pair_seq,priority:1"`                       // برای ordering و شاردینگ
 	Sequence     uint64    `gorm:"not null;default:0;index:idx_outbox_pair_seq,priority:2"`              // ترتیب قطعی در هر Pair
-	Payload      []byte    `gorm:"type:jsonb;not null"`
+	Version      int       `gorm:"not null;default:1"`                                                   // نسخه‌ی schema رویداد (event.Envelope.Version)
+	Payload      []byte    `gorm:"type:jsonb;not null"`                                                  // event.Envelope به صورت JSON
 	Status       string    `gorm:"size:16;not null;default:pending;index:idx_outbox_status_created"`
 	CreatedAt    time.Time `gorm:"not null;index:idx_outbox_status_created"`
 	SentAt       *time.Time
@@ -28,6 +29,8 @@ type OutboxEvent struct {
 
 func (OutboxEvent) TableName() string { return "match_events_outbox" }
 
+// UnmarshalPayload کل payload (پاکت رویداد) را دیکد می‌کند؛
+// برای دیکد بدنه با پشتیبانی نسخه از event.EnvelopeFromOutbox و Registry استفاده کنید.
 func (e *OutboxEvent) UnmarshalPayload(v interface{}) error {
 	return json.Unmarshal(e.Payload, v)
 }
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PairSequence آخرین Sequence تخصیص‌یافته برای هر جفت ارز را نگه می‌دارد.
+// تخصیص باید در همان تراکنشی انجام شود که OutboxEvent درج می‌شود تا در صورت rollback شکافی ایجاد نشود.
+type PairSequence struct {
+	PairID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	LastSequence uint64    `gorm:"not null;default:0"`
+	UpdatedAt    time.Time `gorm:"not null"`
+}
+
+func (PairSequence) TableName() string { return "pair_sequences" }
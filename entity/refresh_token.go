@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// RefreshTokenFamily زنجیره‌ی توکن‌های refresh یک نشست؛
+// با هر refresh توکن جدید در همین خانواده صادر می‌شود و استفاده‌ی دوباره از توکن چرخانده‌شده کل خانواده را لغو می‌کند.
+type RefreshTokenFamily struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	SessionID    string     `gorm:"size:64;index" json:"session_id,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	RevokeReason *string    `gorm:"size:64" json:"revoke_reason,omitempty"` // logout, logout_all, reuse_detected, ...
+	CreatedAt    time.Time  `gorm:"not null" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"not null" json:"updated_at"`
+}
+
+func (f *RefreshTokenFamily) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	now := time.Now()
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = now
+	}
+	f.UpdatedAt = now
+	return nil
+}
+
+func (f *RefreshTokenFamily) BeforeUpdate(tx *gorm.DB) (err error) {
+	f.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsRevoked خانواده لغو شده است یا نه
+func (f *RefreshTokenFamily) IsRevoked() bool {
+	return f.RevokedAt != nil
+}
+
+// RefreshToken هر توکن refresh صادرشده (شناسه = jti)
+type RefreshToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	FamilyID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"family_id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ExpiresAt  time.Time  `gorm:"not null;index" json:"expires_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`                   // زمان جایگزینی با توکن بعدی
+	ReplacedBy *uuid.UUID `gorm:"type:uuid" json:"replaced_by,omitempty"` // jti توکن جایگزین
+	CreatedAt  time.Time  `gorm:"not null" json:"created_at"`
+}
+
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// TOTPCredential کلید برنامه احراز هویت کاربر (RFC 6238)؛
+// تا زمان تایید اولین کد (ConfirmedAt) برای ورود پذیرفته نمی‌شود.
+type TOTPCredential struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null" json:"user_id"`
+	Secret       string     `gorm:"size:128;not null" json:"-"` // base32؛ در صورت امکان رمزنگاری‌شده ذخیره شود
+	Digits       int        `gorm:"not null;default:6" json:"digits"`
+	Period       int        `gorm:"not null;default:30" json:"period"`              // ثانیه
+	Algorithm    string     `gorm:"size:10;not null;default:SHA1" json:"algorithm"` // SHA1، SHA256 یا SHA512؛ ردیف‌های قبلی SHA1 می‌گیرند
+	LastUsedStep int64      `gorm:"not null;default:0" json:"-"`                    // آخرین گام زمانی پذیرفته‌شده (جلوگیری از replay)
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (t *TOTPCredential) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	now := time.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+	return
+}
+
+func (t *TOTPCredential) BeforeUpdate(tx *gorm.DB) (err error) {
+	t.UpdatedAt = time.Now()
+	return
+}
+
+// IsConfirmed ثبت‌نام برنامه احراز هویت تکمیل شده است یا نه
+func (t *TOTPCredential) IsConfirmed() bool {
+	return t.ConfirmedAt != nil
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"github.com/alisiahmansouri/exchange-common/phone"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"time"
@@ -9,7 +10,7 @@ import (
 type User struct {
 	ID              uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
 	Email           *string        `gorm:"uniqueIndex;null" json:"email,omitempty"` // nullable
-	Phone           *string        `gorm:"uniqueIndex;null" json:"phone,omitempty"` // nullable
+	Phone           *string        `gorm:"uniqueIndex;null" json:"phone,omitempty"` // nullable، قالب E.164
 	PasswordHash    string         `gorm:"not null" json:"-"`
 	FullName        string         `json:"full_name"`
 	IsActive        bool           `gorm:"default:true" json:"is_active"`
@@ -28,10 +29,106 @@ func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	now := time.Now()
 	u.CreatedAt = now
 	u.UpdatedAt = now
-	return
+	return u.normalizePhone()
 }
 
+// BeforeUpdate شماره را فقط وقتی ستون phone در همین به‌روزرسانی نوشته می‌شود نرمال می‌کند تا به‌روزرسانی
+// ستون‌های دیگر روی شماره‌های قدیمی ذخیره‌شده (مثل 0912...) خطا ندهد؛ آن شماره‌ها با NormalizeStoredPhones یکسان می‌شوند
 func (u *User) BeforeUpdate(tx *gorm.DB) (err error) {
 	u.UpdatedAt = time.Now()
-	return
+	stmt := tx.Statement
+
+	// Update("phone", v) و Updates(map)
+	if m, ok := stmt.Dest.(map[string]interface{}); ok {
+		for _, key := range []string{"phone", "Phone"} {
+			v, ok := m[key]
+			if !ok {
+				continue
+			}
+			n, err := normalizedPhoneValue(v)
+			if err != nil {
+				return err
+			}
+			m[key] = n
+		}
+		return nil
+	}
+
+	// Save همه‌ی ستون‌ها، از جمله phone، را می‌نویسد
+	if dest, ok := stmt.Dest.(*User); ok && dest == u {
+		return u.normalizePhone()
+	}
+
+	// Updates(User{...})
+	if dest, ok := stmt.Dest.(*User); ok && stmt.Changed("Phone") {
+		return dest.normalizePhone()
+	}
+	if dest, ok := stmt.Dest.(User); ok && stmt.Changed("Phone") {
+		if err := dest.normalizePhone(); err != nil {
+			return err
+		}
+		stmt.SetColumn("Phone", dest.Phone)
+	}
+	return nil
+}
+
+func normalizedPhoneValue(v interface{}) (interface{}, error) {
+	var raw string
+	switch p := v.(type) {
+	case string:
+		raw = p
+	case *string:
+		if p == nil {
+			return v, nil
+		}
+		raw = *p
+	default:
+		return v, nil
+	}
+	if raw == "" {
+		return v, nil
+	}
+	n, err := phone.Normalize(raw)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// normalizePhone شماره را به E.164 تبدیل می‌کند تا uniqueIndex قالب‌های مختلف یک شماره را یکی بداند
+func (u *User) normalizePhone() error {
+	if u.Phone == nil || *u.Phone == "" {
+		return nil
+	}
+	n, err := phone.Normalize(*u.Phone)
+	if err != nil {
+		return err
+	}
+	u.Phone = &n
+	return nil
+}
+
+// NormalizeStoredPhones شماره‌های ذخیره‌شده‌ی غیر E.164 (مثل 0912... پیش از نرمال‌سازی در BeforeCreate)
+// را یک بار به E.164 تبدیل می‌کند و تعداد ردیف‌های به‌روزشده و شناسه‌ی کاربرانی که شماره‌شان قابل تبدیل نیست را برمی‌گرداند.
+// اگر شماره‌ی نرمال‌شده قبلاً برای کاربر دیگری ثبت شده باشد uniqueIndex خطا می‌دهد و تکرار باید دستی رفع شود.
+func NormalizeStoredPhones(db *gorm.DB, batchSize int) (updated int, invalid []uuid.UUID, err error) {
+	var users []User
+	res := db.Unscoped().Model(&User{}).
+		Select("id", "phone").
+		Where("phone IS NOT NULL AND phone <> '' AND phone NOT LIKE ?", "+%").
+		FindInBatches(&users, batchSize, func(tx *gorm.DB, _ int) error {
+			for _, user := range users {
+				n, err := phone.Normalize(*user.Phone)
+				if err != nil {
+					invalid = append(invalid, user.ID)
+					continue
+				}
+				if err := db.Unscoped().Model(&User{}).Where("id = ?", user.ID).UpdateColumn("phone", n).Error; err != nil {
+					return err
+				}
+				updated++
+			}
+			return nil
+		})
+	return updated, invalid, res.Error
 }
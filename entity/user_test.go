@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/phone"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestUserBeforeUpdatePhone(t *testing.T) {
+	const legacy = "09121234567"
+	const e164 = "+989121234567"
+
+	tests := []struct {
+		name    string
+		update  func(db *gorm.DB, u *User) *gorm.DB
+		want    interface{} // مقدار ستون phone در SQL؛ nil یعنی phone نوشته نمی‌شود
+		wantErr error
+	}{
+		{
+			name:   "unrelated column keeps legacy phone",
+			update: func(db *gorm.DB, u *User) *gorm.DB { return db.Model(u).Update("is_active", false) },
+		},
+		{
+			name: "unrelated column with invalid stored phone",
+			update: func(db *gorm.DB, u *User) *gorm.DB {
+				u.Phone = strPtr("garbage")
+				return db.Model(u).Update("full_name", "x")
+			},
+		},
+		{
+			name:   "update phone column",
+			update: func(db *gorm.DB, u *User) *gorm.DB { return db.Model(u).Update("phone", "۰۹۱۲۳۳۳۴۴۵۵") },
+			want:   "+989123334455",
+		},
+		{
+			name: "updates map",
+			update: func(db *gorm.DB, u *User) *gorm.DB {
+				return db.Model(u).Updates(map[string]interface{}{"Phone": "09123334455"})
+			},
+			want: "+989123334455",
+		},
+		{
+			name:   "updates struct",
+			update: func(db *gorm.DB, u *User) *gorm.DB { return db.Model(u).Updates(&User{Phone: strPtr("09123334455")}) },
+			want:   "+989123334455",
+		},
+		{
+			name:   "save writes phone",
+			update: func(db *gorm.DB, u *User) *gorm.DB { return db.Save(u) },
+			want:   e164,
+		},
+		{
+			name:    "invalid new phone",
+			update:  func(db *gorm.DB, u *User) *gorm.DB { return db.Model(u).Update("phone", "12") },
+			wantErr: phone.ErrInvalidNumber,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &User{ID: uuid.New(), Phone: strPtr(legacy)}
+			res := tt.update(dryRunDB(t), u)
+			if !errors.Is(res.Error, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", res.Error, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got := phoneVar(res.Statement)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("phone written as %v, want untouched", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("phone = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// phoneVar مقدار ستون phone در عبارت SET یا nil
+func phoneVar(stmt *gorm.Statement) interface{} {
+	sql := stmt.SQL.String()
+	// DummyDialector ستون‌ها را با ` و مقادیر را با ? می‌نویسد
+	idx, n := -1, 0
+	for i := 0; i+len("`phone`=?") <= len(sql); i++ {
+		if sql[i] == '?' {
+			n++
+		}
+		if sql[i:i+len("`phone`=?")] == "`phone`=?" {
+			idx = n
+			break
+		}
+	}
+	if idx < 0 || idx >= len(stmt.Vars) {
+		return nil
+	}
+	v := stmt.Vars[idx]
+	if p, ok := v.(*string); ok {
+		return *p
+	}
+	return v
+}
+
+func TestNormalizeStoredPhones(t *testing.T) {
+	db := dryRunDB(t)
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	stored := []User{
+		{ID: ids[0], Phone: strPtr("09121234567")},
+		{ID: ids[1], Phone: strPtr("۰۹۳۵۱۱۱۲۲۳۳")},
+		{ID: ids[2], Phone: strPtr("garbage")},
+	}
+	// SELECT به جای پایگاه داده ردیف‌های ثابت برمی‌گرداند
+	if err := db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		if dest, ok := tx.Statement.Dest.(*[]User); ok {
+			*dest = append((*dest)[:0], stored...)
+			tx.RowsAffected = int64(len(stored))
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	written := map[uuid.UUID]interface{}{}
+	if err := db.Callback().Update().After("gorm:update").Register("test:capture", func(tx *gorm.DB) {
+		vars := tx.Statement.Vars
+		written[vars[len(vars)-1].(uuid.UUID)] = vars[0]
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, invalid, err := NormalizeStoredPhones(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != 2 || len(invalid) != 1 || invalid[0] != ids[2] {
+		t.Fatalf("updated = %d, invalid = %v", updated, invalid)
+	}
+	tests := []struct {
+		id   uuid.UUID
+		want interface{}
+	}{
+		{ids[0], "+989121234567"},
+		{ids[1], "+989351112233"},
+		{ids[2], nil},
+	}
+	for _, tt := range tests {
+		if got := written[tt.id]; got != tt.want {
+			t.Errorf("phone of %s = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
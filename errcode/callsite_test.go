@@ -0,0 +1,145 @@
+package errcode
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// codeArg موقعیت آرگومان کد خطا در توابع سازنده‌ی خطا و پاسخ، به تفکیک بسته
+var codeArg = map[string]map[string]int{
+	"richerror": {"New": 2, "Wrap": 3},
+	"errcode":   {"New": 1, "Wrap": 2},
+	"model": {
+		"ErrorResponse":              3,
+		"ErrorResponseWithDetails":   3,
+		"ErrorResponseWithChallenge": 3,
+		"ErrorResponseWithParams":    3,
+		"LocalizedErrorResponse":     2,
+	},
+}
+
+type codeUse struct {
+	pos  string
+	name string // نام ثابت یا رشته‌ی خام
+	code string
+}
+
+// collectCodeUses کدهای ثابتی را که در فراخوانی‌های codeArg زیر root استفاده شده‌اند برمی‌گرداند؛
+// آرگومان‌های غیرثابت (متغیر یا پارامتر) نادیده گرفته می‌شوند و ثابت‌های ناشناخته‌ی consts با code خالی می‌آیند
+func collectCodeUses(t *testing.T, root string, declared map[string]string) []codeUse {
+	t.Helper()
+	fset := token.NewFileSet()
+	var uses []codeUse
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var pkg, fn string
+			switch fun := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				x, ok := fun.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				pkg, fn = x.Name, fun.Sel.Name
+			case *ast.Ident:
+				// فراخوانی بدون پیشوند درون خود بسته
+				pkg, fn = f.Name.Name, fun.Name
+			default:
+				return true
+			}
+			idx, ok := codeArg[pkg][fn]
+			if !ok || idx >= len(call.Args) {
+				return true
+			}
+			use := codeUse{pos: fset.Position(call.Pos()).String()}
+			switch arg := call.Args[idx].(type) {
+			case *ast.BasicLit:
+				if arg.Kind != token.STRING {
+					return true
+				}
+				use.name = arg.Value
+				use.code, _ = strconv.Unquote(arg.Value)
+			case *ast.SelectorExpr:
+				x, ok := arg.X.(*ast.Ident)
+				if !ok || x.Name != "consts" {
+					return true
+				}
+				use.name = "consts." + arg.Sel.Name
+				use.code = declared[arg.Sel.Name]
+			default:
+				return true
+			}
+			uses = append(uses, use)
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uses
+}
+
+func TestCallSiteCodesRegistered(t *testing.T) {
+	declared, err := DeclaredCodes("../consts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uses := collectCodeUses(t, "..", declared)
+	if len(uses) == 0 {
+		t.Fatal("no call sites found; scanner is broken")
+	}
+	for _, u := range uses {
+		switch {
+		case u.code == "":
+			t.Errorf("%s: %s is not a declared error code", u.pos, u.name)
+		case len(Default.Unregistered(u.code)) > 0:
+			t.Errorf("%s: %s (%q) is not registered in errcode", u.pos, u.name, u.code)
+		}
+	}
+}
+
+func TestCollectCodeUses(t *testing.T) {
+	declared := map[string]string{"CodeKnown": "KNOWN", "CodeAlias": "KNOWN"}
+	uses := collectCodeUses(t, "testdata/callsites", declared)
+	want := []struct{ name, code string }{
+		{"consts.CodeKnown", "KNOWN"},
+		{"consts.CodeUnknown", ""},
+		{`"RAW_CODE"`, "RAW_CODE"},
+		{"consts.CodeKnown", "KNOWN"},
+		{"consts.CodeAlias", "KNOWN"},
+	}
+	if len(uses) != len(want) {
+		t.Fatalf("uses = %+v, want %d entries", uses, len(want))
+	}
+	for i, w := range want {
+		if uses[i].name != w.name || uses[i].code != w.code {
+			t.Errorf("use %d = (%s, %q), want (%s, %q)", i, uses[i].name, uses[i].code, w.name, w.code)
+		}
+	}
+}
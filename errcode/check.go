@@ -0,0 +1,139 @@
+package errcode
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+// DeclaredCodes ثابت‌های Code* تعریف‌شده در فایل‌های Go پوشه‌ی dir (معمولاً consts) را به همراه مقدارشان برمی‌گرداند؛
+// ثابت‌هایی که به ثابت دیگری اشاره می‌کنند (CodeAuthInvalidBody = CodeInvalidBody) به مقدار نهایی باز می‌شوند
+func DeclaredCodes(dir string) (map[string]string, error) {
+	values, aliases, err := declaredConsts(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string)
+	for name := range values {
+		if strings.HasPrefix(name, "Code") {
+			out[name] = values[name]
+		}
+	}
+	for name, target := range aliases {
+		if !strings.HasPrefix(name, "Code") {
+			continue
+		}
+		// زنجیره‌ی ارجاع حداکثر به اندازه‌ی تعداد ثابت‌ها دنبال می‌شود تا حلقه باعث گیر کردن نشود
+		for range len(aliases) {
+			next, ok := aliases[target]
+			if !ok {
+				break
+			}
+			target = next
+		}
+		if v, ok := values[target]; ok {
+			out[name] = v
+		}
+	}
+	return out, nil
+}
+
+// declaredConsts ثابت‌های رشته‌ای با مقدار صریح (values) و ثابت‌هایی که به ثابت دیگری اشاره می‌کنند (aliases)
+func declaredConsts(dir string) (values, aliases map[string]string, err error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, nil, err
+	}
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	values = make(map[string]string)
+	aliases = make(map[string]string)
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i >= len(vs.Values) {
+						continue
+					}
+					switch v := vs.Values[i].(type) {
+					case *ast.BasicLit:
+						if v.Kind != token.STRING {
+							continue
+						}
+						if s, err := strconv.Unquote(v.Value); err == nil {
+							values[name.Name] = s
+						}
+					case *ast.Ident:
+						aliases[name.Name] = v.Name
+					}
+				}
+			}
+		}
+	}
+	return values, aliases, nil
+}
+
+// Check همه‌ی ثابت‌های Code* پوشه‌ی constsDir باید در r ثبت شده باشند و هیچ دو ثابتی با مقدار صریح
+// هم‌مقدار نباشند (ارجاع با نام ثابت، مثل CodeAuthInvalidBody = CodeInvalidBody، مجاز است)؛ در غیر این صورت
+// خطای ErrCodeUnregistered یا ErrCodeDuplicateValue با فهرست ثابت‌ها برمی‌گردد (برای اجرا در CI)
+func Check(r *Registry, constsDir string) error {
+	declared, err := DeclaredCodes(constsDir)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for name, code := range declared {
+		if len(r.Unregistered(code)) > 0 {
+			problems = append(problems, fmt.Sprintf("%s=%q", name, code))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%w:\n  %s", model.ErrCodeUnregistered, strings.Join(problems, "\n  "))
+	}
+
+	values, _, err := declaredConsts(constsDir)
+	if err != nil {
+		return err
+	}
+	byValue := make(map[string][]string)
+	for name, code := range values {
+		if strings.HasPrefix(name, "Code") {
+			byValue[code] = append(byValue[code], name)
+		}
+	}
+	for code, names := range byValue {
+		if len(names) > 1 {
+			sort.Strings(names)
+			problems = append(problems, fmt.Sprintf("%q: %s", code, strings.Join(names, ", ")))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w:\n  %s", model.ErrCodeDuplicateValue, strings.Join(problems, "\n  "))
+}
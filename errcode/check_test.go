@@ -0,0 +1,65 @@
+package errcode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/i18n"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+func TestCheck(t *testing.T) {
+	r := NewRegistry(i18n.NewCatalog(i18n.DefaultLang))
+	r.MustRegister(Definition{Code: "KNOWN", Kind: richerror.KindInternal})
+
+	tests := []struct {
+		dir     string
+		wantErr error
+	}{
+		{"testdata/consts_ok", nil},
+		{"testdata/consts_dup", model.ErrCodeDuplicateValue},
+		{"testdata/consts_unreg", model.ErrCodeUnregistered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if err := Check(r, tt.dir); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Check err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckConsts(t *testing.T) {
+	if err := Check(Default, "../consts"); err != nil {
+		t.Fatal(err)
+	}
+	for _, lang := range i18n.Supported {
+		if codes := Default.Untranslated(lang); len(codes) > 0 {
+			t.Errorf("codes without %s message: %v", lang, codes)
+		}
+	}
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	tests := []struct {
+		code      string
+		retryable bool
+	}{
+		{"INTERNAL_ERROR", true},
+		{"UNFREEZE_ERROR", false},
+		{"DEDUCT_FROZEN_ERROR", false},
+		{"TRANSFER_ERROR", false},
+		{"INVALID_BODY", false},
+	}
+	for _, tt := range tests {
+		d, ok := Lookup(tt.code)
+		if !ok {
+			t.Errorf("%s not registered", tt.code)
+			continue
+		}
+		if d.Retryable != tt.retryable {
+			t.Errorf("%s Retryable = %v, want %v", tt.code, d.Retryable, tt.retryable)
+		}
+	}
+}
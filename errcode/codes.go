@@ -0,0 +1,165 @@
+package errcode
+
+import (
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+// هر مقدار کد فقط یک بار آمده است؛ ثابت‌هایی از consts که با نام به ثابت دیگری اشاره می‌کنند
+// (مثل CodeInternal = CodeInternalError) با همان تعریف پوشش داده می‌شوند
+var defaultDefinitions = []Definition{
+	// عمومی
+	{Code: consts.CodeUnauthorized, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeForbidden, Kind: richerror.KindForbidden},
+	{Code: consts.CodeNotFound, Kind: richerror.KindNotFound},
+	{Code: consts.CodeInvalidRequest, Kind: richerror.KindValidation},
+	{Code: consts.CodeInternalError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeTooManyRequests, Kind: richerror.KindTooManyRequests, Retryable: true},
+	{Code: consts.CodeRateLimitUnavailable, Kind: richerror.KindInternal, Retryable: true},
+
+	// احراز هویت
+	{Code: consts.CodeInvalidBody, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidParams, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidEmail, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidPassword, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidOrExpiredCode, Kind: richerror.KindInvalid},
+	{Code: consts.CodeResetPasswordFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeAuthTokenGenFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeUserAlreadyExists, Kind: richerror.KindConflict},
+	{Code: consts.CodeInvalidCredentials, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeTooManyAttempts, Kind: richerror.KindTooManyRequests, Retryable: true},
+	{Code: consts.CodeInvalidUserID, Kind: richerror.KindInvalid},
+	{Code: consts.CodeInvalidRefreshToken, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeTokenEmpty, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeAuthHeaderMissing, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeUserIDTypeInvalid, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeTokenNotFoundInContext, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeJWTRevokeError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeTokenInvalid, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeTokenExpired, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeRefreshTokenReused, Kind: richerror.KindUnauthorized},
+	{Code: consts.Code2FACheckError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeInvalid2FACode, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeLoginThrottled, Kind: richerror.KindTooManyRequests, Retryable: true},
+	{Code: consts.CodeInvalidPurpose, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidChannel, Kind: richerror.KindValidation},
+	{Code: consts.CodeRateLimitCheckFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeRateLimitExceeded, Kind: richerror.KindTooManyRequests, Retryable: true},
+	{Code: consts.Code2FAAttemptCheckFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeForgotPasswordEmailNotFound, Kind: richerror.KindNotFound},
+	{Code: consts.CodeForgotPasswordSendFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeInvalidPhone, Kind: richerror.KindValidation},
+	{Code: consts.CodeSendPhoneVerificationFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeVerifyPhoneFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeResendPhoneVerificationFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeVerifyEmailFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeResendEmailVerificationFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeInvalidEmailOrPhone, Kind: richerror.KindValidation},
+	{Code: consts.CodeEmailAlreadyExists, Kind: richerror.KindConflict},
+	{Code: consts.CodePhoneAlreadyExists, Kind: richerror.KindConflict},
+	{Code: consts.Code2FAResendFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeVerificationUsed, Kind: richerror.KindConflict},
+	{Code: consts.CodeTOTPNotEnrolled, Kind: richerror.KindForbidden},
+	{Code: consts.CodeTOTPAlreadyEnabled, Kind: richerror.KindConflict},
+	{Code: consts.CodeTOTPEnrollFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeBackupCodeGenFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeVerificationIssueFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeVerificationCheckFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeVerificationExpired, Kind: richerror.KindInvalid},
+	{Code: consts.CodeResendCooldown, Kind: richerror.KindTooManyRequests, Retryable: true},
+	{Code: consts.Code2FACodeGen, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.Code2FASendFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeTokenGenFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeLockoutCheckFail, Kind: richerror.KindInternal, Retryable: true},
+
+	// رمز عبور
+	{Code: consts.CodePasswordPolicyViolation, Kind: richerror.KindValidation},
+	{Code: consts.CodePasswordTooShort, Kind: richerror.KindValidation},
+	{Code: consts.CodePasswordTooLong, Kind: richerror.KindValidation},
+	{Code: consts.CodePasswordCharClasses, Kind: richerror.KindValidation},
+	{Code: consts.CodePasswordCommon, Kind: richerror.KindValidation},
+	{Code: consts.CodePasswordHasIdentifier, Kind: richerror.KindValidation},
+	{Code: consts.CodePasswordWeak, Kind: richerror.KindValidation},
+	{Code: consts.CodePasswordReused, Kind: richerror.KindValidation},
+
+	// کپچا و اثبات کار
+	{Code: consts.CodeCaptchaIDGenerationFailed, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeCaptchaGenerationFailed, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeCaptchaStorageFailed, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeInvalidCaptchaID, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidCaptcha, Kind: richerror.KindValidation},
+	{Code: consts.CodeCaptchaEmpty, Kind: richerror.KindValidation},
+	{Code: consts.CodeCaptchaNotFound, Kind: richerror.KindNotFound},
+	{Code: consts.CodeCaptchaWrong, Kind: richerror.KindInvalid},
+	{Code: consts.CodeCaptchaRequired, Kind: richerror.KindForbidden},
+	{Code: consts.CodePoWIssueFail, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodePoWInvalid, Kind: richerror.KindValidation},
+	{Code: consts.CodePoWExpired, Kind: richerror.KindInvalid},
+	{Code: consts.CodePoWInsufficient, Kind: richerror.KindInvalid},
+	{Code: consts.CodePoWReplayed, Kind: richerror.KindConflict},
+
+	// ارز
+	{Code: consts.CodeCurrencyListError, Kind: richerror.KindInternal, Retryable: true},
+
+	// سفارش
+	{Code: consts.CodeOrderInvalidBody, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderCreateError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeOrderNotFound, Kind: richerror.KindNotFound},
+	{Code: consts.CodeOrderUnauthorized, Kind: richerror.KindUnauthorized},
+	{Code: consts.CodeOrderForbidden, Kind: richerror.KindForbidden},
+	{Code: consts.CodeOrderInsufficientFunds, Kind: richerror.KindInsufficientFunds},
+	{Code: consts.CodeOrderInvalidAmount, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderInvalidID, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderCancelError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeOrderCannotBeCanceled, Kind: richerror.KindConflict},
+	{Code: consts.CodeOrderAmountOutOfRange, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderPairNotFoundOrInactive, Kind: richerror.KindNotFound},
+	{Code: consts.CodeOrderWalletNotFoundOrInactive, Kind: richerror.KindNotFound},
+	{Code: consts.CodeOrderConflict, Kind: richerror.KindConflict},
+	{Code: consts.CodeOrderTooManyRequests, Kind: richerror.KindTooManyRequests, Retryable: true},
+	{Code: consts.CodeOrderTimeout, Kind: richerror.KindTimeout, Retryable: true},
+	{Code: consts.CodeOrderInvalidSide, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderClientOrderIDTooLong, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderInvalidLimitPrice, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderPriceNotAllowedForMarket, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderPairIDInvalid, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderInputInvalid, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderInvalidType, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderInvalidStatusFilter, Kind: richerror.KindValidation},
+	{Code: consts.CodeOrderInvalidPagination, Kind: richerror.KindValidation},
+
+	// کیف پول
+	{Code: consts.CodeInvalidWalletID, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidCurrencyID, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidAmount, Kind: richerror.KindValidation},
+	{Code: consts.CodeInvalidWalletStatus, Kind: richerror.KindValidation},
+	{Code: consts.CodeWalletNotFound, Kind: richerror.KindNotFound},
+	{Code: consts.CodeWalletInactive, Kind: richerror.KindForbidden},
+	{Code: consts.CodeDepositError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeWithdrawError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeFreezeError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeUnfreezeError, Kind: richerror.KindInternal},
+	{Code: consts.CodeDeductFrozenError, Kind: richerror.KindInternal},
+	{Code: consts.CodeTransferError, Kind: richerror.KindInternal},
+	{Code: consts.CodeChangeStatusError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeWalletListError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeWalletSummaryError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeWalletHistoryError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeBulkOperationError, Kind: richerror.KindInternal, Retryable: true},
+	{Code: consts.CodeFetchError, Kind: richerror.KindInternal, Retryable: true},
+}
+
+// NewDefaultRegistry رجیستری با همه‌ی کدهای consts
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry(nil)
+	r.MustRegister(defaultDefinitions...)
+	return r
+}
+
+// Default رجیستری پیش‌فرض؛ کد تکراری در defaultDefinitions هنگام راه‌اندازی panic می‌دهد
+var Default = NewDefaultRegistry()
+
+// Lookup مشخصات کد از رجیستری پیش‌فرض
+func Lookup(code string) (Definition, bool) {
+	return Default.Lookup(code)
+}
@@ -0,0 +1,79 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/i18n"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/gin-gonic/gin"
+)
+
+// New RichError با Kind و پیام فارسی ثبت‌شده برای code می‌سازد؛ کد ثبت‌نشده خطای داخلی حساب می‌شود
+func New(op, code string, err error) *richerror.RichError {
+	kind, msg := richerror.KindInternal, code
+	if d, ok := Default.Lookup(code); ok {
+		kind, msg = d.Kind, d.Message(i18n.DefaultLang, nil)
+	}
+	re := richerror.New(op, msg, code, kind, err)
+	re.Caller = caller()
+	return re
+}
+
+// Wrap مانند New ولی برای err نال، نال برمی‌گرداند
+func Wrap(op string, err error, code string) *richerror.RichError {
+	if err == nil {
+		return nil
+	}
+	re := New(op, code, err)
+	re.Caller = caller()
+	return re
+}
+
+// HTTPStatus وضعیت HTTP خطا؛ ابتدا از کد ثبت‌شده و در غیر این صورت از Kind
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var re *richerror.RichError
+	if !errors.As(err, &re) {
+		return http.StatusInternalServerError
+	}
+	if d, ok := Default.Lookup(re.Code); ok {
+		return d.Status
+	}
+	return StatusForKind(re.Kind)
+}
+
+// Retryable تکرار درخواستی که به err منجر شده ممکن است موفق شود
+func Retryable(err error) bool {
+	var re *richerror.RichError
+	if !errors.As(err, &re) {
+		return false
+	}
+	d, ok := Default.Lookup(re.Code)
+	return ok && d.Retryable
+}
+
+// Respond پاسخ خطای err را با وضعیت HTTP کدش می‌نویسد؛ خطای غیر RichError به صورت خطای داخلی گزارش می‌شود
+func Respond(c *gin.Context, err error) {
+	var re *richerror.RichError
+	if !errors.As(err, &re) {
+		model.ErrorResponse(c, http.StatusInternalServerError, "", consts.CodeInternalError)
+		return
+	}
+	model.ErrorResponseWithParams(c, HTTPStatus(re), re.UserMessage, re.Code, re.Params)
+}
+
+// caller موقعیت فراخواننده‌ی New/Wrap (نه خود این بسته) برای فیلد Caller
+func caller() string {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,54 @@
+package errcode
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRespond(t *testing.T) {
+	throttled := richerror.New("op", "محدود", consts.CodeLoginThrottled, richerror.KindTooManyRequests, nil).
+		WithParams(map[string]interface{}{"wait": 2 * time.Minute})
+	tests := []struct {
+		name     string
+		lang     string
+		err      error
+		status   int
+		code     string
+		contains string
+	}{
+		{"plain error is internal", "en", errors.New("boom"), http.StatusInternalServerError, consts.CodeInternalError, ""},
+		{"params reach english message", "en", throttled, http.StatusTooManyRequests, consts.CodeLoginThrottled, "2 minutes"},
+		{"persian keeps user message", "fa", throttled, http.StatusTooManyRequests, consts.CodeLoginThrottled, "محدود"},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.Header.Set("Accept-Language", tt.lang)
+			Respond(c, tt.err)
+
+			var res model.Response
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != tt.status || res.ErrorCode != tt.code {
+				t.Fatalf("status, code = %d, %q; want %d, %q", w.Code, res.ErrorCode, tt.status, tt.code)
+			}
+			if !strings.Contains(res.Message, tt.contains) {
+				t.Fatalf("message %q does not contain %q", res.Message, tt.contains)
+			}
+		})
+	}
+}
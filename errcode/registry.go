@@ -0,0 +1,165 @@
+package errcode
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/alisiahmansouri/exchange-common/i18n"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+// Definition مشخصات یک کد خطا
+type Definition struct {
+	Code      string
+	Kind      richerror.Kind
+	Status    int                  // وضعیت HTTP؛ صفر یعنی StatusForKind(Kind)
+	Retryable bool                 // تکرار همان درخواست (بعداً) ممکن است موفق شود
+	Messages  map[i18n.Lang]string // پیام پیش‌فرض هر زبان؛ زبان‌های خالی از کاتالوگ i18n پر می‌شوند
+}
+
+// Message پیام کد در زبان lang با جایگزینی params (یا پیام زبان پیش‌فرض)
+func (d Definition) Message(lang i18n.Lang, params i18n.Params) string {
+	msg, ok := d.Messages[lang]
+	if !ok {
+		if msg, ok = d.Messages[i18n.DefaultLang]; !ok {
+			return d.Code
+		}
+		lang = i18n.DefaultLang
+	}
+	return i18n.Interpolate(lang, msg, params)
+}
+
+var kindStatus = map[richerror.Kind]int{
+	richerror.KindValidation:        http.StatusBadRequest,
+	richerror.KindInvalid:           http.StatusBadRequest,
+	richerror.KindNotFound:          http.StatusNotFound,
+	richerror.KindUnauthorized:      http.StatusUnauthorized,
+	richerror.KindForbidden:         http.StatusForbidden,
+	richerror.KindConflict:          http.StatusConflict,
+	richerror.KindTooManyRequests:   http.StatusTooManyRequests,
+	richerror.KindTimeout:           http.StatusGatewayTimeout,
+	richerror.KindInsufficientFunds: http.StatusUnprocessableEntity,
+	richerror.KindInternal:          http.StatusInternalServerError,
+}
+
+// StatusForKind وضعیت HTTP متناظر هر Kind؛ Kind ناشناخته خطای داخلی حساب می‌شود
+func StatusForKind(kind richerror.Kind) int {
+	if s, ok := kindStatus[kind]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
+
+// Registry نگاشت کد خطا به مشخصاتش؛ هر مقدار کد فقط یک بار قابل ثبت است
+type Registry struct {
+	mu      sync.RWMutex
+	defs    map[string]Definition
+	catalog *i18n.Catalog
+}
+
+// NewRegistry رجیستری خالی؛ پیام‌های تعریف‌نشده از catalog خوانده و پیام‌های تعریف‌شده در آن ثبت می‌شوند
+// (catalog نال یعنی i18n.Default)
+func NewRegistry(catalog *i18n.Catalog) *Registry {
+	if catalog == nil {
+		catalog = i18n.Default
+	}
+	return &Registry{defs: make(map[string]Definition), catalog: catalog}
+}
+
+// Register کد را ثبت می‌کند؛ کد تکراری (حتی با نام ثابت دیگری در consts) خطای ErrCodeAlreadyRegistered می‌دهد
+func (r *Registry) Register(d Definition) error {
+	if d.Code == "" {
+		return model.ErrCodeEmpty
+	}
+	if _, ok := kindStatus[d.Kind]; !ok {
+		return fmt.Errorf("%w: %s (%q)", model.ErrCodeKindInvalid, d.Code, d.Kind)
+	}
+	if d.Status == 0 {
+		d.Status = StatusForKind(d.Kind)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.defs[d.Code]; ok {
+		return fmt.Errorf("%w: %s", model.ErrCodeAlreadyRegistered, d.Code)
+	}
+
+	msgs := make(map[i18n.Lang]string, len(i18n.Supported))
+	for lang, msg := range d.Messages {
+		msgs[lang] = msg
+		r.catalog.Add(d.Code, lang, msg)
+	}
+	for _, lang := range i18n.Supported {
+		if _, ok := msgs[lang]; ok {
+			continue
+		}
+		if msg, ok := r.catalog.Lookup(lang, d.Code); ok {
+			msgs[lang] = msg
+		}
+	}
+	d.Messages = msgs
+	r.defs[d.Code] = d
+	return nil
+}
+
+// MustRegister مانند Register ولی در صورت خطا panic می‌کند (برای راه‌اندازی)
+func (r *Registry) MustRegister(defs ...Definition) {
+	for _, d := range defs {
+		if err := r.Register(d); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Lookup مشخصات کد
+func (r *Registry) Lookup(code string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.defs[code]
+	return d, ok
+}
+
+// Codes همه‌ی کدهای ثبت‌شده به ترتیب الفبا
+func (r *Registry) Codes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]string, 0, len(r.defs))
+	for code := range r.defs {
+		out = append(out, code)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Unregistered کدهایی از codes که ثبت نشده‌اند (بدون تکرار، به ترتیب ورودی)
+func (r *Registry) Unregistered(codes ...string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []string
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if _, ok := r.defs[code]; ok || seen[code] {
+			continue
+		}
+		seen[code] = true
+		out = append(out, code)
+	}
+	return out
+}
+
+// Untranslated کدهای ثبت‌شده‌ای که در زبان lang پیام ندارند
+func (r *Registry) Untranslated(lang i18n.Lang) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []string
+	for code, d := range r.defs {
+		if _, ok := d.Messages[lang]; !ok {
+			out = append(out, code)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,11 @@
+package sample
+
+func handlers(c any, err error, code string) {
+	_ = richerror.New("op", "msg", consts.CodeKnown, richerror.KindInternal, err)
+	_ = richerror.Wrap("op", err, "msg", consts.CodeUnknown, richerror.KindInternal)
+	_ = richerror.Wrap("op", err, "msg", code, richerror.KindInternal)
+	model.ErrorResponse(c, 400, "msg", "RAW_CODE")
+	model.LocalizedErrorResponse(c, 429, consts.CodeKnown, nil)
+	errcode.Wrap("op", err, consts.CodeAlias)
+	other.New("a", "b", consts.CodeUnknown)
+}
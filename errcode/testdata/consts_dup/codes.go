@@ -0,0 +1,6 @@
+package consts
+
+const (
+	CodeKnown      = "KNOWN"
+	CodeKnownAgain = "KNOWN"
+)
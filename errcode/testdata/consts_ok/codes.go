@@ -0,0 +1,7 @@
+package consts
+
+const (
+	CodeKnown      = "KNOWN"
+	CodeKnownAlias = CodeKnown
+	ErrKnown       = "KNOWN"
+)
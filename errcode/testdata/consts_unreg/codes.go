@@ -0,0 +1,6 @@
+package consts
+
+const (
+	CodeKnown   = "KNOWN"
+	CodeMissing = "MISSING"
+)
@@ -0,0 +1,165 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	eventpb "github.com/alisiahmansouri/exchange-common/event/pb"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"google.golang.org/protobuf/proto"
+)
+
+// تاپیک‌های استاندارد پیام‌رسانی بین سرویس‌ها
+const (
+	TopicOrderEnqueue  = "orders.enqueue"
+	TopicOrderCancel   = "orders.cancel"
+	TopicTradeSettle   = "trades.settle"
+	TopicWalletActions = "wallet.actions"
+)
+
+// Content-Typeهای پشتیبانی‌شده (برای هدر پیام)
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Codec فرمت سیمی پیام‌ها را مشخص می‌کند
+type Codec interface {
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec فرمت فعلی (و پیش‌فرض) همه‌ی تاپیک‌ها
+type JSONCodec struct{}
+
+func (JSONCodec) ContentType() string                        { return ContentTypeJSON }
+func (JSONCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// ProtoCodec structهای model را از طریق پیام‌های eventpb سریال می‌کند.
+// انواع پشتیبانی‌شده: EnqueueOrderEvent, CancelOrderEvent, SettleTradeEvent, WalletAction
+// (و هر proto.Message دلخواه).
+type ProtoCodec struct{}
+
+func (ProtoCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (ProtoCodec) Marshal(v interface{}) ([]byte, error) {
+	var msg proto.Message
+	switch t := v.(type) {
+	case proto.Message:
+		msg = t
+	case *model.SettleTradeEvent:
+		msg = eventpb.FromSettleTradeEvent(t)
+	case model.SettleTradeEvent:
+		msg = eventpb.FromSettleTradeEvent(&t)
+	case *model.WalletAction:
+		msg = eventpb.FromWalletAction(t)
+	case model.WalletAction:
+		msg = eventpb.FromWalletAction(&t)
+	case *model.EnqueueOrderEvent:
+		msg = eventpb.FromEnqueueOrderEvent(t)
+	case model.EnqueueOrderEvent:
+		msg = eventpb.FromEnqueueOrderEvent(&t)
+	case *model.CancelOrderEvent:
+		msg = eventpb.FromCancelOrderEvent(t)
+	case model.CancelOrderEvent:
+		msg = eventpb.FromCancelOrderEvent(&t)
+	default:
+		return nil, fmt.Errorf("protobuf codec: unsupported type %T", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (ProtoCodec) Unmarshal(data []byte, v interface{}) error {
+	switch t := v.(type) {
+	case proto.Message:
+		return proto.Unmarshal(data, t)
+	case *model.SettleTradeEvent:
+		var p eventpb.SettleTradeEvent
+		if err := proto.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		out, err := eventpb.ToSettleTradeEvent(&p)
+		if err != nil {
+			return err
+		}
+		*t = *out
+	case *model.WalletAction:
+		var p eventpb.WalletAction
+		if err := proto.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		out, err := eventpb.ToWalletAction(&p)
+		if err != nil {
+			return err
+		}
+		*t = *out
+	case *model.EnqueueOrderEvent:
+		var p eventpb.EnqueueOrderEvent
+		if err := proto.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		out, err := eventpb.ToEnqueueOrderEvent(&p)
+		if err != nil {
+			return err
+		}
+		*t = *out
+	case *model.CancelOrderEvent:
+		var p eventpb.CancelOrderEvent
+		if err := proto.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		*t = *eventpb.ToCancelOrderEvent(&p)
+	default:
+		return fmt.Errorf("protobuf codec: unsupported type %T", v)
+	}
+	return nil
+}
+
+// Codecs انتخاب codec برای هر تاپیک؛ تاپیک‌های تنظیم‌نشده از codec پیش‌فرض (JSON) استفاده می‌کنند.
+// برای مهاجرت تدریجی، هر تاپیک جداگانه به protobuf سوییچ می‌شود
+// و مصرف‌کننده با ForContentType بر اساس هدر پیام دیکد می‌کند.
+type Codecs struct {
+	mu       sync.RWMutex
+	fallback Codec
+	byTopic  map[string]Codec
+}
+
+func NewCodecs(fallback Codec) *Codecs {
+	if fallback == nil {
+		fallback = JSONCodec{}
+	}
+	return &Codecs{fallback: fallback, byTopic: make(map[string]Codec)}
+}
+
+// Set codec یک تاپیک را تعیین می‌کند
+func (c *Codecs) Set(topic string, codec Codec) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byTopic[topic] = codec
+}
+
+// For codec تولیدکننده برای تاپیک
+func (c *Codecs) For(topic string) Codec {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if codec, ok := c.byTopic[topic]; ok {
+		return codec
+	}
+	return c.fallback
+}
+
+// ForContentType codec مصرف‌کننده بر اساس Content-Type پیام دریافتی؛
+// در نبود هدر، codec تاپیک استفاده می‌شود.
+func (c *Codecs) ForContentType(topic, contentType string) Codec {
+	switch contentType {
+	case ContentTypeJSON:
+		return JSONCodec{}
+	case ContentTypeProtobuf:
+		return ProtoCodec{}
+	default:
+		return c.For(topic)
+	}
+}
@@ -0,0 +1,118 @@
+package event
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+func roundTrip(t *testing.T, codec Codec, in, out interface{}) {
+	t.Helper()
+	data, err := codec.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.Unmarshal(data, out); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProtoCodecOrderSettlementID(t *testing.T) {
+	nilID := uuid.Nil
+	id := uuid.New()
+	now := time.Unix(1_700_000_000, 123).UTC()
+	tests := []struct {
+		name       string
+		settlement *uuid.UUID
+	}{
+		{"absent", nil},
+		{"pointer to nil uuid", &nilID},
+		{"set", &id},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := model.EnqueueOrderEvent{Order: entity.Order{
+				ID:           uuid.New(),
+				UserID:       uuid.New(),
+				PairID:       uuid.New(),
+				Amount:       0.1,
+				Price:        12345.678,
+				SettlementID: tt.settlement,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}}
+			var out model.EnqueueOrderEvent
+			roundTrip(t, ProtoCodec{}, &in, &out)
+			got := out.Order.SettlementID
+			if (got == nil) != (tt.settlement == nil) || (got != nil && *got != *tt.settlement) {
+				t.Fatalf("SettlementID = %v, want %v", got, tt.settlement)
+			}
+			if !reflect.DeepEqual(in, out) {
+				t.Fatalf("round trip mismatch:\n in = %+v\nout = %+v", in, out)
+			}
+		})
+	}
+}
+
+func TestCodecsRoundTrip(t *testing.T) {
+	settle := model.SettleTradeEvent{
+		EventID:      uuid.New(),
+		Version:      1,
+		PairID:       uuid.New(),
+		Sequence:     42,
+		TakerOrderID: uuid.New(),
+		MakerOrderID: uuid.New(),
+		MatchAmount:  0.30000000000000004,
+		TradePrice:   1e-8,
+		TraceID:      "t",
+		CreatedAt:    time.Unix(1_700_000_000, 0).UTC(),
+	}
+	action := model.WalletAction{
+		ActionID:  uuid.New(),
+		UserID:    uuid.New(),
+		WalletID:  uuid.New(),
+		Amount:    99.99,
+		Action:    "freeze",
+		Reason:    "order",
+		CreatedAt: time.Unix(1_700_000_000, 0).UTC(),
+	}
+	for _, codec := range []Codec{JSONCodec{}, ProtoCodec{}} {
+		t.Run(codec.ContentType(), func(t *testing.T) {
+			var gotSettle model.SettleTradeEvent
+			roundTrip(t, codec, &settle, &gotSettle)
+			if !reflect.DeepEqual(settle, gotSettle) {
+				t.Fatalf("settle:\n in = %+v\nout = %+v", settle, gotSettle)
+			}
+			var gotAction model.WalletAction
+			roundTrip(t, codec, action, &gotAction)
+			if !reflect.DeepEqual(action, gotAction) {
+				t.Fatalf("action:\n in = %+v\nout = %+v", action, gotAction)
+			}
+		})
+	}
+}
+
+func TestCodecsForContentType(t *testing.T) {
+	c := NewCodecs(nil)
+	c.Set(TopicTradeSettle, ProtoCodec{})
+	tests := []struct {
+		topic, contentType, want string
+	}{
+		{TopicTradeSettle, "", ContentTypeProtobuf},
+		{TopicOrderCancel, "", ContentTypeJSON},
+		{TopicTradeSettle, ContentTypeJSON, ContentTypeJSON},
+		{TopicOrderCancel, ContentTypeProtobuf, ContentTypeProtobuf},
+	}
+	for _, tt := range tests {
+		if got := c.ForContentType(tt.topic, tt.contentType).ContentType(); got != tt.want {
+			t.Errorf("ForContentType(%s, %q) = %s, want %s", tt.topic, tt.contentType, got, tt.want)
+		}
+	}
+	if _, err := (ProtoCodec{}).Marshal(struct{}{}); err == nil {
+		t.Error("ProtoCodec accepted unsupported type")
+	}
+}
@@ -0,0 +1,80 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/util"
+)
+
+// Envelope پاکت استاندارد همه‌ی رویدادهای بین‌سرویسی؛
+// مصرف‌کننده ابتدا Type و Version را می‌خواند و سپس Payload را با Registry دیکد می‌کند.
+type Envelope struct {
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	Payload       json.RawMessage `json:"payload"`
+	TraceID       string          `json:"trace_id,omitempty"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+// NewEnvelope payload را به JSON کانونیکال تبدیل و داخل پاکت قرار می‌دهد
+func NewEnvelope(eventType string, version int, payload interface{}) (Envelope, error) {
+	body, err := CanonicalJSON(payload)
+	if err != nil {
+		return Envelope{}, err
+	}
+	return Envelope{
+		Type:       eventType,
+		Version:    version,
+		Payload:    body,
+		OccurredAt: util.NowUTC(),
+	}, nil
+}
+
+// WithTrace شناسه‌های tracing را روی پاکت ست می‌کند
+func (e Envelope) WithTrace(traceID, correlationID string) Envelope {
+	e.TraceID = traceID
+	e.CorrelationID = correlationID
+	return e
+}
+
+// Marshal پاکت را به JSON کانونیکال تبدیل می‌کند
+func (e Envelope) Marshal() ([]byte, error) {
+	return CanonicalJSON(e)
+}
+
+// UnmarshalEnvelope پاکت را از JSON می‌خواند
+func UnmarshalEnvelope(data []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Envelope{}, err
+	}
+	return env, nil
+}
+
+// EnvelopeFromOutbox پاکت ذخیره‌شده در ردیف Outbox را برمی‌گرداند.
+// ردیف‌های قدیمی که payload خام (بدون پاکت) دارند هم پشتیبانی می‌شوند.
+func EnvelopeFromOutbox(o *entity.OutboxEvent) (Envelope, error) {
+	env, err := UnmarshalEnvelope(o.Payload)
+	if err != nil {
+		return Envelope{}, err
+	}
+	if env.Type != "" && len(env.Payload) > 0 {
+		return env, nil
+	}
+
+	// ردیف قدیمی: کل payload همان بدنه‌ی رویداد است
+	version := o.Version
+	if version == 0 {
+		version = DefaultVersion
+	}
+	return Envelope{
+		Type:       o.EventType,
+		Version:    version,
+		Payload:    json.RawMessage(bytes.Clone(o.Payload)),
+		OccurredAt: o.CreatedAt,
+	}, nil
+}
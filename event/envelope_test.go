@@ -0,0 +1,70 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+)
+
+func TestEnvelopeMarshalRoundTrip(t *testing.T) {
+	env, err := NewEnvelope("widget", 2, map[string]int{"b": 1, "a": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env = env.WithTrace("t-1", "c-1")
+	if string(env.Payload) != `{"a":2,"b":1}` {
+		t.Fatalf("payload = %s", env.Payload)
+	}
+	data, err := env.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalEnvelope(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "widget" || got.Version != 2 || got.TraceID != "t-1" || got.CorrelationID != "c-1" ||
+		string(got.Payload) != string(env.Payload) || !got.OccurredAt.Equal(env.OccurredAt) {
+		t.Fatalf("round trip = %+v, want %+v", got, env)
+	}
+}
+
+func TestEnvelopeFromOutbox(t *testing.T) {
+	created := time.Unix(1_700_000_000, 0).UTC()
+	wrapped, _ := NewEnvelope("widget", 3, map[string]int{"n": 1})
+	wrappedJSON, _ := wrapped.Marshal()
+
+	tests := []struct {
+		name    string
+		row     entity.OutboxEvent
+		typ     string
+		version int
+		payload string
+		wantErr bool
+	}{
+		{"envelope row", entity.OutboxEvent{EventType: "other", Payload: wrappedJSON}, "widget", 3, `{"n":1}`, false},
+		{"legacy row", entity.OutboxEvent{EventType: "widget", Version: 2, Payload: []byte(`{"n":1}`), CreatedAt: created}, "widget", 2, `{"n":1}`, false},
+		{"legacy row without version", entity.OutboxEvent{EventType: "widget", Payload: []byte(`{"n":1}`)}, "widget", DefaultVersion, `{"n":1}`, false},
+		{"invalid json", entity.OutboxEvent{EventType: "widget", Payload: []byte(`{`)}, "", 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := EnvelopeFromOutbox(&tt.row)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if env.Type != tt.typ || env.Version != tt.version || string(env.Payload) != tt.payload {
+				t.Fatalf("env = %s v%d %s; want %s v%d %s", env.Type, env.Version, env.Payload, tt.typ, tt.version, tt.payload)
+			}
+			var v map[string]int
+			if err := json.Unmarshal(env.Payload, &v); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
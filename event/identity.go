@@ -0,0 +1,170 @@
+package event
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+// انواع رویدادهایی که در Outbox ثبت می‌شوند
+const (
+	TypeSettleTrade  = "settle_trade"
+	TypeEnqueueOrder = "enqueue_order"
+	TypeCancelOrder  = "cancel_order"
+	TypeWalletAction = "wallet_action"
+)
+
+// Namespace فضای نام UUIDv5 برای EventIDهای قطعی؛ هرگز تغییر نکند،
+// وگرنه شناسه‌های تولیدشده با نسخه‌های قبلی هم‌خوانی نخواهند داشت.
+var Namespace = uuid.MustParse("6f1c3b52-8f0e-5d4a-9a57-3e2b1c7d9e04")
+
+// Key ورودی‌های تعیین‌کننده هویت یک رویداد است.
+// ترتیب OrderIDs اهمیت دارد (مثلاً taker سپس maker).
+type Key struct {
+	EventType string
+	PairID    uuid.UUID
+	Sequence  uint64
+	OrderIDs  []uuid.UUID
+}
+
+// canonical نمایش رشته‌ای یکتا و پایدار از Key
+func (k Key) canonical() string {
+	var b strings.Builder
+	b.WriteString(k.EventType)
+	b.WriteByte('|')
+	b.WriteString(k.PairID.String())
+	b.WriteByte('|')
+	b.WriteString(strconv.FormatUint(k.Sequence, 10))
+	for _, id := range k.OrderIDs {
+		b.WriteByte('|')
+		b.WriteString(id.String())
+	}
+	return b.String()
+}
+
+// DedupKey هش SHA-256 (hex، ۶۴ کاراکتر) برای ستون OutboxEvent.DedupKey
+func (k Key) DedupKey() string {
+	sum := sha256.Sum256([]byte(k.canonical()))
+	return hex.EncodeToString(sum[:])
+}
+
+// EventID شناسه UUIDv5 قطعی؛ تولید دوباره‌ی همان رویداد همان شناسه را می‌دهد.
+func (k Key) EventID() uuid.UUID {
+	return uuid.NewSHA1(Namespace, []byte(k.canonical()))
+}
+
+// SettleTradeKey کلید هویتی رویداد تسویه‌ی معامله
+func SettleTradeKey(e *model.SettleTradeEvent) Key {
+	return Key{
+		EventType: TypeSettleTrade,
+		PairID:    e.PairID,
+		Sequence:  e.Sequence,
+		OrderIDs:  []uuid.UUID{e.TakerOrderID, e.MakerOrderID},
+	}
+}
+
+// StampSettleTrade مقدار EventID و Version را به‌صورت قطعی روی رویداد ست می‌کند
+// و DedupKey متناظر را برمی‌گرداند.
+func StampSettleTrade(e *model.SettleTradeEvent) string {
+	k := SettleTradeKey(e)
+	e.EventID = k.EventID()
+	if e.Version == 0 {
+		e.Version = model.SettleTradeEventVersion
+	}
+	return k.DedupKey()
+}
+
+// CanonicalJSON مقدار را به JSON کانونیکال تبدیل می‌کند:
+// کلیدها مرتب، بدون فاصله‌ی اضافه و بدون escape کردن HTML.
+// اعداد بدون تغییر (json.Number) حفظ می‌شوند.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	raw, err := marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, generic); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PayloadHash هش SHA-256 (hex) از JSON کانونیکال؛ برای تطبیق محتوای دو رویداد
+func PayloadHash(v interface{}) (string, error) {
+	b, err := CanonicalJSON(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v interface{}) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			kb, err := marshal(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(kb)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, t[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		buf.WriteString(t.String())
+	default:
+		b, err := marshal(t)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+	return nil
+}
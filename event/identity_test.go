@@ -0,0 +1,96 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+func TestKeyIdentity(t *testing.T) {
+	pair := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	taker := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	maker := uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	base := Key{EventType: TypeSettleTrade, PairID: pair, Sequence: 7, OrderIDs: []uuid.UUID{taker, maker}}
+
+	tests := []struct {
+		name string
+		key  Key
+		same bool
+	}{
+		{"identical", Key{EventType: TypeSettleTrade, PairID: pair, Sequence: 7, OrderIDs: []uuid.UUID{taker, maker}}, true},
+		{"other sequence", Key{EventType: TypeSettleTrade, PairID: pair, Sequence: 8, OrderIDs: []uuid.UUID{taker, maker}}, false},
+		{"other type", Key{EventType: TypeCancelOrder, PairID: pair, Sequence: 7, OrderIDs: []uuid.UUID{taker, maker}}, false},
+		{"swapped orders", Key{EventType: TypeSettleTrade, PairID: pair, Sequence: 7, OrderIDs: []uuid.UUID{maker, taker}}, false},
+		{"missing order", Key{EventType: TypeSettleTrade, PairID: pair, Sequence: 7, OrderIDs: []uuid.UUID{taker}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.EventID() == base.EventID(); got != tt.same {
+				t.Fatalf("EventID equal = %v, want %v", got, tt.same)
+			}
+			if got := tt.key.DedupKey() == base.DedupKey(); got != tt.same {
+				t.Fatalf("DedupKey equal = %v, want %v", got, tt.same)
+			}
+		})
+	}
+	if id := base.EventID(); id.Version() != 5 {
+		t.Fatalf("EventID version = %d, want 5", id.Version())
+	}
+	if len(base.DedupKey()) != 64 {
+		t.Fatalf("DedupKey length = %d, want 64", len(base.DedupKey()))
+	}
+}
+
+func TestStampSettleTrade(t *testing.T) {
+	e := &model.SettleTradeEvent{PairID: uuid.New(), Sequence: 3, TakerOrderID: uuid.New(), MakerOrderID: uuid.New()}
+	dedup := StampSettleTrade(e)
+	if e.EventID != SettleTradeKey(e).EventID() || dedup != SettleTradeKey(e).DedupKey() {
+		t.Fatal("stamp does not match key")
+	}
+	if e.Version != model.SettleTradeEventVersion {
+		t.Fatalf("Version = %d, want %d", e.Version, model.SettleTradeEventVersion)
+	}
+	e.Version = 99
+	StampSettleTrade(e)
+	if e.Version != 99 {
+		t.Fatal("explicit version overwritten")
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{"sorted keys", map[string]interface{}{"b": 1, "a": 2}, `{"a":2,"b":1}`},
+		{"nested", map[string]interface{}{"z": []interface{}{map[string]interface{}{"y": 1, "x": 2}}}, `{"z":[{"x":2,"y":1}]}`},
+		{"no html escape", map[string]string{"s": "<a&b>"}, `{"s":"<a&b>"}`},
+		{"numbers kept", map[string]interface{}{"n": 0.1, "big": 12345678901234567}, `{"big":12345678901234567,"n":0.1}`},
+		{"struct field order", struct {
+			B int `json:"b"`
+			A int `json:"a"`
+		}{1, 2}, `{"a":2,"b":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	h1, _ := PayloadHash(map[string]int{"a": 1, "b": 2})
+	h2, _ := PayloadHash(struct {
+		B int `json:"b"`
+		A int `json:"a"`
+	}{2, 1})
+	if h1 != h2 {
+		t.Fatal("PayloadHash depends on key order")
+	}
+}
@@ -0,0 +1,96 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/util"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultInboxRetention مدت نگهداری ردیف‌های inbox؛ باید از بیشترین فاصله‌ی redelivery بروکر بزرگ‌تر باشد
+const DefaultInboxRetention = 7 * 24 * time.Hour
+
+// HandlerFunc تغییر business را روی تراکنش داده‌شده انجام می‌دهد
+type HandlerFunc func(tx *gorm.DB) error
+
+// Inbox مصرف دقیقاً-یک‌باره: شناسه‌ی پیام در همان تراکنش تغییر business ثبت می‌شود،
+// پس پیام تحویل‌شده‌ی تکراری (مثلاً SettleTradeEvent یا WalletAction) بدون اثر رد می‌شود.
+type Inbox struct {
+	db       *gorm.DB
+	consumer string
+}
+
+func NewInbox(db *gorm.DB, consumer string) *Inbox {
+	return &Inbox{db: db, consumer: consumer}
+}
+
+// Process اگر پیام قبلاً پردازش نشده باشد fn را در یک تراکنش اجرا می‌کند.
+// خروجی processed=false یعنی پیام تکراری بود و fn اجرا نشد (باید ack شود).
+// خطای fn کل تراکنش (از جمله ردیف inbox) را rollback می‌کند تا پیام دوباره قابل پردازش باشد.
+func (i *Inbox) Process(ctx context.Context, messageID uuid.UUID, eventType string, fn HandlerFunc) (bool, error) {
+	if messageID == uuid.Nil {
+		return false, model.ErrEventMessageIDInvalid
+	}
+	processed := false
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := entity.InboxMessage{
+			Consumer:    i.consumer,
+			MessageID:   messageID,
+			EventType:   eventType,
+			ProcessedAt: util.NowUTC(),
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		processed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return processed, nil
+}
+
+// ProcessSettleTrade رویداد تسویه را با EventID آن یک‌بار پردازش می‌کند
+func (i *Inbox) ProcessSettleTrade(ctx context.Context, e *model.SettleTradeEvent, fn HandlerFunc) (bool, error) {
+	return i.Process(ctx, e.EventID, TypeSettleTrade, fn)
+}
+
+// ProcessWalletAction اکشن کیف پول را با ActionID آن یک‌بار پردازش می‌کند
+func (i *Inbox) ProcessWalletAction(ctx context.Context, a *model.WalletAction, fn HandlerFunc) (bool, error) {
+	return i.Process(ctx, a.ActionID, TypeWalletAction, fn)
+}
+
+// Seen بررسی می‌کند پیام قبلاً پردازش شده یا نه (بدون قفل؛ فقط برای کوتاه‌کردن مسیر)
+func (i *Inbox) Seen(ctx context.Context, messageID uuid.UUID) (bool, error) {
+	var count int64
+	err := i.db.WithContext(ctx).Model(&entity.InboxMessage{}).
+		Where("consumer = ? AND message_id = ?", i.consumer, messageID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Cleanup ردیف‌های قدیمی‌تر از retention را حذف و تعداد حذف‌شده را برمی‌گرداند.
+// retention صفر یعنی DefaultInboxRetention.
+func (i *Inbox) Cleanup(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		retention = DefaultInboxRetention
+	}
+	cutoff := util.NowUTC().Add(-retention)
+	res := i.db.WithContext(ctx).
+		Where("consumer = ? AND processed_at < ?", i.consumer, cutoff).
+		Delete(&entity.InboxMessage{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,168 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+var errNoDB = errors.New("fake pool: no database")
+
+type inboxKey struct {
+	consumer string
+	id       uuid.UUID
+}
+
+// fakePool جدول inbox_messages را در حافظه شبیه‌سازی می‌کند؛ gorm در حالت DryRun فقط SQL می‌سازد و
+// callback آزمایشی ردیف را با قید یکتایی (consumer, message_id) در تراکنش جاری ثبت می‌کند
+type fakePool struct {
+	rows      map[inboxKey]bool
+	rollbacks int
+}
+
+type fakeTx struct {
+	pool    *fakePool
+	pending map[inboxKey]bool
+}
+
+func (*fakePool) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, errNoDB }
+func (*fakePool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errNoDB
+}
+func (*fakePool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDB
+}
+func (*fakePool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row { return nil }
+func (p *fakePool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{pool: p, pending: map[inboxKey]bool{}}, nil
+}
+
+func (*fakeTx) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, errNoDB }
+func (*fakeTx) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errNoDB
+}
+func (*fakeTx) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDB
+}
+func (*fakeTx) QueryRowContext(context.Context, string, ...interface{}) *sql.Row { return nil }
+func (t *fakeTx) Commit() error {
+	for k := range t.pending {
+		t.pool.rows[k] = true
+	}
+	return nil
+}
+func (t *fakeTx) Rollback() error {
+	t.pool.rollbacks++
+	return nil
+}
+
+func newFakeInboxDB(t *testing.T) (*gorm.DB, *fakePool) {
+	t.Helper()
+	pool := &fakePool{rows: map[inboxKey]bool{}}
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true, ConnPool: pool})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Callback().Create().After("gorm:create").Register("test:inbox_unique", func(tx *gorm.DB) {
+		row, ok := tx.Statement.Dest.(*entity.InboxMessage)
+		ftx, inTx := tx.Statement.ConnPool.(*fakeTx)
+		if !ok || !inTx {
+			return
+		}
+		k := inboxKey{row.Consumer, row.MessageID}
+		if pool.rows[k] || ftx.pending[k] {
+			tx.RowsAffected = 0
+			return
+		}
+		ftx.pending[k] = true
+		tx.RowsAffected = 1
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, pool
+}
+
+func TestInboxProcess(t *testing.T) {
+	ctx := context.Background()
+	db, pool := newFakeInboxDB(t)
+	inbox := NewInbox(db, "settlement")
+	other := NewInbox(db, "wallet")
+	id := uuid.New()
+	failure := errors.New("business failure")
+
+	calls := 0
+	ok := func(*gorm.DB) error { calls++; return nil }
+	fail := func(*gorm.DB) error { calls++; return failure }
+
+	tests := []struct {
+		name      string
+		inbox     *Inbox
+		id        uuid.UUID
+		fn        HandlerFunc
+		processed bool
+		wantErr   error
+		calls     int
+	}{
+		{"nil id", inbox, uuid.Nil, ok, false, model.ErrEventMessageIDInvalid, 0},
+		{"handler error rolls back", inbox, id, fail, false, failure, 1},
+		{"retry after rollback", inbox, id, ok, true, nil, 2},
+		{"duplicate skipped", inbox, id, ok, false, nil, 2},
+		{"other consumer", other, id, ok, true, nil, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, err := tt.inbox.Process(ctx, tt.id, TypeSettleTrade, tt.fn)
+			if processed != tt.processed || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Process = %v, %v; want %v, %v", processed, err, tt.processed, tt.wantErr)
+			}
+			if calls != tt.calls {
+				t.Fatalf("handler calls = %d, want %d", calls, tt.calls)
+			}
+		})
+	}
+	if pool.rollbacks != 1 {
+		t.Fatalf("rollbacks = %d, want 1", pool.rollbacks)
+	}
+
+	// EventID رویداد تسویه کلید inbox است
+	e := &model.SettleTradeEvent{EventID: uuid.New()}
+	for i, want := range []bool{true, false} {
+		if processed, err := inbox.ProcessSettleTrade(ctx, e, ok); err != nil || processed != want {
+			t.Fatalf("ProcessSettleTrade #%d = %v, %v; want %v", i+1, processed, err, want)
+		}
+	}
+}
+
+func TestInboxCleanupScopedToConsumer(t *testing.T) {
+	db, _ := newFakeInboxDB(t)
+	var sqlText string
+	var vars []interface{}
+	if err := db.Callback().Delete().After("gorm:delete").Register("test:capture", func(tx *gorm.DB) {
+		sqlText, vars = tx.Statement.SQL.String(), tx.Statement.Vars
+	}); err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now().UTC().Add(-DefaultInboxRetention)
+	if _, err := NewInbox(db, "settlement").Cleanup(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sqlText, "consumer = ?") || !strings.Contains(sqlText, "processed_at < ?") {
+		t.Fatalf("SQL = %s", sqlText)
+	}
+	if len(vars) != 2 || vars[0] != "settlement" {
+		t.Fatalf("vars = %v", vars)
+	}
+	if cutoff, ok := vars[1].(time.Time); !ok || cutoff.Before(before.Add(-time.Second)) || cutoff.After(before.Add(time.Second)) {
+		t.Fatalf("cutoff = %v, want about %v", vars[1], before)
+	}
+}
@@ -0,0 +1,41 @@
+package event
+
+import (
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/util"
+)
+
+// NewOutboxEvent یک ردیف Outbox با DedupKey و Sequence قطعی می‌سازد و پاکت را در Payload ذخیره می‌کند.
+// شناسه‌ی ردیف همان EventID رویداد است تا تولید دوباره، ردیف تکراری نسازد.
+func NewOutboxEvent(k Key, env Envelope) (entity.OutboxEvent, error) {
+	body, err := env.Marshal()
+	if err != nil {
+		return entity.OutboxEvent{}, err
+	}
+	return entity.OutboxEvent{
+		ID:        k.EventID(),
+		EventType: k.EventType,
+		DedupKey:  k.DedupKey(),
+		PairID:    k.PairID,
+		Sequence:  k.Sequence,
+		Version:   env.Version,
+		Payload:   body,
+		Status:    entity.OutboxStatusPending,
+		CreatedAt: util.NowUTC(),
+	}, nil
+}
+
+// NewSettleTradeOutbox رویداد تسویه را مهر می‌کند (EventID/Version) و ردیف Outbox آن را می‌سازد.
+func NewSettleTradeOutbox(e *model.SettleTradeEvent) (entity.OutboxEvent, error) {
+	StampSettleTrade(e)
+	env, err := NewEnvelope(TypeSettleTrade, e.Version, e)
+	if err != nil {
+		return entity.OutboxEvent{}, err
+	}
+	env = env.WithTrace(e.TraceID, e.CorrelationID)
+	if !e.CreatedAt.IsZero() {
+		env.OccurredAt = e.CreatedAt
+	}
+	return NewOutboxEvent(SettleTradeKey(e), env)
+}
@@ -0,0 +1,316 @@
+package eventpb
+
+//go:generate protoc --proto_path=../.. --go_out=../.. --go_opt=paths=source_relative event/pb/events.proto
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// تبدیل‌های بدون اتلاف بین structهای فعلی (JSON) و پیام‌های protobuf.
+// اعشاری‌ها با کوتاه‌ترین نمایش دسیمال (strconv 'f', -1) منتقل می‌شوند که دقیقاً به همان float64 برمی‌گردد.
+// زمان‌ها به صورت google.protobuf.Timestamp (لحظه‌ی مطلق) منتقل می‌شوند: منطقه‌ی زمانی و offset حفظ نمی‌شود
+// و مقدار بازگشتی همیشه UTC است؛ t.Equal برقرار است ولی == برای زمان غیر UTC نه.
+
+// --- SettleTradeEvent ---
+
+func FromSettleTradeEvent(e *model.SettleTradeEvent) *SettleTradeEvent {
+	if e == nil {
+		return nil
+	}
+	return &SettleTradeEvent{
+		EventId:       uuidBytes(e.EventID),
+		Version:       int32(e.Version),
+		PairId:        uuidBytes(e.PairID),
+		Sequence:      e.Sequence,
+		TakerOrderId:  uuidBytes(e.TakerOrderID),
+		MakerOrderId:  uuidBytes(e.MakerOrderID),
+		MatchAmount:   decimalString(e.MatchAmount),
+		TradePrice:    decimalString(e.TradePrice),
+		TraceId:       e.TraceID,
+		CorrelationId: e.CorrelationID,
+		CreatedAt:     timestamppb.New(e.CreatedAt),
+	}
+}
+
+func ToSettleTradeEvent(p *SettleTradeEvent) (*model.SettleTradeEvent, error) {
+	if p == nil {
+		return nil, nil
+	}
+	var (
+		out model.SettleTradeEvent
+		err error
+	)
+	if out.EventID, err = parseUUID("event_id", p.EventId); err != nil {
+		return nil, err
+	}
+	if out.PairID, err = parseUUID("pair_id", p.PairId); err != nil {
+		return nil, err
+	}
+	if out.TakerOrderID, err = parseUUID("taker_order_id", p.TakerOrderId); err != nil {
+		return nil, err
+	}
+	if out.MakerOrderID, err = parseUUID("maker_order_id", p.MakerOrderId); err != nil {
+		return nil, err
+	}
+	if out.MatchAmount, err = parseDecimal("match_amount", p.MatchAmount); err != nil {
+		return nil, err
+	}
+	if out.TradePrice, err = parseDecimal("trade_price", p.TradePrice); err != nil {
+		return nil, err
+	}
+	out.Version = int(p.Version)
+	out.Sequence = p.Sequence
+	out.TraceID = p.TraceId
+	out.CorrelationID = p.CorrelationId
+	out.CreatedAt = fromTimestamp(p.CreatedAt)
+	return &out, nil
+}
+
+// --- WalletAction ---
+
+func FromWalletAction(w *model.WalletAction) *WalletAction {
+	if w == nil {
+		return nil
+	}
+	return &WalletAction{
+		ActionId:  uuidBytes(w.ActionID),
+		UserId:    uuidBytes(w.UserID),
+		WalletId:  uuidBytes(w.WalletID),
+		Amount:    decimalString(w.Amount),
+		Action:    string(w.Action),
+		Reason:    w.Reason,
+		OrderId:   uuidBytes(w.OrderID),
+		PairId:    uuidBytes(w.PairID),
+		Ref:       w.Ref,
+		CreatedAt: timestamppb.New(w.CreatedAt),
+		TraceId:   w.TraceID,
+		Source:    w.Source,
+	}
+}
+
+func ToWalletAction(p *WalletAction) (*model.WalletAction, error) {
+	if p == nil {
+		return nil, nil
+	}
+	var (
+		out model.WalletAction
+		err error
+	)
+	if out.ActionID, err = parseUUID("action_id", p.ActionId); err != nil {
+		return nil, err
+	}
+	if out.UserID, err = parseUUID("user_id", p.UserId); err != nil {
+		return nil, err
+	}
+	if out.WalletID, err = parseUUID("wallet_id", p.WalletId); err != nil {
+		return nil, err
+	}
+	if out.OrderID, err = parseUUID("order_id", p.OrderId); err != nil {
+		return nil, err
+	}
+	if out.PairID, err = parseUUID("pair_id", p.PairId); err != nil {
+		return nil, err
+	}
+	if out.Amount, err = parseDecimal("amount", p.Amount); err != nil {
+		return nil, err
+	}
+	out.Action = model.WalletActionType(p.Action)
+	out.Reason = p.Reason
+	out.Ref = p.Ref
+	out.CreatedAt = fromTimestamp(p.CreatedAt)
+	out.TraceID = p.TraceId
+	out.Source = p.Source
+	return &out, nil
+}
+
+// --- CancelOrderEvent ---
+
+func FromCancelOrderEvent(e *model.CancelOrderEvent) *CancelOrderEvent {
+	if e == nil {
+		return nil
+	}
+	return &CancelOrderEvent{OrderId: e.OrderID}
+}
+
+func ToCancelOrderEvent(p *CancelOrderEvent) *model.CancelOrderEvent {
+	if p == nil {
+		return nil
+	}
+	return &model.CancelOrderEvent{OrderID: p.OrderId}
+}
+
+// --- EnqueueOrderEvent / Order ---
+
+func FromEnqueueOrderEvent(e *model.EnqueueOrderEvent) *EnqueueOrderEvent {
+	if e == nil {
+		return nil
+	}
+	return &EnqueueOrderEvent{Order: FromOrder(&e.Order)}
+}
+
+func ToEnqueueOrderEvent(p *EnqueueOrderEvent) (*model.EnqueueOrderEvent, error) {
+	if p == nil {
+		return nil, nil
+	}
+	out := &model.EnqueueOrderEvent{}
+	if p.Order == nil {
+		return out, nil
+	}
+	order, err := ToOrder(p.Order)
+	if err != nil {
+		return nil, err
+	}
+	out.Order = *order
+	return out, nil
+}
+
+func FromOrder(o *entity.Order) *Order {
+	if o == nil {
+		return nil
+	}
+	out := &Order{
+		Id:            uuidBytes(o.ID),
+		UserId:        uuidBytes(o.UserID),
+		WalletId:      uuidBytes(o.WalletID),
+		PairId:        uuidBytes(o.PairID),
+		OrderType:     string(o.OrderType),
+		Side:          string(o.Side),
+		Amount:        decimalString(o.Amount),
+		FilledAmount:  decimalString(o.FilledAmount),
+		Price:         decimalString(o.Price),
+		Status:        string(o.Status),
+		TimeInForce:   string(o.TimeInForce),
+		ClientOrderId: o.ClientOrderID,
+		Meta:          o.Meta,
+		ExecutedAt:    optionalTimestamp(o.ExecutedAt),
+		ExpiresAt:     optionalTimestamp(o.ExpiresAt),
+		CreatedAt:     timestamppb.New(o.CreatedAt),
+		UpdatedAt:     timestamppb.New(o.UpdatedAt),
+	}
+	out.SettlementId = optionalUUIDBytes(o.SettlementID)
+	return out
+}
+
+func ToOrder(p *Order) (*entity.Order, error) {
+	if p == nil {
+		return nil, nil
+	}
+	var (
+		out entity.Order
+		err error
+	)
+	if out.ID, err = parseUUID("id", p.Id); err != nil {
+		return nil, err
+	}
+	if out.UserID, err = parseUUID("user_id", p.UserId); err != nil {
+		return nil, err
+	}
+	if out.WalletID, err = parseUUID("wallet_id", p.WalletId); err != nil {
+		return nil, err
+	}
+	if out.PairID, err = parseUUID("pair_id", p.PairId); err != nil {
+		return nil, err
+	}
+	if p.SettlementId != nil {
+		id, err := parseUUID("settlement_id", p.SettlementId)
+		if err != nil {
+			return nil, err
+		}
+		out.SettlementID = &id
+	}
+	if out.Amount, err = parseDecimal("amount", p.Amount); err != nil {
+		return nil, err
+	}
+	if out.FilledAmount, err = parseDecimal("filled_amount", p.FilledAmount); err != nil {
+		return nil, err
+	}
+	if out.Price, err = parseDecimal("price", p.Price); err != nil {
+		return nil, err
+	}
+	out.OrderType = entity.OrderType(p.OrderType)
+	out.Side = entity.OrderSide(p.Side)
+	out.Status = entity.OrderStatus(p.Status)
+	out.TimeInForce = entity.OrderTimeInForce(p.TimeInForce)
+	out.ClientOrderID = p.ClientOrderId
+	out.Meta = p.Meta
+	out.ExecutedAt = optionalTime(p.ExecutedAt)
+	out.ExpiresAt = optionalTime(p.ExpiresAt)
+	out.CreatedAt = fromTimestamp(p.CreatedAt)
+	out.UpdatedAt = fromTimestamp(p.UpdatedAt)
+	return &out, nil
+}
+
+// --- ابزارهای داخلی ---
+
+func uuidBytes(id uuid.UUID) []byte {
+	if id == uuid.Nil {
+		return nil
+	}
+	b := id
+	return b[:]
+}
+
+// optionalUUIDBytes برای فیلدهای optional: nil یعنی فیلد غایب؛ اشاره‌گر به uuid.Nil هم ۱۶ بایت است تا پس از دیکد حفظ شود
+func optionalUUIDBytes(id *uuid.UUID) []byte {
+	if id == nil {
+		return nil
+	}
+	b := *id
+	return b[:]
+}
+
+func parseUUID(field string, b []byte) (uuid.UUID, error) {
+	if len(b) == 0 {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return id, nil
+}
+
+func decimalString(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func parseDecimal(field, s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", field, err)
+	}
+	return f, nil
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// fromTimestamp زمان را به UTC برمی‌گرداند (offset مبدأ در Timestamp ذخیره نمی‌شود)
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,681 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: event/pb/events.proto
+
+package eventpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Order معادل entity.Order (بدون فیلدهای داخلی GORM مثل DeletedAt)
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        []byte                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WalletId      []byte                 `protobuf:"bytes,3,opt,name=wallet_id,json=walletId,proto3" json:"wallet_id,omitempty"`
+	PairId        []byte                 `protobuf:"bytes,4,opt,name=pair_id,json=pairId,proto3" json:"pair_id,omitempty"`
+	SettlementId  []byte                 `protobuf:"bytes,5,opt,name=settlement_id,json=settlementId,proto3,oneof" json:"settlement_id,omitempty"`
+	OrderType     string                 `protobuf:"bytes,6,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	Side          string                 `protobuf:"bytes,7,opt,name=side,proto3" json:"side,omitempty"`
+	Amount        string                 `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	FilledAmount  string                 `protobuf:"bytes,9,opt,name=filled_amount,json=filledAmount,proto3" json:"filled_amount,omitempty"`
+	Price         string                 `protobuf:"bytes,10,opt,name=price,proto3" json:"price,omitempty"`
+	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	TimeInForce   string                 `protobuf:"bytes,12,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force,omitempty"`
+	ClientOrderId *string                `protobuf:"bytes,13,opt,name=client_order_id,json=clientOrderId,proto3,oneof" json:"client_order_id,omitempty"`
+	Meta          *string                `protobuf:"bytes,14,opt,name=meta,proto3,oneof" json:"meta,omitempty"`
+	ExecutedAt    *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_event_pb_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_event_pb_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_event_pb_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Order) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *Order) GetWalletId() []byte {
+	if x != nil {
+		return x.WalletId
+	}
+	return nil
+}
+
+func (x *Order) GetPairId() []byte {
+	if x != nil {
+		return x.PairId
+	}
+	return nil
+}
+
+func (x *Order) GetSettlementId() []byte {
+	if x != nil {
+		return x.SettlementId
+	}
+	return nil
+}
+
+func (x *Order) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *Order) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *Order) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Order) GetFilledAmount() string {
+	if x != nil {
+		return x.FilledAmount
+	}
+	return ""
+}
+
+func (x *Order) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil && x.ClientOrderId != nil {
+		return *x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetMeta() string {
+	if x != nil && x.Meta != nil {
+		return *x.Meta
+	}
+	return ""
+}
+
+func (x *Order) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+func (x *Order) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// EnqueueOrderEvent معادل model.EnqueueOrderEvent
+type EnqueueOrderEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnqueueOrderEvent) Reset() {
+	*x = EnqueueOrderEvent{}
+	mi := &file_event_pb_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnqueueOrderEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueOrderEvent) ProtoMessage() {}
+
+func (x *EnqueueOrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_pb_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueOrderEvent.ProtoReflect.Descriptor instead.
+func (*EnqueueOrderEvent) Descriptor() ([]byte, []int) {
+	return file_event_pb_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EnqueueOrderEvent) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// CancelOrderEvent معادل model.CancelOrderEvent
+type CancelOrderEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderEvent) Reset() {
+	*x = CancelOrderEvent{}
+	mi := &file_event_pb_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderEvent) ProtoMessage() {}
+
+func (x *CancelOrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_pb_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderEvent.ProtoReflect.Descriptor instead.
+func (*CancelOrderEvent) Descriptor() ([]byte, []int) {
+	return file_event_pb_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CancelOrderEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// SettleTradeEvent معادل model.SettleTradeEvent
+type SettleTradeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       []byte                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	PairId        []byte                 `protobuf:"bytes,3,opt,name=pair_id,json=pairId,proto3" json:"pair_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	TakerOrderId  []byte                 `protobuf:"bytes,5,opt,name=taker_order_id,json=takerOrderId,proto3" json:"taker_order_id,omitempty"`
+	MakerOrderId  []byte                 `protobuf:"bytes,6,opt,name=maker_order_id,json=makerOrderId,proto3" json:"maker_order_id,omitempty"`
+	MatchAmount   string                 `protobuf:"bytes,7,opt,name=match_amount,json=matchAmount,proto3" json:"match_amount,omitempty"`
+	TradePrice    string                 `protobuf:"bytes,8,opt,name=trade_price,json=tradePrice,proto3" json:"trade_price,omitempty"`
+	TraceId       string                 `protobuf:"bytes,9,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CorrelationId string                 `protobuf:"bytes,10,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettleTradeEvent) Reset() {
+	*x = SettleTradeEvent{}
+	mi := &file_event_pb_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettleTradeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettleTradeEvent) ProtoMessage() {}
+
+func (x *SettleTradeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_pb_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettleTradeEvent.ProtoReflect.Descriptor instead.
+func (*SettleTradeEvent) Descriptor() ([]byte, []int) {
+	return file_event_pb_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SettleTradeEvent) GetEventId() []byte {
+	if x != nil {
+		return x.EventId
+	}
+	return nil
+}
+
+func (x *SettleTradeEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SettleTradeEvent) GetPairId() []byte {
+	if x != nil {
+		return x.PairId
+	}
+	return nil
+}
+
+func (x *SettleTradeEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SettleTradeEvent) GetTakerOrderId() []byte {
+	if x != nil {
+		return x.TakerOrderId
+	}
+	return nil
+}
+
+func (x *SettleTradeEvent) GetMakerOrderId() []byte {
+	if x != nil {
+		return x.MakerOrderId
+	}
+	return nil
+}
+
+func (x *SettleTradeEvent) GetMatchAmount() string {
+	if x != nil {
+		return x.MatchAmount
+	}
+	return ""
+}
+
+func (x *SettleTradeEvent) GetTradePrice() string {
+	if x != nil {
+		return x.TradePrice
+	}
+	return ""
+}
+
+func (x *SettleTradeEvent) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *SettleTradeEvent) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *SettleTradeEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// WalletAction معادل model.WalletAction
+type WalletAction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActionId      []byte                 `protobuf:"bytes,1,opt,name=action_id,json=actionId,proto3" json:"action_id,omitempty"`
+	UserId        []byte                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WalletId      []byte                 `protobuf:"bytes,3,opt,name=wallet_id,json=walletId,proto3" json:"wallet_id,omitempty"`
+	Amount        string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	OrderId       []byte                 `protobuf:"bytes,7,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PairId        []byte                 `protobuf:"bytes,8,opt,name=pair_id,json=pairId,proto3" json:"pair_id,omitempty"`
+	Ref           string                 `protobuf:"bytes,9,opt,name=ref,proto3" json:"ref,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TraceId       string                 `protobuf:"bytes,11,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	Source        string                 `protobuf:"bytes,12,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletAction) Reset() {
+	*x = WalletAction{}
+	mi := &file_event_pb_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletAction) ProtoMessage() {}
+
+func (x *WalletAction) ProtoReflect() protoreflect.Message {
+	mi := &file_event_pb_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletAction.ProtoReflect.Descriptor instead.
+func (*WalletAction) Descriptor() ([]byte, []int) {
+	return file_event_pb_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WalletAction) GetActionId() []byte {
+	if x != nil {
+		return x.ActionId
+	}
+	return nil
+}
+
+func (x *WalletAction) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *WalletAction) GetWalletId() []byte {
+	if x != nil {
+		return x.WalletId
+	}
+	return nil
+}
+
+func (x *WalletAction) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *WalletAction) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *WalletAction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *WalletAction) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+func (x *WalletAction) GetPairId() []byte {
+	if x != nil {
+		return x.PairId
+	}
+	return nil
+}
+
+func (x *WalletAction) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *WalletAction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WalletAction) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *WalletAction) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+var File_event_pb_events_proto protoreflect.FileDescriptor
+
+const file_event_pb_events_proto_rawDesc = "" +
+	"\n" +
+	"\x15event/pb/events.proto\x12\x12exchange.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb5\x05\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\fR\x06userId\x12\x1b\n" +
+	"\twallet_id\x18\x03 \x01(\fR\bwalletId\x12\x17\n" +
+	"\apair_id\x18\x04 \x01(\fR\x06pairId\x12(\n" +
+	"\rsettlement_id\x18\x05 \x01(\fH\x00R\fsettlementId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\x06 \x01(\tR\torderType\x12\x12\n" +
+	"\x04side\x18\a \x01(\tR\x04side\x12\x16\n" +
+	"\x06amount\x18\b \x01(\tR\x06amount\x12#\n" +
+	"\rfilled_amount\x18\t \x01(\tR\ffilledAmount\x12\x14\n" +
+	"\x05price\x18\n" +
+	" \x01(\tR\x05price\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\"\n" +
+	"\rtime_in_force\x18\f \x01(\tR\vtimeInForce\x12+\n" +
+	"\x0fclient_order_id\x18\r \x01(\tH\x01R\rclientOrderId\x88\x01\x01\x12\x17\n" +
+	"\x04meta\x18\x0e \x01(\tH\x02R\x04meta\x88\x01\x01\x12;\n" +
+	"\vexecuted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x10\n" +
+	"\x0e_settlement_idB\x12\n" +
+	"\x10_client_order_idB\a\n" +
+	"\x05_meta\"D\n" +
+	"\x11EnqueueOrderEvent\x12/\n" +
+	"\x05order\x18\x01 \x01(\v2\x19.exchange.events.v1.OrderR\x05order\"-\n" +
+	"\x10CancelOrderEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x89\x03\n" +
+	"\x10SettleTradeEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\fR\aeventId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x17\n" +
+	"\apair_id\x18\x03 \x01(\fR\x06pairId\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequence\x12$\n" +
+	"\x0etaker_order_id\x18\x05 \x01(\fR\ftakerOrderId\x12$\n" +
+	"\x0emaker_order_id\x18\x06 \x01(\fR\fmakerOrderId\x12!\n" +
+	"\fmatch_amount\x18\a \x01(\tR\vmatchAmount\x12\x1f\n" +
+	"\vtrade_price\x18\b \x01(\tR\n" +
+	"tradePrice\x12\x19\n" +
+	"\btrace_id\x18\t \x01(\tR\atraceId\x12%\n" +
+	"\x0ecorrelation_id\x18\n" +
+	" \x01(\tR\rcorrelationId\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xdd\x02\n" +
+	"\fWalletAction\x12\x1b\n" +
+	"\taction_id\x18\x01 \x01(\fR\bactionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\fR\x06userId\x12\x1b\n" +
+	"\twallet_id\x18\x03 \x01(\fR\bwalletId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x19\n" +
+	"\border_id\x18\a \x01(\fR\aorderId\x12\x17\n" +
+	"\apair_id\x18\b \x01(\fR\x06pairId\x12\x10\n" +
+	"\x03ref\x18\t \x01(\tR\x03ref\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x19\n" +
+	"\btrace_id\x18\v \x01(\tR\atraceId\x12\x16\n" +
+	"\x06source\x18\f \x01(\tR\x06sourceB=Z;github.com/alisiahmansouri/exchange-common/event/pb;eventpbb\x06proto3"
+
+var (
+	file_event_pb_events_proto_rawDescOnce sync.Once
+	file_event_pb_events_proto_rawDescData []byte
+)
+
+func file_event_pb_events_proto_rawDescGZIP() []byte {
+	file_event_pb_events_proto_rawDescOnce.Do(func() {
+		file_event_pb_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_pb_events_proto_rawDesc), len(file_event_pb_events_proto_rawDesc)))
+	})
+	return file_event_pb_events_proto_rawDescData
+}
+
+var file_event_pb_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_event_pb_events_proto_goTypes = []any{
+	(*Order)(nil),                 // 0: exchange.events.v1.Order
+	(*EnqueueOrderEvent)(nil),     // 1: exchange.events.v1.EnqueueOrderEvent
+	(*CancelOrderEvent)(nil),      // 2: exchange.events.v1.CancelOrderEvent
+	(*SettleTradeEvent)(nil),      // 3: exchange.events.v1.SettleTradeEvent
+	(*WalletAction)(nil),          // 4: exchange.events.v1.WalletAction
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_event_pb_events_proto_depIdxs = []int32{
+	5, // 0: exchange.events.v1.Order.executed_at:type_name -> google.protobuf.Timestamp
+	5, // 1: exchange.events.v1.Order.expires_at:type_name -> google.protobuf.Timestamp
+	5, // 2: exchange.events.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	5, // 3: exchange.events.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 4: exchange.events.v1.EnqueueOrderEvent.order:type_name -> exchange.events.v1.Order
+	5, // 5: exchange.events.v1.SettleTradeEvent.created_at:type_name -> google.protobuf.Timestamp
+	5, // 6: exchange.events.v1.WalletAction.created_at:type_name -> google.protobuf.Timestamp
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_event_pb_events_proto_init() }
+func file_event_pb_events_proto_init() {
+	if File_event_pb_events_proto != nil {
+		return
+	}
+	file_event_pb_events_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_pb_events_proto_rawDesc), len(file_event_pb_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_event_pb_events_proto_goTypes,
+		DependencyIndexes: file_event_pb_events_proto_depIdxs,
+		MessageInfos:      file_event_pb_events_proto_msgTypes,
+	}.Build()
+	File_event_pb_events_proto = out.File
+	file_event_pb_events_proto_goTypes = nil
+	file_event_pb_events_proto_depIdxs = nil
+}
@@ -0,0 +1,171 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+// DefaultVersion نسخه‌ی پیش‌فرض رویدادهایی که هنوز نسخه‌ی صریح ندارند
+const DefaultVersion = 1
+
+// Upcaster بدنه‌ی نسخه‌ی n را به نسخه‌ی n+1 مهاجرت می‌دهد
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+type registration struct {
+	version   int
+	goType    reflect.Type
+	upcasters map[int]Upcaster // کلید: نسخه‌ی مبدأ
+}
+
+// Registry نگاشت EventType+Version به نوع Go و زنجیره‌ی upcasterها
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]*registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]*registration)}
+}
+
+// NewDefaultRegistry رجیستری با رویدادهای استاندارد کتابخانه
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.MustRegister(TypeSettleTrade, model.SettleTradeEventVersion, model.SettleTradeEvent{})
+	r.MustRegister(TypeEnqueueOrder, DefaultVersion, model.EnqueueOrderEvent{})
+	r.MustRegister(TypeCancelOrder, DefaultVersion, model.CancelOrderEvent{})
+	r.MustRegister(TypeWalletAction, DefaultVersion, model.WalletAction{})
+	return r
+}
+
+// Register نسخه‌ی فعلی یک نوع رویداد و نوع Go متناظر را ثبت می‌کند.
+// sample فقط برای تعیین نوع استفاده می‌شود (مثلاً model.SettleTradeEvent{}).
+func (r *Registry) Register(eventType string, version int, sample interface{}) error {
+	if eventType == "" || version < 1 || sample == nil {
+		return model.ErrEventTypeUnknown
+	}
+	t := reflect.TypeOf(sample)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[eventType]; ok {
+		return fmt.Errorf("%w: %s", model.ErrEventAlreadyRegistered, eventType)
+	}
+	r.types[eventType] = &registration{
+		version:   version,
+		goType:    t,
+		upcasters: make(map[int]Upcaster),
+	}
+	return nil
+}
+
+// MustRegister مانند Register ولی در صورت خطا panic می‌کند (برای راه‌اندازی)
+func (r *Registry) MustRegister(eventType string, version int, sample interface{}) {
+	if err := r.Register(eventType, version, sample); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterUpcaster مبدل نسخه‌ی fromVersion به fromVersion+1 را ثبت می‌کند
+func (r *Registry) RegisterUpcaster(eventType string, fromVersion int, up Upcaster) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reg, ok := r.types[eventType]
+	if !ok {
+		return fmt.Errorf("%w: %s", model.ErrEventTypeUnknown, eventType)
+	}
+	if fromVersion < 1 || fromVersion >= reg.version {
+		return fmt.Errorf("%w: %s v%d", model.ErrEventVersionUnsupported, eventType, fromVersion)
+	}
+	reg.upcasters[fromVersion] = up
+	return nil
+}
+
+// CurrentVersion نسخه‌ی فعلی ثبت‌شده برای نوع رویداد
+func (r *Registry) CurrentVersion(eventType string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reg, ok := r.types[eventType]
+	if !ok {
+		return 0, false
+	}
+	return reg.version, true
+}
+
+// Encode رویداد را با نسخه‌ی فعلی ثبت‌شده در پاکت قرار می‌دهد
+func (r *Registry) Encode(eventType string, payload interface{}) (Envelope, error) {
+	version, ok := r.CurrentVersion(eventType)
+	if !ok {
+		return Envelope{}, fmt.Errorf("%w: %s", model.ErrEventTypeUnknown, eventType)
+	}
+	return NewEnvelope(eventType, version, payload)
+}
+
+// Upcast بدنه‌ی پاکت را تا نسخه‌ی فعلی مهاجرت می‌دهد.
+// نسخه‌های آینده (بزرگ‌تر از نسخه‌ی فعلی) صراحتاً رد می‌شوند.
+func (r *Registry) Upcast(env Envelope) (Envelope, error) {
+	r.mu.RLock()
+	reg, ok := r.types[env.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return Envelope{}, fmt.Errorf("%w: %s", model.ErrEventTypeUnknown, env.Type)
+	}
+	if env.Version > reg.version {
+		return Envelope{}, fmt.Errorf("%w: %s v%d > v%d", model.ErrEventVersionUnsupported, env.Type, env.Version, reg.version)
+	}
+	if env.Version < 1 {
+		env.Version = DefaultVersion
+	}
+
+	payload := env.Payload
+	for v := env.Version; v < reg.version; v++ {
+		r.mu.RLock()
+		up, ok := reg.upcasters[v]
+		r.mu.RUnlock()
+		if !ok {
+			return Envelope{}, fmt.Errorf("%w: %s v%d->v%d", model.ErrEventUpcasterMissing, env.Type, v, v+1)
+		}
+		next, err := up(payload)
+		if err != nil {
+			return Envelope{}, fmt.Errorf("upcast %s v%d->v%d: %w", env.Type, v, v+1, err)
+		}
+		payload = next
+	}
+
+	env.Payload = payload
+	env.Version = reg.version
+	return env, nil
+}
+
+// Decode پاکت را upcast کرده و یک اشاره‌گر به نوع Go ثبت‌شده برمی‌گرداند
+// (مثلاً *model.SettleTradeEvent).
+func (r *Registry) Decode(env Envelope) (interface{}, error) {
+	env, err := r.Upcast(env)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	t := r.types[env.Type].goType
+	r.mu.RUnlock()
+
+	out := reflect.New(t).Interface()
+	if err := json.Unmarshal(env.Payload, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecodeInto پاکت را upcast کرده و در out دیکد می‌کند
+func (r *Registry) DecodeInto(env Envelope, out interface{}) error {
+	env, err := r.Upcast(env)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(env.Payload, out)
+}
@@ -0,0 +1,149 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+type widgetV3 struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Unit  string `json:"unit"`
+}
+
+// newWidgetRegistry نوع آزمایشی با دو upcaster: v1 (qty) -> v2 (count) -> v3 (unit پیش‌فرض)
+func newWidgetRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r := NewRegistry()
+	r.MustRegister("widget", 3, widgetV3{})
+	if err := r.RegisterUpcaster("widget", 1, func(p json.RawMessage) (json.RawMessage, error) {
+		var v1 struct {
+			Name string `json:"name"`
+			Qty  int    `json:"qty"`
+		}
+		if err := json.Unmarshal(p, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]interface{}{"name": v1.Name, "count": v1.Qty})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterUpcaster("widget", 2, func(p json.RawMessage) (json.RawMessage, error) {
+		var m map[string]interface{}
+		if err := json.Unmarshal(p, &m); err != nil {
+			return nil, err
+		}
+		m["unit"] = "pcs"
+		return json.Marshal(m)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+	tests := []struct {
+		name    string
+		typ     string
+		version int
+		sample  interface{}
+		wantErr error
+	}{
+		{"ok", "a", 1, widgetV3{}, nil},
+		{"pointer sample", "b", 2, &widgetV3{}, nil},
+		{"duplicate", "a", 1, widgetV3{}, model.ErrEventAlreadyRegistered},
+		{"empty type", "", 1, widgetV3{}, model.ErrEventTypeUnknown},
+		{"zero version", "c", 0, widgetV3{}, model.ErrEventTypeUnknown},
+		{"nil sample", "d", 1, nil, model.ErrEventTypeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Register(tt.typ, tt.version, tt.sample); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	upTests := []struct {
+		name string
+		typ  string
+		from int
+		want error
+	}{
+		{"unknown type", "x", 1, model.ErrEventTypeUnknown},
+		{"from current", "b", 2, model.ErrEventVersionUnsupported},
+		{"from zero", "b", 0, model.ErrEventVersionUnsupported},
+		{"ok", "b", 1, nil},
+	}
+	for _, tt := range upTests {
+		t.Run("upcaster "+tt.name, func(t *testing.T) {
+			err := r.RegisterUpcaster(tt.typ, tt.from, func(p json.RawMessage) (json.RawMessage, error) { return p, nil })
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryDecode(t *testing.T) {
+	r := newWidgetRegistry(t)
+	tests := []struct {
+		name    string
+		env     Envelope
+		want    widgetV3
+		wantErr error
+	}{
+		{"current", Envelope{Type: "widget", Version: 3, Payload: json.RawMessage(`{"name":"a","count":2,"unit":"kg"}`)}, widgetV3{"a", 2, "kg"}, nil},
+		{"from v1", Envelope{Type: "widget", Version: 1, Payload: json.RawMessage(`{"name":"a","qty":5}`)}, widgetV3{"a", 5, "pcs"}, nil},
+		{"missing version is v1", Envelope{Type: "widget", Payload: json.RawMessage(`{"name":"a","qty":1}`)}, widgetV3{"a", 1, "pcs"}, nil},
+		{"future version", Envelope{Type: "widget", Version: 4, Payload: json.RawMessage(`{}`)}, widgetV3{}, model.ErrEventVersionUnsupported},
+		{"unknown type", Envelope{Type: "gadget", Version: 1, Payload: json.RawMessage(`{}`)}, widgetV3{}, model.ErrEventTypeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Decode(tt.env)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if w, ok := got.(*widgetV3); !ok || *w != tt.want {
+				t.Fatalf("Decode = %#v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	gap := NewRegistry()
+	gap.MustRegister("widget", 2, widgetV3{})
+	if _, err := gap.Upcast(Envelope{Type: "widget", Version: 1, Payload: json.RawMessage(`{}`)}); !errors.Is(err, model.ErrEventUpcasterMissing) {
+		t.Fatalf("missing upcaster err = %v", err)
+	}
+}
+
+func TestRegistryEncodeRoundTrip(t *testing.T) {
+	r := NewDefaultRegistry()
+	in := model.SettleTradeEvent{PairID: uuid.New(), Sequence: 9, TakerOrderID: uuid.New(), MakerOrderID: uuid.New(), MatchAmount: 1.5, TradePrice: 100}
+	env, err := r.Encode(TypeSettleTrade, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Version != model.SettleTradeEventVersion {
+		t.Fatalf("version = %d", env.Version)
+	}
+	var out model.SettleTradeEvent
+	if err := r.DecodeInto(env, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Fatalf("round trip = %+v, want %+v", out, in)
+	}
+	if _, err := r.Encode("nope", in); !errors.Is(err, model.ErrEventTypeUnknown) {
+		t.Fatalf("unknown type err = %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/util"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Sequenced رویدادی با ترتیب قطعی در هر جفت ارز (مثل SettleTradeEvent یا OutboxEvent)
+type Sequenced struct {
+	PairID   uuid.UUID
+	Sequence uint64
+	Payload  interface{}
+}
+
+// SequencedSettleTrade رویداد تسویه را برای ReorderBuffer بسته‌بندی می‌کند
+func SequencedSettleTrade(e *model.SettleTradeEvent) Sequenced {
+	return Sequenced{PairID: e.PairID, Sequence: e.Sequence, Payload: e}
+}
+
+// SequencedOutbox ردیف Outbox را برای ReorderBuffer بسته‌بندی می‌کند
+func SequencedOutbox(o *entity.OutboxEvent) Sequenced {
+	return Sequenced{PairID: o.PairID, Sequence: o.Sequence, Payload: o}
+}
+
+// CheckpointStore آخرین Sequence اعمال‌شده برای هر Pair را نگه می‌دارد
+type CheckpointStore interface {
+	Load(ctx context.Context, pairID uuid.UUID) (uint64, error)
+	Save(ctx context.Context, pairID uuid.UUID, sequence uint64) error
+}
+
+// ApplyFunc رویداد را اعمال می‌کند؛ در صورت خطا checkpoint جلو نمی‌رود
+type ApplyFunc func(ctx context.Context, ev Sequenced) error
+
+// GapFunc هنگام ماندگاری شکاف بیش از GapTimeout صدا زده می‌شود تا بازه‌ی [from, to] دوباره ارسال (replay) شود
+type GapFunc func(ctx context.Context, pairID uuid.UUID, from, to uint64)
+
+// PushResult نتیجه‌ی دریافت یک رویداد
+type PushResult int
+
+const (
+	PushApplied   PushResult = iota + 1 // اعمال شد (به همراه رویدادهای بافرشده‌ی بعدی)
+	PushBuffered                        // خارج از ترتیب؛ تا پر شدن شکاف نگه داشته شد
+	PushDuplicate                       // قبلاً اعمال یا بافر شده بود؛ دور ریخته شد
+	PushFailed                          // رویداد اعمال شد اما اعمال رویداد بافرشده‌ی بعدی خطا داد؛ آن رویداد در بافر می‌ماند تا Retry
+)
+
+const (
+	DefaultReorderMaxBuffered = 1024
+	DefaultReorderGapTimeout  = 5 * time.Second
+)
+
+type ReorderConfig struct {
+	MaxBuffered int           // حداکثر رویداد بافرشده برای هر Pair
+	GapTimeout  time.Duration // مدت انتظار برای پر شدن شکاف قبل از درخواست replay
+	OnGap       GapFunc
+	Now         func() time.Time
+}
+
+type pairState struct {
+	loaded   bool
+	last     uint64
+	pending  map[uint64]Sequenced
+	gapSince time.Time
+}
+
+// ReorderBuffer رویدادهای هر Pair را دقیقاً به ترتیب Sequence اعمال می‌کند:
+// تکراری‌ها را دور می‌ریزد، خارج از ترتیب‌ها را تا سقف MaxBuffered نگه می‌دارد
+// و شکاف‌های ماندگار را از طریق OnGap گزارش می‌دهد.
+// اعمال رویدادها سریالی است (یک قفل برای کل بافر).
+// فقط checkpoint در CheckpointStore ماندگار است؛ رویدادهای بافرشده در حافظه‌اند و پس از راه‌اندازی مجدد
+// با گزارش شکاف (OnGap) از checkpoint به بعد دوباره درخواست می‌شوند.
+type ReorderBuffer struct {
+	mu    sync.Mutex
+	store CheckpointStore
+	apply ApplyFunc
+	cfg   ReorderConfig
+	pairs map[uuid.UUID]*pairState
+}
+
+func NewReorderBuffer(store CheckpointStore, apply ApplyFunc, cfg ReorderConfig) *ReorderBuffer {
+	if cfg.MaxBuffered <= 0 {
+		cfg.MaxBuffered = DefaultReorderMaxBuffered
+	}
+	if cfg.GapTimeout <= 0 {
+		cfg.GapTimeout = DefaultReorderGapTimeout
+	}
+	if cfg.Now == nil {
+		cfg.Now = util.NowUTC
+	}
+	return &ReorderBuffer{
+		store: store,
+		apply: apply,
+		cfg:   cfg,
+		pairs: make(map[uuid.UUID]*pairState),
+	}
+}
+
+// Push یک رویداد دریافتی را پردازش می‌کند.
+// ErrEventBufferFull یعنی رویداد پذیرفته نشد و باید بعداً دوباره تحویل داده شود (nack).
+// PushFailed به همراه خطا یعنی خود رویداد اعمال شده (ack شود) ولی رویداد بافرشده‌ی بعدی اعمال نشد؛
+// آن رویداد قبلاً ack شده است، پس در بافر می‌ماند و با Retry یا تحویل بعدی دوباره اعمال می‌شود.
+func (b *ReorderBuffer) Push(ctx context.Context, ev Sequenced) (PushResult, error) {
+	if ev.PairID == uuid.Nil {
+		return 0, model.ErrEventPairIDInvalid
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, err := b.state(ctx, ev.PairID)
+	if err != nil {
+		return 0, err
+	}
+	if ev.Sequence <= st.last {
+		return PushDuplicate, nil
+	}
+	// رویداد بعدی همیشه همین حالا اعمال می‌شود، حتی اگر نسخه‌ای از آن (مثلاً پس از خطای drain) هنوز بافر باشد
+	if _, ok := st.pending[ev.Sequence]; ok && ev.Sequence != st.last+1 {
+		return PushDuplicate, nil
+	}
+
+	if ev.Sequence != st.last+1 {
+		if len(st.pending) >= b.cfg.MaxBuffered {
+			return 0, model.ErrEventBufferFull
+		}
+		st.pending[ev.Sequence] = ev
+		if st.gapSince.IsZero() {
+			st.gapSince = b.cfg.Now()
+		}
+		return PushBuffered, nil
+	}
+
+	if err := b.applyOne(ctx, ev.PairID, st, ev); err != nil {
+		return 0, err
+	}
+	delete(st.pending, ev.Sequence)
+	if err := b.drain(ctx, ev.PairID, st); err != nil {
+		return PushFailed, err
+	}
+	return PushApplied, nil
+}
+
+// Retry برای همه‌ی Pairهایی که رویداد بعدی‌شان در بافر است (مثلاً پس از PushFailed) اعمال را دوباره
+// امتحان می‌کند؛ مانند CheckGaps به صورت دوره‌ای صدا زده شود. اولین خطا برگردانده می‌شود.
+func (b *ReorderBuffer) Retry(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var first error
+	for pairID, st := range b.pairs {
+		if _, ok := st.pending[st.last+1]; !ok {
+			continue
+		}
+		if err := b.drain(ctx, pairID, st); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// CheckGaps برای شکاف‌هایی که بیش از GapTimeout باز مانده‌اند OnGap را صدا می‌زند.
+// باید به صورت دوره‌ای (مثلاً با time.Ticker) فراخوانی شود؛ گزارش هر GapTimeout تکرار می‌شود.
+func (b *ReorderBuffer) CheckGaps(ctx context.Context) {
+	type gap struct {
+		pairID   uuid.UUID
+		from, to uint64
+	}
+	now := b.cfg.Now()
+
+	b.mu.Lock()
+	var gaps []gap
+	for pairID, st := range b.pairs {
+		if len(st.pending) == 0 || st.gapSince.IsZero() {
+			continue
+		}
+		if now.Sub(st.gapSince) < b.cfg.GapTimeout {
+			continue
+		}
+		from, to := st.last+1, minKey(st.pending)-1
+		if to < from {
+			continue
+		}
+		gaps = append(gaps, gap{pairID: pairID, from: from, to: to})
+		st.gapSince = now
+	}
+	b.mu.Unlock()
+
+	if b.cfg.OnGap == nil {
+		return
+	}
+	for _, g := range gaps {
+		b.cfg.OnGap(ctx, g.pairID, g.from, g.to)
+	}
+}
+
+// Last آخرین Sequence اعمال‌شده برای Pair
+func (b *ReorderBuffer) Last(ctx context.Context, pairID uuid.UUID) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, err := b.state(ctx, pairID)
+	if err != nil {
+		return 0, err
+	}
+	return st.last, nil
+}
+
+// Pending تعداد رویدادهای بافرشده برای Pair
+func (b *ReorderBuffer) Pending(pairID uuid.UUID) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if st, ok := b.pairs[pairID]; ok {
+		return len(st.pending)
+	}
+	return 0
+}
+
+func (b *ReorderBuffer) state(ctx context.Context, pairID uuid.UUID) (*pairState, error) {
+	st, ok := b.pairs[pairID]
+	if !ok {
+		st = &pairState{pending: make(map[uint64]Sequenced)}
+		b.pairs[pairID] = st
+	}
+	if !st.loaded {
+		last, err := b.store.Load(ctx, pairID)
+		if err != nil {
+			return nil, err
+		}
+		st.last = last
+		st.loaded = true
+	}
+	return st, nil
+}
+
+func (b *ReorderBuffer) applyOne(ctx context.Context, pairID uuid.UUID, st *pairState, ev Sequenced) error {
+	if err := b.apply(ctx, ev); err != nil {
+		return err
+	}
+	if err := b.store.Save(ctx, pairID, ev.Sequence); err != nil {
+		return err
+	}
+	st.last = ev.Sequence
+	return nil
+}
+
+// drain رویدادهای بافرشده‌ی پشت سر هم را اعمال می‌کند؛ رویدادی که اعمالش خطا بدهد در بافر می‌ماند
+// (قبلاً ack شده و جای دیگری ذخیره نیست) تا Retry یا تحویل مجدد آن دوباره اعمالش کند
+func (b *ReorderBuffer) drain(ctx context.Context, pairID uuid.UUID, st *pairState) error {
+	for {
+		next, ok := st.pending[st.last+1]
+		if !ok {
+			break
+		}
+		if err := b.applyOne(ctx, pairID, st, next); err != nil {
+			return err
+		}
+		delete(st.pending, next.Sequence)
+	}
+	if len(st.pending) == 0 {
+		st.gapSince = time.Time{}
+	} else {
+		// شکاف جدید؛ زمان انتظار از نو شروع می‌شود
+		st.gapSince = b.cfg.Now()
+	}
+	return nil
+}
+
+func minKey(m map[uint64]Sequenced) uint64 {
+	var out uint64
+	first := true
+	for k := range m {
+		if first || k < out {
+			out = k
+			first = false
+		}
+	}
+	return out
+}
+
+// MemoryCheckpointStore پیاده‌سازی درون‌حافظه‌ای CheckpointStore
+type MemoryCheckpointStore struct {
+	mu   sync.Mutex
+	last map[uuid.UUID]uint64
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{last: make(map[uuid.UUID]uint64)}
+}
+
+func (s *MemoryCheckpointStore) Load(_ context.Context, pairID uuid.UUID) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last[pairID], nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, pairID uuid.UUID, sequence uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last[pairID] = sequence
+	return nil
+}
+
+// GormCheckpointStore پیاده‌سازی مبتنی بر جدول consumer_checkpoints؛ برای اینکه checkpoint و تغییر business
+// با هم commit شوند، ApplyFunc می‌تواند Save را با WithTx روی همان تراکنش صدا بزند
+type GormCheckpointStore struct {
+	db       *gorm.DB
+	consumer string
+}
+
+func NewGormCheckpointStore(db *gorm.DB, consumer string) *GormCheckpointStore {
+	return &GormCheckpointStore{db: db, consumer: consumer}
+}
+
+// WithTx نسخه‌ای از store که روی تراکنش داده‌شده کار می‌کند
+func (s *GormCheckpointStore) WithTx(tx *gorm.DB) *GormCheckpointStore {
+	return &GormCheckpointStore{db: tx, consumer: s.consumer}
+}
+
+func (s *GormCheckpointStore) Load(ctx context.Context, pairID uuid.UUID) (uint64, error) {
+	var row entity.ConsumerCheckpoint
+	err := s.db.WithContext(ctx).
+		Where("consumer = ? AND pair_id = ?", s.consumer, pairID).
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return row.LastSequence, nil
+}
+
+func (s *GormCheckpointStore) Save(ctx context.Context, pairID uuid.UUID, sequence uint64) error {
+	row := entity.ConsumerCheckpoint{
+		Consumer:     s.consumer,
+		PairID:       pairID,
+		LastSequence: sequence,
+		UpdatedAt:    util.NowUTC(),
+	}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "consumer"}, {Name: "pair_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_sequence", "updated_at"}),
+	}).Create(&row).Error
+}
@@ -0,0 +1,271 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/utils/tests"
+)
+
+type applyRecorder struct {
+	applied []uint64
+	failOn  map[uint64]int // تعداد دفعاتی که اعمال Sequence باید خطا بدهد
+}
+
+func (r *applyRecorder) apply(_ context.Context, ev Sequenced) error {
+	if r.failOn[ev.Sequence] > 0 {
+		r.failOn[ev.Sequence]--
+		return errors.New("apply failed")
+	}
+	r.applied = append(r.applied, ev.Sequence)
+	return nil
+}
+
+func TestReorderBufferPush(t *testing.T) {
+	pair := uuid.New()
+	tests := []struct {
+		name    string
+		pushes  []uint64
+		want    []PushResult
+		applied []uint64
+		pending int
+	}{
+		{"in order", []uint64{1, 2, 3}, []PushResult{PushApplied, PushApplied, PushApplied}, []uint64{1, 2, 3}, 0},
+		{"gap filled", []uint64{2, 3, 1}, []PushResult{PushBuffered, PushBuffered, PushApplied}, []uint64{1, 2, 3}, 0},
+		{"duplicate applied", []uint64{1, 1}, []PushResult{PushApplied, PushDuplicate}, []uint64{1}, 0},
+		{"duplicate buffered", []uint64{3, 3}, []PushResult{PushBuffered, PushDuplicate}, nil, 1},
+		{"partial drain", []uint64{2, 4, 1}, []PushResult{PushBuffered, PushBuffered, PushApplied}, []uint64{1, 2}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &applyRecorder{}
+			b := NewReorderBuffer(NewMemoryCheckpointStore(), rec.apply, ReorderConfig{})
+			for i, seq := range tt.pushes {
+				got, err := b.Push(context.Background(), Sequenced{PairID: pair, Sequence: seq})
+				if err != nil {
+					t.Fatalf("Push(%d): %v", seq, err)
+				}
+				if got != tt.want[i] {
+					t.Fatalf("Push(%d) = %v, want %v", seq, got, tt.want[i])
+				}
+			}
+			if !equalSeqs(rec.applied, tt.applied) {
+				t.Fatalf("applied = %v, want %v", rec.applied, tt.applied)
+			}
+			if got := b.Pending(pair); got != tt.pending {
+				t.Fatalf("Pending = %d, want %d", got, tt.pending)
+			}
+		})
+	}
+}
+
+func TestReorderBufferBufferFull(t *testing.T) {
+	pair := uuid.New()
+	rec := &applyRecorder{}
+	b := NewReorderBuffer(NewMemoryCheckpointStore(), rec.apply, ReorderConfig{MaxBuffered: 1})
+	if _, err := b.Push(context.Background(), Sequenced{PairID: pair, Sequence: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Push(context.Background(), Sequenced{PairID: pair, Sequence: 4}); !errors.Is(err, model.ErrEventBufferFull) {
+		t.Fatalf("err = %v, want ErrEventBufferFull", err)
+	}
+	if _, err := b.Push(context.Background(), Sequenced{PairID: uuid.Nil, Sequence: 1}); !errors.Is(err, model.ErrEventPairIDInvalid) {
+		t.Fatalf("err = %v, want ErrEventPairIDInvalid", err)
+	}
+}
+
+// رویداد بافرشده (که قبلاً ack شده) با خطای اعمال در drain نباید گم شود
+func TestReorderBufferDrainFailureKeepsEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		recover func(ctx context.Context, b *ReorderBuffer, pair uuid.UUID) error
+	}{
+		{"retry", func(ctx context.Context, b *ReorderBuffer, _ uuid.UUID) error {
+			return b.Retry(ctx)
+		}},
+		{"redelivery", func(ctx context.Context, b *ReorderBuffer, pair uuid.UUID) error {
+			res, err := b.Push(ctx, Sequenced{PairID: pair, Sequence: 2})
+			if err == nil && res != PushApplied {
+				return errors.New("redelivered event not applied")
+			}
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := uuid.New()
+			rec := &applyRecorder{failOn: map[uint64]int{2: 1}}
+			var gaps [][2]uint64
+			now := time.Unix(0, 0)
+			b := NewReorderBuffer(NewMemoryCheckpointStore(), rec.apply, ReorderConfig{
+				GapTimeout: time.Second,
+				Now:        func() time.Time { return now },
+				OnGap: func(_ context.Context, _ uuid.UUID, from, to uint64) {
+					gaps = append(gaps, [2]uint64{from, to})
+				},
+			})
+			ctx := context.Background()
+
+			for _, seq := range []uint64{2, 3} {
+				if _, err := b.Push(ctx, Sequenced{PairID: pair, Sequence: seq}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if res, err := b.Push(ctx, Sequenced{PairID: pair, Sequence: 1}); res != PushFailed || err == nil {
+				t.Fatalf("Push(1) = %v, %v; want PushFailed with drain error", res, err)
+			}
+			if n := b.Pending(pair); n != 2 {
+				t.Fatalf("Pending = %d, want 2 (failed event kept)", n)
+			}
+			now = now.Add(2 * time.Second)
+			b.CheckGaps(ctx)
+			if len(gaps) != 0 {
+				t.Fatalf("gaps = %v, want none", gaps)
+			}
+
+			if err := tt.recover(ctx, b, pair); err != nil {
+				t.Fatal(err)
+			}
+			if !equalSeqs(rec.applied, []uint64{1, 2, 3}) {
+				t.Fatalf("applied = %v", rec.applied)
+			}
+			if last, _ := b.Last(ctx, pair); last != 3 || b.Pending(pair) != 0 {
+				t.Fatalf("Last = %d, Pending = %d; want 3, 0", last, b.Pending(pair))
+			}
+		})
+	}
+}
+
+func TestReorderBufferCheckGaps(t *testing.T) {
+	pair := uuid.New()
+	rec := &applyRecorder{}
+	var gaps [][2]uint64
+	now := time.Unix(0, 0)
+	b := NewReorderBuffer(NewMemoryCheckpointStore(), rec.apply, ReorderConfig{
+		GapTimeout: time.Second,
+		Now:        func() time.Time { return now },
+		OnGap: func(_ context.Context, _ uuid.UUID, from, to uint64) {
+			gaps = append(gaps, [2]uint64{from, to})
+		},
+	})
+	ctx := context.Background()
+	if _, err := b.Push(ctx, Sequenced{PairID: pair, Sequence: 5}); err != nil {
+		t.Fatal(err)
+	}
+	b.CheckGaps(ctx)
+	if len(gaps) != 0 {
+		t.Fatalf("gap reported before timeout: %v", gaps)
+	}
+	now = now.Add(time.Second)
+	b.CheckGaps(ctx)
+	if len(gaps) != 1 || gaps[0] != [2]uint64{1, 4} {
+		t.Fatalf("gaps = %v, want [[1 4]]", gaps)
+	}
+}
+
+func equalSeqs(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newFakeCheckpointDB جدول consumer_checkpoints را با callbackهای gorm در حالت DryRun در حافظه شبیه‌سازی می‌کند
+func newFakeCheckpointDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type key struct {
+		consumer string
+		pair     uuid.UUID
+	}
+	rows := map[key]uint64{}
+	if err := db.Callback().Create().After("gorm:create").Register("test:upsert", func(tx *gorm.DB) {
+		row := tx.Statement.Dest.(*entity.ConsumerCheckpoint)
+		rows[key{row.Consumer, row.PairID}] = row.LastSequence
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		callbacks.BuildQuerySQL(tx)
+		var k key
+		for _, v := range tx.Statement.Vars {
+			switch v := v.(type) {
+			case string:
+				k.consumer = v
+			case uuid.UUID:
+				k.pair = v
+			}
+		}
+		seq, ok := rows[k]
+		if !ok {
+			tx.AddError(gorm.ErrRecordNotFound)
+			return
+		}
+		*tx.Statement.Dest.(*entity.ConsumerCheckpoint) = entity.ConsumerCheckpoint{Consumer: k.consumer, PairID: k.pair, LastSequence: seq}
+		tx.RowsAffected = 1
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestGormCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeCheckpointDB(t)
+	settlement := NewGormCheckpointStore(db, "settlement")
+	other := NewGormCheckpointStore(db, "notifier")
+	pair := uuid.New()
+
+	steps := []struct {
+		store *GormCheckpointStore
+		save  uint64 // ۰ یعنی فقط Load
+		want  uint64
+	}{
+		{settlement, 0, 0},
+		{settlement, 5, 5},
+		{settlement, 7, 7},
+		{other, 0, 0},
+		{settlement.WithTx(db), 0, 7},
+	}
+	for i, st := range steps {
+		if st.save > 0 {
+			if err := st.store.Save(ctx, pair, st.save); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := st.store.Load(ctx, pair)
+		if err != nil || got != st.want {
+			t.Fatalf("step %d: Load = %d, %v; want %d", i, got, err, st.want)
+		}
+	}
+
+	// بافر جدید پس از راه‌اندازی مجدد از checkpoint ماندگار ادامه می‌دهد
+	rec := &applyRecorder{}
+	b := NewReorderBuffer(settlement, rec.apply, ReorderConfig{})
+	for _, p := range []struct {
+		seq  uint64
+		want PushResult
+	}{{7, PushDuplicate}, {8, PushApplied}} {
+		if res, err := b.Push(ctx, Sequenced{PairID: pair, Sequence: p.seq}); err != nil || res != p.want {
+			t.Fatalf("Push(%d) = %v, %v; want %v", p.seq, res, err, p.want)
+		}
+	}
+	if got, _ := settlement.Load(ctx, pair); got != 8 {
+		t.Fatalf("checkpoint = %d, want 8", got)
+	}
+}
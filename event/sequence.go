@@ -0,0 +1,118 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/util"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SequenceAllocator شماره‌ی ترتیب یکنوا و بدون شکاف برای هر جفت ارز تخصیص می‌دهد.
+// اولین مقدار برای هر Pair برابر ۱ است.
+type SequenceAllocator interface {
+	Next(ctx context.Context, pairID uuid.UUID) (uint64, error)
+	Current(ctx context.Context, pairID uuid.UUID) (uint64, error)
+}
+
+// MemorySequenceAllocator پیاده‌سازی درون‌حافظه‌ای؛ مناسب تست و موتور تک‌نمونه‌ای
+type MemorySequenceAllocator struct {
+	mu   sync.Mutex
+	last map[uuid.UUID]uint64
+}
+
+func NewMemorySequenceAllocator() *MemorySequenceAllocator {
+	return &MemorySequenceAllocator{last: make(map[uuid.UUID]uint64)}
+}
+
+func (a *MemorySequenceAllocator) Next(_ context.Context, pairID uuid.UUID) (uint64, error) {
+	if pairID == uuid.Nil {
+		return 0, model.ErrEventPairIDInvalid
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.last[pairID]++
+	return a.last[pairID], nil
+}
+
+func (a *MemorySequenceAllocator) Current(_ context.Context, pairID uuid.UUID) (uint64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.last[pairID], nil
+}
+
+// Restore مقدار آخرین Sequence را (مثلاً پس از بازیابی snapshot) تنظیم می‌کند.
+// مقدار کمتر از مقدار فعلی نادیده گرفته می‌شود تا یکنوایی حفظ شود.
+func (a *MemorySequenceAllocator) Restore(pairID uuid.UUID, last uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if last > a.last[pairID] {
+		a.last[pairID] = last
+	}
+}
+
+// GormSequenceAllocator پیاده‌سازی مبتنی بر جدول pair_sequences.
+// برای تضمین «بدون شکاف» باید با WithTx داخل همان تراکنشی صدا زده شود
+// که OutboxEvent درج می‌شود؛ در غیر این صورت هر فراخوانی تراکنش مستقل خودش را دارد.
+type GormSequenceAllocator struct {
+	db *gorm.DB
+}
+
+func NewGormSequenceAllocator(db *gorm.DB) *GormSequenceAllocator {
+	return &GormSequenceAllocator{db: db}
+}
+
+// WithTx نسخه‌ای از allocator که روی تراکنش داده‌شده کار می‌کند
+func (a *GormSequenceAllocator) WithTx(tx *gorm.DB) *GormSequenceAllocator {
+	return &GormSequenceAllocator{db: tx}
+}
+
+func (a *GormSequenceAllocator) Next(ctx context.Context, pairID uuid.UUID) (uint64, error) {
+	if pairID == uuid.Nil {
+		return 0, model.ErrEventPairIDInvalid
+	}
+	var next uint64
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// ردیف را در صورت نبود بساز؛ درج همزمان توسط unique PK خنثی می‌شود
+		seed := entity.PairSequence{PairID: pairID, UpdatedAt: util.NowUTC()}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seed).Error; err != nil {
+			return err
+		}
+
+		var row entity.PairSequence
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("pair_id = ?", pairID).
+			Take(&row).Error; err != nil {
+			return err
+		}
+
+		next = row.LastSequence + 1
+		return tx.Model(&entity.PairSequence{}).
+			Where("pair_id = ?", pairID).
+			Updates(map[string]interface{}{
+				"last_sequence": next,
+				"updated_at":    util.NowUTC(),
+			}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+func (a *GormSequenceAllocator) Current(ctx context.Context, pairID uuid.UUID) (uint64, error) {
+	var row entity.PairSequence
+	err := a.db.WithContext(ctx).Where("pair_id = ?", pairID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return row.LastSequence, nil
+}
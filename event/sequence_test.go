@@ -0,0 +1,70 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+func TestMemorySequenceAllocator(t *testing.T) {
+	ctx := context.Background()
+	a := NewMemorySequenceAllocator()
+	p1, p2 := uuid.New(), uuid.New()
+
+	if _, err := a.Next(ctx, uuid.Nil); !errors.Is(err, model.ErrEventPairIDInvalid) {
+		t.Fatalf("nil pair err = %v", err)
+	}
+
+	steps := []struct {
+		name string
+		do   func() (uint64, error)
+		want uint64
+	}{
+		{"first is one", func() (uint64, error) { return a.Next(ctx, p1) }, 1},
+		{"monotonic", func() (uint64, error) { return a.Next(ctx, p1) }, 2},
+		{"pairs independent", func() (uint64, error) { return a.Next(ctx, p2) }, 1},
+		{"current", func() (uint64, error) { return a.Current(ctx, p1) }, 2},
+		{"restore forward", func() (uint64, error) { a.Restore(p1, 10); return a.Next(ctx, p1) }, 11},
+		{"restore backward ignored", func() (uint64, error) { a.Restore(p1, 3); return a.Current(ctx, p1) }, 11},
+	}
+	for _, s := range steps {
+		got, err := s.do()
+		if err != nil || got != s.want {
+			t.Fatalf("%s: got %d, %v; want %d", s.name, got, err, s.want)
+		}
+	}
+}
+
+func TestMemorySequenceAllocatorConcurrentNoGaps(t *testing.T) {
+	ctx := context.Background()
+	a := NewMemorySequenceAllocator()
+	pair := uuid.New()
+	const n = 200
+	seen := make([]bool, n+1)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seq, err := a.Next(ctx, pair)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			seen[seq] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	for seq := 1; seq <= n; seq++ {
+		if !seen[seq] {
+			t.Fatalf("sequence %d missing", seq)
+		}
+	}
+}
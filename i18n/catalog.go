@@ -0,0 +1,89 @@
+package i18n
+
+import "sync"
+
+// Catalog پیام‌های هر کد خطا به زبان‌های مختلف
+type Catalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[Lang]string
+	fallback Lang
+}
+
+// NewCatalog کاتالوگ خالی؛ اگر پیامی در زبان درخواستی نباشد از fallback استفاده می‌شود
+func NewCatalog(fallback Lang) *Catalog {
+	return &Catalog{messages: make(map[string]map[Lang]string), fallback: fallback}
+}
+
+// Add پیام یک کد در یک زبان را ثبت (یا جایگزین) می‌کند
+func (c *Catalog) Add(code string, lang Lang, msg string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok := c.messages[code]
+	if !ok {
+		m = make(map[Lang]string, len(Supported))
+		c.messages[code] = m
+	}
+	m[lang] = msg
+}
+
+// AddAll پیام‌های یک زبان را به صورت دسته‌ای ثبت می‌کند
+func (c *Catalog) AddAll(lang Lang, messages map[string]string) {
+	for code, msg := range messages {
+		c.Add(code, lang, msg)
+	}
+}
+
+// Lookup پیام خام (بدون جایگزینی پارامتر) کد در زبان lang
+func (c *Catalog) Lookup(lang Lang, code string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	msg, ok := c.messages[code][lang]
+	return msg, ok
+}
+
+// Has کد حداقل در یک زبان پیام دارد
+func (c *Catalog) Has(code string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.messages[code]) > 0
+}
+
+// Message پیام کد در زبان lang (یا زبان fallback) با جایگزینی params؛ کد ناشناخته خود کد را برمی‌گرداند
+func (c *Catalog) Message(lang Lang, code string, params Params) string {
+	msg, ok := c.Lookup(lang, code)
+	if !ok {
+		lang = c.fallback
+		if msg, ok = c.Lookup(lang, code); !ok {
+			return code
+		}
+	}
+	return Interpolate(lang, msg, params)
+}
+
+// Missing کدهایی که در زبان lang پیام ندارند (برای بررسی کامل بودن ترجمه‌ها)
+func (c *Catalog) Missing(lang Lang) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var out []string
+	for code, m := range c.messages {
+		if _, ok := m[lang]; !ok {
+			out = append(out, code)
+		}
+	}
+	return out
+}
+
+// Default کاتالوگ پیش‌فرض شامل همه‌ی کدهای consts
+var Default = newDefault()
+
+func newDefault() *Catalog {
+	c := NewCatalog(DefaultLang)
+	c.AddAll(FA, messagesFA)
+	c.AddAll(EN, messagesEN)
+	return c
+}
+
+// Message پیام کد از کاتالوگ پیش‌فرض
+func Message(lang Lang, code string, params Params) string {
+	return Default.Message(lang, code, params)
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextKeyLang کلید ذخیره‌ی زبان مذاکره‌شده در gin.Context
+const ContextKeyLang = "i18n.lang"
+
+// Negotiate مقدار هدر Accept-Language را با توجه به q ها به بهترین زبان پشتیبانی‌شده تبدیل می‌کند
+func Negotiate(acceptLanguage string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+	var cands []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		for _, p := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if ok && strings.EqualFold(k, "q") {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		if l, ok := ParseLang(tag); ok {
+			cands = append(cands, candidate{lang: l, q: q})
+		}
+	}
+	if len(cands) == 0 {
+		return DefaultLang
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].q > cands[j].q })
+	return cands[0].lang
+}
+
+// Middleware زبان درخواست را از پارامتر lang یا هدر Accept-Language تعیین و در context ذخیره می‌کند
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := ParseLang(c.Query("lang"))
+		if !ok {
+			lang = Negotiate(c.GetHeader("Accept-Language"))
+		}
+		c.Set(ContextKeyLang, lang)
+		c.Header("Content-Language", string(lang))
+		c.Next()
+	}
+}
+
+// FromGin زبان ذخیره‌شده توسط Middleware؛ بدون Middleware هدر Accept-Language مستقیماً مذاکره می‌شود
+func FromGin(c *gin.Context) Lang {
+	if v, ok := c.Get(ContextKeyLang); ok {
+		if l, ok := v.(Lang); ok {
+			return l
+		}
+	}
+	if c.Request == nil {
+		return DefaultLang
+	}
+	return Negotiate(c.GetHeader("Accept-Language"))
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lang زبان پیام‌ها
+type Lang string
+
+const (
+	FA Lang = "fa"
+	EN Lang = "en"
+)
+
+// DefaultLang زبان پیش‌فرض وقتی کلاینت زبانی اعلام نکرده یا زبانش پشتیبانی نمی‌شود
+const DefaultLang = FA
+
+// Supported زبان‌های پشتیبانی‌شده به ترتیب اولویت
+var Supported = []Lang{FA, EN}
+
+// ParseLang برچسب زبان (fa، fa-IR، en_US، ...) را به Lang پشتیبانی‌شده تبدیل می‌کند
+func ParseLang(tag string) (Lang, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, l := range Supported {
+		if string(l) == tag {
+			return l, true
+		}
+	}
+	// per (کد قدیمی فارسی)
+	if tag == "per" {
+		return FA, true
+	}
+	return "", false
+}
+
+// Params مقادیر جایگزین {name} در پیام
+type Params map[string]interface{}
+
+// Interpolate {name} ها را با params جایگزین می‌کند؛ بخش داخل [ ] فقط وقتی نگه داشته می‌شود
+// که همه‌ی پارامترهایش مقدار داشته باشند (مثلاً "[؛ {wait} دیگر تلاش کنید]")
+func Interpolate(lang Lang, msg string, params Params) string {
+	if !strings.ContainsAny(msg, "{[") {
+		return msg
+	}
+	var b strings.Builder
+	for msg != "" {
+		open := strings.IndexByte(msg, '[')
+		if open < 0 {
+			b.WriteString(replace(lang, msg, params, nil))
+			break
+		}
+		end := strings.IndexByte(msg[open:], ']')
+		if end < 0 {
+			b.WriteString(replace(lang, msg, params, nil))
+			break
+		}
+		b.WriteString(replace(lang, msg[:open], params, nil))
+		missing := false
+		section := replace(lang, msg[open+1:open+end], params, &missing)
+		if !missing {
+			b.WriteString(section)
+		}
+		msg = msg[open+end+1:]
+	}
+	return b.String()
+}
+
+func replace(lang Lang, s string, params Params, missing *bool) string {
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(s, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(s[open:], '}')
+		if end < 0 {
+			break
+		}
+		name := s[open+1 : open+end]
+		b.WriteString(s[:open])
+		if v, ok := params[name]; ok {
+			b.WriteString(format(lang, v))
+		} else {
+			if missing != nil {
+				*missing = true
+			}
+			b.WriteString(s[open : open+end+1])
+		}
+		s = s[open+end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func format(lang Lang, v interface{}) string {
+	switch x := v.(type) {
+	case time.Duration:
+		return FormatDuration(lang, x)
+	case string:
+		return x
+	}
+	return fmt.Sprint(v)
+}
+
+// FormatDuration مدت را به صورت خوانا در زبان lang برمی‌گرداند (به بالا گرد می‌شود)
+func FormatDuration(lang Lang, d time.Duration) string {
+	var n int
+	var fa, en string
+	switch {
+	case d <= time.Minute:
+		n, fa, en = max(1, int((d+time.Second-1)/time.Second)), "ثانیه", "second"
+	case d < time.Hour:
+		n, fa, en = int((d+time.Minute-1)/time.Minute), "دقیقه", "minute"
+	default:
+		n, fa, en = int((d+time.Hour-1)/time.Hour), "ساعت", "hour"
+	}
+	if lang == EN {
+		if n != 1 {
+			en += "s"
+		}
+		return fmt.Sprintf("%d %s", n, en)
+	}
+	return fmt.Sprintf("%d %s", n, fa)
+}
@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Lang
+		ok   bool
+	}{
+		{"fa", FA, true},
+		{"fa-IR", FA, true},
+		{"EN_us", EN, true},
+		{"per", FA, true},
+		{"de", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLang(tt.tag)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseLang(%q) = %q, %v; want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Lang
+	}{
+		{"", DefaultLang},
+		{"en-US,en;q=0.9", EN},
+		{"de,en;q=0.5,fa;q=0.8", FA},
+		{"fa;q=0,en", EN},
+		{"de,fr", DefaultLang},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	const msg = "locked[; try again in {wait}]"
+	tests := []struct {
+		name   string
+		lang   Lang
+		msg    string
+		params Params
+		want   string
+	}{
+		{"no params drops optional section", EN, msg, nil, "locked"},
+		{"duration in english", EN, msg, Params{"wait": 5 * time.Minute}, "locked; try again in 5 minutes"},
+		{"duration in persian", FA, msg, Params{"wait": time.Minute}, "locked; try again in 60 ثانیه"},
+		{"string value", EN, "hi {name}", Params{"name": "ali"}, "hi ali"},
+		{"missing required param kept", EN, "hi {name}", nil, "hi {name}"},
+		{"plain", EN, "plain", Params{"x": 1}, "plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Interpolate(tt.lang, tt.msg, tt.params); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		d    time.Duration
+		want string
+	}{
+		{EN, 0, "1 second"},
+		{EN, 30 * time.Second, "30 seconds"},
+		{EN, 61 * time.Second, "2 minutes"},
+		{EN, time.Hour, "1 hour"},
+		{FA, 0, "1 ثانیه"},
+		{FA, 1500 * time.Millisecond, "2 ثانیه"},
+		{FA, time.Minute, "60 ثانیه"},
+		{FA, 61 * time.Second, "2 دقیقه"},
+		{FA, 90 * time.Minute, "2 ساعت"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.lang, tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%s, %v) = %q, want %q", tt.lang, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogMessage(t *testing.T) {
+	c := NewCatalog(FA)
+	c.Add("X", FA, "پیام {n}")
+	c.Add("Y", EN, "message")
+
+	tests := []struct {
+		lang Lang
+		code string
+		want string
+	}{
+		{FA, "X", "پیام 3"},
+		{EN, "X", "پیام 3"}, // fallback
+		{EN, "Y", "message"},
+		{FA, "Z", "Z"},
+	}
+	for _, tt := range tests {
+		if got := c.Message(tt.lang, tt.code, Params{"n": 3}); got != tt.want {
+			t.Errorf("Message(%s, %s) = %q, want %q", tt.lang, tt.code, got, tt.want)
+		}
+	}
+	if m := c.Missing(EN); len(m) != 1 || m[0] != "X" {
+		t.Fatalf("Missing(EN) = %v, want [X]", m)
+	}
+}
+
+func TestDefaultCatalogComplete(t *testing.T) {
+	for _, lang := range Supported {
+		if m := Default.Missing(lang); len(m) > 0 {
+			t.Errorf("codes without %s message: %v", lang, m)
+		}
+	}
+}
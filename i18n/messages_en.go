@@ -0,0 +1,146 @@
+package i18n
+
+import "github.com/alisiahmansouri/exchange-common/consts"
+
+// messagesEN پیام‌های انگلیسی برای شرکای API
+var messagesEN = map[string]string{
+	// general
+	consts.CodeUnauthorized:         "You must be signed in to perform this action",
+	consts.CodeForbidden:            "You are not allowed to perform this action",
+	consts.CodeNotFound:             "The requested resource was not found",
+	consts.CodeInvalidRequest:       "Invalid request",
+	consts.CodeInternalError:        "Internal server error",
+	consts.CodeTooManyRequests:      "Too many requests, please try again later",
+	consts.CodeRateLimitUnavailable: "Could not check the request limit, please try again",
+
+	// auth
+	consts.CodeInvalidBody:                 "Invalid request body",
+	consts.CodeInvalidParams:               "Required parameters are missing or invalid",
+	consts.CodeInvalidEmail:                "Invalid email format",
+	consts.CodeInvalidPassword:             "The new password is not valid",
+	consts.CodeInvalidOrExpiredCode:        "The code is incorrect or has expired",
+	consts.CodeResetPasswordFail:           "Password reset failed",
+	consts.CodeAuthTokenGenFail:            "Failed to generate token",
+	consts.CodeUserAlreadyExists:           "A user with this email or mobile number already exists",
+	consts.CodeInvalidCredentials:          "Incorrect email, mobile number or password",
+	consts.CodeTooManyAttempts:             "Too many failed attempts",
+	consts.CodeInvalidUserID:               "Invalid user ID",
+	consts.CodeInvalidRefreshToken:         "The refresh token is invalid or has expired",
+	consts.CodeTokenEmpty:                  "The token is missing or empty",
+	consts.CodeAuthHeaderMissing:           "The Authorization header is missing",
+	consts.CodeUserIDTypeInvalid:           "Invalid user ID type",
+	consts.CodeTokenNotFoundInContext:      "Token not found in request context",
+	consts.CodeJWTRevokeError:              "Failed to revoke token",
+	consts.CodeTokenInvalid:                "Invalid token",
+	consts.CodeTokenExpired:                "The token has expired",
+	consts.CodeRefreshTokenReused:          "The refresh token was already used; all related sessions have been revoked",
+	consts.Code2FACheckError:               "Failed to check two-factor authentication status",
+	consts.CodeInvalid2FACode:              "The two-factor code is empty or invalid",
+	consts.CodeLoginThrottled:              "Sign-in is temporarily restricted after too many failed attempts[; try again in {wait}]",
+	consts.CodeInvalidPurpose:              "Invalid verification code purpose",
+	consts.CodeInvalidChannel:              "Invalid verification code channel",
+	consts.CodeRateLimitCheckFail:          "Failed to check the sending limit",
+	consts.CodeRateLimitExceeded:           "Too many codes sent, please try again later",
+	consts.Code2FAAttemptCheckFail:         "Failed to check two-factor attempt limit",
+	consts.CodeForgotPasswordEmailNotFound: "The email address was not found",
+	consts.CodeForgotPasswordSendFail:      "Failed to send the recovery email",
+	consts.CodeInvalidPhone:                "Invalid mobile number",
+	consts.CodeSendPhoneVerificationFail:   "Failed to send the mobile verification code",
+	consts.CodeVerifyPhoneFail:             "Mobile number verification failed",
+	consts.CodeResendPhoneVerificationFail: "Failed to resend the mobile verification code",
+	consts.CodeVerifyEmailFail:             "Email verification failed",
+	consts.CodeResendEmailVerificationFail: "Failed to resend the verification email",
+	consts.CodeInvalidEmailOrPhone:         "Invalid email or mobile number format",
+	consts.CodeEmailAlreadyExists:          "This email address is already registered",
+	consts.CodePhoneAlreadyExists:          "This mobile number is already registered",
+	consts.Code2FAResendFail:               "Failed to send the two-factor code",
+	consts.CodeVerificationUsed:            "The verification code has already been used",
+	consts.CodeTOTPNotEnrolled:             "No authenticator app is set up for this account",
+	consts.CodeTOTPAlreadyEnabled:          "An authenticator app is already enabled",
+	consts.CodeTOTPEnrollFail:              "Failed to set up the authenticator app",
+	consts.CodeBackupCodeGenFail:           "Failed to generate backup codes",
+	consts.CodeVerificationIssueFail:       "Failed to issue a verification code",
+	consts.CodeVerificationCheckFail:       "Failed to check the verification code",
+	consts.CodeVerificationExpired:         "The verification code has expired, please request a new one",
+	consts.CodeResendCooldown:              "Please wait a moment before requesting another code",
+	consts.Code2FACodeGen:                  "Failed to generate the verification code",
+	consts.Code2FASendFail:                 "Failed to send the verification code",
+	consts.CodeTokenGenFail:                "Failed to generate token",
+	consts.CodeLockoutCheckFail:            "Failed to check sign-in restrictions",
+
+	// password
+	consts.CodePasswordPolicyViolation: "The password does not meet the security policy",
+	consts.CodePasswordTooShort:        "The password is too short",
+	consts.CodePasswordTooLong:         "The password is too long",
+	consts.CodePasswordCharClasses:     "The password must mix upper-case letters, lower-case letters, digits and symbols",
+	consts.CodePasswordCommon:          "This password is too common or has appeared in a data breach",
+	consts.CodePasswordHasIdentifier:   "The password must not contain your email or mobile number",
+	consts.CodePasswordWeak:            "The password is not strong enough",
+	consts.CodePasswordReused:          "This password was used recently",
+
+	// captcha and proof-of-work
+	consts.CodeCaptchaIDGenerationFailed: "Failed to generate captcha ID",
+	consts.CodeCaptchaGenerationFailed:   "Failed to generate captcha",
+	consts.CodeCaptchaStorageFailed:      "Failed to store captcha",
+	consts.CodeInvalidCaptchaID:          "Invalid captcha ID",
+	consts.CodeInvalidCaptcha:            "Incorrect captcha",
+	consts.CodeCaptchaEmpty:              "The captcha answer is empty",
+	consts.CodeCaptchaNotFound:           "Captcha not found or expired",
+	consts.CodeCaptchaWrong:              "Incorrect captcha",
+	consts.CodeCaptchaRequired:           "Please solve the captcha to continue",
+	consts.CodePoWIssueFail:              "Failed to create proof-of-work challenge",
+	consts.CodePoWInvalid:                "Invalid proof-of-work challenge",
+	consts.CodePoWExpired:                "The proof-of-work challenge has expired",
+	consts.CodePoWInsufficient:           "Incorrect proof-of-work solution",
+	consts.CodePoWReplayed:               "This proof-of-work challenge has already been used",
+
+	// currency
+	consts.CodeCurrencyListError: "Failed to fetch the currency list",
+
+	// order
+	consts.CodeOrderInvalidBody:              "Invalid order request body",
+	consts.CodeOrderCreateError:              "Failed to place order",
+	consts.CodeOrderNotFound:                 "Order not found",
+	consts.CodeOrderUnauthorized:             "Unauthorized access to order",
+	consts.CodeOrderForbidden:                "You are not allowed to access this order",
+	consts.CodeOrderInsufficientFunds:        "Insufficient balance to place the order",
+	consts.CodeOrderInvalidAmount:            "Order amount must be greater than zero",
+	consts.CodeOrderInvalidID:                "Invalid order ID",
+	consts.CodeOrderCancelError:              "Failed to cancel order",
+	consts.CodeOrderCannotBeCanceled:         "This order cannot be canceled",
+	consts.CodeOrderAmountOutOfRange:         "Order amount is out of the allowed range",
+	consts.CodeOrderPairNotFoundOrInactive:   "Trading pair not found or inactive",
+	consts.CodeOrderWalletNotFoundOrInactive: "Wallet not found or inactive",
+	consts.CodeOrderConflict:                 "Conflicting or duplicate order",
+	consts.CodeOrderTooManyRequests:          "Too many order requests",
+	consts.CodeOrderTimeout:                  "The order was not placed due to a timeout",
+	consts.CodeOrderInvalidSide:              "Invalid order side (buy/sell)",
+	consts.CodeOrderClientOrderIDTooLong:     "clientOrderID is too long",
+	consts.CodeOrderInvalidLimitPrice:        "Invalid limit order price",
+	consts.CodeOrderPriceNotAllowedForMarket: "Price is not allowed for market orders",
+	consts.CodeOrderPairIDInvalid:            "Invalid trading pair ID",
+	consts.CodeOrderInputInvalid:             "Invalid order input",
+	consts.CodeOrderInvalidType:              "Invalid order type",
+	consts.CodeOrderInvalidStatusFilter:      "Invalid order status filter",
+	consts.CodeOrderInvalidPagination:        "Invalid pagination values",
+
+	// wallet
+	consts.CodeInvalidWalletID:     "Invalid wallet ID",
+	consts.CodeInvalidCurrencyID:   "Invalid currency ID",
+	consts.CodeInvalidAmount:       "Amount must be greater than zero",
+	consts.CodeInvalidWalletStatus: "Invalid wallet status",
+	consts.CodeWalletNotFound:      "Wallet not found",
+	consts.CodeWalletInactive:      "Wallet is inactive",
+	consts.CodeDepositError:        "Failed to deposit to wallet",
+	consts.CodeWithdrawError:       "Failed to withdraw from wallet",
+	consts.CodeFreezeError:         "Failed to freeze funds",
+	consts.CodeUnfreezeError:       "Failed to release frozen funds",
+	consts.CodeDeductFrozenError:   "Failed to deduct from frozen balance",
+	consts.CodeTransferError:       "Failed to transfer between wallets",
+	consts.CodeChangeStatusError:   "Failed to change wallet status",
+	consts.CodeWalletListError:     "Failed to fetch wallet list",
+	consts.CodeWalletSummaryError:  "Failed to fetch balance summary",
+	consts.CodeWalletHistoryError:  "Failed to fetch wallet history",
+	consts.CodeBulkOperationError:  "Bulk wallet operation failed",
+	consts.CodeFetchError:          "Failed to fetch wallet details",
+}
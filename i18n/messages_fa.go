@@ -0,0 +1,146 @@
+package i18n
+
+import "github.com/alisiahmansouri/exchange-common/consts"
+
+// messagesFA پیام‌های فارسی؛ تا حد امکان همان ثابت‌های Err* در consts
+var messagesFA = map[string]string{
+	// عمومی
+	consts.CodeUnauthorized:         "برای این عملیات باید وارد حساب شوید",
+	consts.CodeForbidden:            "اجازه‌ی انجام این عملیات را ندارید",
+	consts.CodeNotFound:             "مورد درخواستی یافت نشد",
+	consts.CodeInvalidRequest:       "درخواست نامعتبر است",
+	consts.CodeInternalError:        consts.ErrInternal,
+	consts.CodeTooManyRequests:      consts.ErrTooManyRequests,
+	consts.CodeRateLimitUnavailable: consts.ErrRateLimitUnavailable,
+
+	// احراز هویت
+	consts.CodeInvalidBody:                 consts.ErrInvalidBody,
+	consts.CodeInvalidParams:               consts.ErrInvalidParams,
+	consts.CodeInvalidEmail:                consts.ErrInvalidEmail,
+	consts.CodeInvalidPassword:             consts.ErrInvalidPassword,
+	consts.CodeInvalidOrExpiredCode:        consts.ErrInvalidOrExpiredCode,
+	consts.CodeResetPasswordFail:           consts.ErrResetPasswordFail,
+	consts.CodeAuthTokenGenFail:            consts.ErrAuthTokenGenFail,
+	consts.CodeUserAlreadyExists:           consts.ErrAuthUserExists,
+	consts.CodeInvalidCredentials:          consts.ErrAuthInvalidCredentials,
+	consts.CodeTooManyAttempts:             consts.ErrTooManyAttempts,
+	consts.CodeInvalidUserID:               consts.ErrAuthInvalidUserID,
+	consts.CodeInvalidRefreshToken:         consts.ErrAuthInvalidRefreshToken,
+	consts.CodeTokenEmpty:                  consts.ErrAuthEmptyToken,
+	consts.CodeAuthHeaderMissing:           consts.ErrAuthNoAuthHeader,
+	consts.CodeUserIDTypeInvalid:           consts.ErrAuthUserIDTypeInvalid,
+	consts.CodeTokenNotFoundInContext:      consts.ErrAuthTokenNotFound,
+	consts.CodeJWTRevokeError:              consts.ErrAuthRevokeFail,
+	consts.CodeTokenInvalid:                consts.ErrAuthTokenInvalid,
+	consts.CodeTokenExpired:                consts.ErrAuthTokenExpired,
+	consts.CodeRefreshTokenReused:          consts.ErrAuthRefreshTokenReused,
+	consts.Code2FACheckError:               consts.Err2FACodeCheckFail,
+	consts.CodeInvalid2FACode:              consts.Err2FACodeInvalid,
+	consts.CodeLoginThrottled:              "ورود به دلیل تلاش‌های ناموفق متعدد موقتاً محدود شده است[؛ {wait} دیگر دوباره تلاش کنید]",
+	consts.CodeInvalidPurpose:              consts.ErrInvalidPurpose,
+	consts.CodeInvalidChannel:              consts.ErrInvalidChannel,
+	consts.CodeRateLimitCheckFail:          consts.ErrRateLimitCheckFail,
+	consts.CodeRateLimitExceeded:           consts.ErrRateLimitExceeded,
+	consts.Code2FAAttemptCheckFail:         consts.Err2FAAttemptCheckFail,
+	consts.CodeForgotPasswordEmailNotFound: consts.ErrForgotPasswordEmailNotFound,
+	consts.CodeForgotPasswordSendFail:      consts.ErrForgotPasswordSendFail,
+	consts.CodeInvalidPhone:                consts.ErrPhoneInvalid,
+	consts.CodeSendPhoneVerificationFail:   consts.ErrSendPhoneVerificationFail,
+	consts.CodeVerifyPhoneFail:             consts.ErrVerifyPhoneFail,
+	consts.CodeResendPhoneVerificationFail: consts.ErrResendPhoneVerificationFail,
+	consts.CodeVerifyEmailFail:             consts.ErrVerifyEmailFail,
+	consts.CodeResendEmailVerificationFail: consts.ErrResendEmailVerificationFail,
+	consts.CodeInvalidEmailOrPhone:         consts.ErrInvalidEmailOrPhone,
+	consts.CodeEmailAlreadyExists:          consts.ErrEmailExists,
+	consts.CodePhoneAlreadyExists:          consts.ErrPhoneExists,
+	consts.Code2FAResendFail:               consts.Err2FASendFail,
+	consts.CodeVerificationUsed:            consts.ErrVerificationUsed,
+	consts.CodeTOTPNotEnrolled:             consts.ErrTOTPNotEnrolled,
+	consts.CodeTOTPAlreadyEnabled:          consts.ErrTOTPAlreadyEnabled,
+	consts.CodeTOTPEnrollFail:              consts.ErrTOTPEnrollFail,
+	consts.CodeBackupCodeGenFail:           consts.ErrBackupCodeGenFail,
+	consts.CodeVerificationIssueFail:       consts.ErrVerificationIssueFail,
+	consts.CodeVerificationCheckFail:       consts.ErrVerificationCheckFail,
+	consts.CodeVerificationExpired:         consts.ErrVerificationExpired,
+	consts.CodeResendCooldown:              consts.ErrVerificationResendWait,
+	consts.Code2FACodeGen:                  "خطا در تولید کد تایید",
+	consts.Code2FASendFail:                 consts.ErrAuth2FACodeSendFail,
+	consts.CodeTokenGenFail:                consts.ErrAuthTokenGenFail,
+	consts.CodeLockoutCheckFail:            consts.ErrLockoutCheckFail,
+
+	// رمز عبور
+	consts.CodePasswordPolicyViolation: consts.ErrPasswordPolicyViolation,
+	consts.CodePasswordTooShort:        consts.ErrPasswordTooShort,
+	consts.CodePasswordTooLong:         consts.ErrPasswordTooLong,
+	consts.CodePasswordCharClasses:     consts.ErrPasswordCharClasses,
+	consts.CodePasswordCommon:          consts.ErrPasswordCommon,
+	consts.CodePasswordHasIdentifier:   consts.ErrPasswordHasIdentifier,
+	consts.CodePasswordWeak:            consts.ErrPasswordWeak,
+	consts.CodePasswordReused:          consts.ErrPasswordReused,
+
+	// کپچا و اثبات کار
+	consts.CodeCaptchaIDGenerationFailed: consts.ErrCaptchaIDGenFail,
+	consts.CodeCaptchaGenerationFailed:   consts.ErrCaptchaGenFail,
+	consts.CodeCaptchaStorageFailed:      consts.ErrCaptchaStoreFail,
+	consts.CodeInvalidCaptchaID:          consts.ErrCaptchaInvalidID,
+	consts.CodeInvalidCaptcha:            consts.ErrCaptchaInvalid,
+	consts.CodeCaptchaEmpty:              "پاسخ کپچا وارد نشده است",
+	consts.CodeCaptchaNotFound:           consts.ErrCaptchaNotFound,
+	consts.CodeCaptchaWrong:              consts.ErrCaptchaInvalid,
+	consts.CodeCaptchaRequired:           consts.ErrCaptchaRequired,
+	consts.CodePoWIssueFail:              consts.ErrPoWIssueFail,
+	consts.CodePoWInvalid:                consts.ErrPoWInvalid,
+	consts.CodePoWExpired:                consts.ErrPoWExpired,
+	consts.CodePoWInsufficient:           consts.ErrPoWInsufficient,
+	consts.CodePoWReplayed:               consts.ErrPoWReplayed,
+
+	// ارز
+	consts.CodeCurrencyListError: consts.ErrCurrencyListFailed,
+
+	// سفارش
+	consts.CodeOrderInvalidBody:              consts.ErrOrderInvalidBody,
+	consts.CodeOrderCreateError:              consts.ErrOrderCreateFailed,
+	consts.CodeOrderNotFound:                 consts.ErrOrderNotFound,
+	consts.CodeOrderUnauthorized:             consts.ErrOrderUnauthorized,
+	consts.CodeOrderForbidden:                consts.ErrOrderForbidden,
+	consts.CodeOrderInsufficientFunds:        consts.ErrOrderInsufficientFunds,
+	consts.CodeOrderInvalidAmount:            consts.ErrOrderInvalidAmount,
+	consts.CodeOrderInvalidID:                consts.ErrOrderInvalidID,
+	consts.CodeOrderCancelError:              consts.ErrOrderCancelFailed,
+	consts.CodeOrderCannotBeCanceled:         consts.ErrOrderCannotBeCanceled,
+	consts.CodeOrderAmountOutOfRange:         consts.ErrOrderAmountOutOfRange,
+	consts.CodeOrderPairNotFoundOrInactive:   consts.ErrOrderPairNotFoundOrInactive,
+	consts.CodeOrderWalletNotFoundOrInactive: consts.ErrOrderWalletNotFoundOrInactive,
+	consts.CodeOrderConflict:                 consts.ErrOrderConflict,
+	consts.CodeOrderTooManyRequests:          consts.ErrOrderTooManyRequests,
+	consts.CodeOrderTimeout:                  consts.ErrOrderTimeout,
+	consts.CodeOrderInvalidSide:              consts.ErrOrderInvalidSide,
+	consts.CodeOrderClientOrderIDTooLong:     consts.ErrOrderClientOrderIDTooLong,
+	consts.CodeOrderInvalidLimitPrice:        consts.ErrOrderInvalidLimitPrice,
+	consts.CodeOrderPriceNotAllowedForMarket: consts.ErrOrderPriceNotAllowedForMarket,
+	consts.CodeOrderPairIDInvalid:            consts.ErrOrderPairIDInvalid,
+	consts.CodeOrderInputInvalid:             consts.ErrOrderInputInvalid,
+	consts.CodeOrderInvalidType:              consts.ErrOrderInvalidType,
+	consts.CodeOrderInvalidStatusFilter:      consts.ErrOrderInvalidStatusFilter,
+	consts.CodeOrderInvalidPagination:        consts.ErrOrderInvalidPagination,
+
+	// کیف پول
+	consts.CodeInvalidWalletID:     consts.ErrWalletInvalidID,
+	consts.CodeInvalidCurrencyID:   consts.ErrWalletInvalidCurrencyID,
+	consts.CodeInvalidAmount:       consts.ErrWalletInvalidAmount,
+	consts.CodeInvalidWalletStatus: consts.ErrWalletInvalidStatus,
+	consts.CodeWalletNotFound:      consts.ErrWalletNotFound,
+	consts.CodeWalletInactive:      consts.ErrWalletInactive,
+	consts.CodeDepositError:        consts.ErrWalletDepositFailed,
+	consts.CodeWithdrawError:       consts.ErrWalletWithdrawFailed,
+	consts.CodeFreezeError:         consts.ErrWalletFreezeFailed,
+	consts.CodeUnfreezeError:       consts.ErrWalletUnfreezeFailed,
+	consts.CodeDeductFrozenError:   consts.ErrWalletDeductFrozenFailed,
+	consts.CodeTransferError:       consts.ErrWalletTransferFailed,
+	consts.CodeChangeStatusError:   consts.ErrWalletChangeStatusFailed,
+	consts.CodeWalletListError:     consts.ErrWalletListFailed,
+	consts.CodeWalletSummaryError:  consts.ErrWalletSummaryFailed,
+	consts.CodeWalletHistoryError:  consts.ErrWalletHistoryFailed,
+	consts.CodeBulkOperationError:  consts.ErrWalletBulkOperationFailed,
+	consts.CodeFetchError:          consts.ErrWalletFetchFailed,
+}
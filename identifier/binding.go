@@ -0,0 +1,39 @@
+package identifier
+
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// TagRegistrable تگ validator که فهرست دامنه‌های مسدود/موقت را فقط برای ثبت‌نام بررسی می‌کند
+const TagRegistrable = "registrable"
+
+// RegisterValidator باعث می‌شود تگ‌های validator (مثل required) روی Identifier مانند رشته عمل کنند
+// و تگ registrable را ثبت می‌کند
+func RegisterValidator(v *validator.Validate) {
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if id, ok := field.Interface().(Identifier); ok && !id.IsZero() {
+			return id.String()
+		}
+		return nil
+	}, Identifier{})
+	// نام تگ ثابت و معتبر است، پس خطا ممکن نیست
+	_ = v.RegisterValidation(TagRegistrable, func(fl validator.FieldLevel) bool {
+		id, err := Parse(fl.Field().String())
+		return err == nil && id.CheckRegistrable() == nil
+	})
+}
+
+// init ثبت روی validator پیش‌فرض gin؛ بدون آن binding درخواست‌هایی مثل model.RegisterRequest که تگ registrable دارند
+// با "Undefined validation function" panic می‌کند
+func init() { RegisterGinBinding() }
+
+// RegisterGinBinding Identifier را در validator فعلی gin ثبت می‌کند؛ هنگام import خودکار انجام می‌شود و
+// فقط اگر سرویس binding.Validator را با validator دیگری جایگزین کند باید دوباره صدا زده شود
+func RegisterGinBinding() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		RegisterValidator(v)
+	}
+}
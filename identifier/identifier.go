@@ -0,0 +1,198 @@
+package identifier
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/email"
+	"github.com/alisiahmansouri/exchange-common/phone"
+)
+
+// Kind نوع شناسه
+type Kind string
+
+const (
+	KindEmail Kind = "email"
+	KindPhone Kind = "phone"
+)
+
+// ErrInvalid ورودی نه ایمیل است و نه شماره موبایل (model.ErrInvalidIdentifier به همین مقدار اشاره می‌کند)
+var ErrInvalid = errors.New("شناسه ایمیل یا شماره موبایل نامعتبر است")
+
+// Identifier ایمیل یا شماره موبایل که یک بار تجزیه و نرمال شده است
+// (ایمیل: حروف کوچک با دامنه‌ی punycode، موبایل: E.164)
+type Identifier struct {
+	kind  Kind
+	value string
+}
+
+// Parse ورودی کاربر را به Identifier تبدیل می‌کند؛ فقط قالب بررسی می‌شود تا کاربران موجود پس از
+// افزودن دامنه‌شان به فهرست‌های مسدود/موقت همچنان بتوانند وارد شوند یا رمز را بازیابی کنند.
+// برای ثبت‌نام از ParseNew یا تگ registrable استفاده کنید.
+func Parse(raw string) (Identifier, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Identifier{}, ErrInvalid
+	}
+	if strings.Contains(raw, "@") {
+		a, err := email.Parse(raw)
+		if err != nil {
+			return Identifier{}, err
+		}
+		return Identifier{kind: KindEmail, value: a.String()}, nil
+	}
+	n, err := phone.Normalize(raw)
+	if err != nil {
+		return Identifier{}, ErrInvalid
+	}
+	return Identifier{kind: KindPhone, value: n}, nil
+}
+
+// ParseNew مانند Parse به همراه بررسی فهرست دامنه‌های مسدود/موقت (برای ثبت‌نام)
+func ParseNew(raw string) (Identifier, error) {
+	id, err := Parse(raw)
+	if err != nil {
+		return Identifier{}, err
+	}
+	if err := id.CheckRegistrable(); err != nil {
+		return Identifier{}, err
+	}
+	return id, nil
+}
+
+// CheckRegistrable دامنه‌ی ایمیل در فهرست‌های مسدود/موقت نباشد؛ موبایل همیشه مجاز است
+func (i Identifier) CheckRegistrable() error {
+	if i.kind != KindEmail {
+		return nil
+	}
+	_, err := email.Validate(i.value)
+	return err
+}
+
+// MustParse برای مقادیر ثابت (تست و seed)
+func MustParse(raw string) Identifier {
+	id, err := Parse(raw)
+	if err != nil {
+		panic(fmt.Sprintf("identifier: %q: %v", raw, err))
+	}
+	return id
+}
+
+func (i Identifier) Kind() Kind              { return i.kind }
+func (i Identifier) String() string          { return i.value }
+func (i Identifier) IsZero() bool            { return i.value == "" }
+func (i Identifier) IsEmail() bool           { return i.kind == KindEmail }
+func (i Identifier) IsPhone() bool           { return i.kind == KindPhone }
+func (i Identifier) Equal(o Identifier) bool { return i.value == o.value }
+
+// Channel کانال پیش‌فرض ارسال کد تایید
+func (i Identifier) Channel() string {
+	switch i.kind {
+	case KindEmail:
+		return consts.ChannelEmail
+	case KindPhone:
+		return consts.ChannelSMS
+	}
+	return ""
+}
+
+// Mask نسخه‌ی قابل ثبت در لاگ (a***@g***.com یا +98912***4567)
+func (i Identifier) Mask() string {
+	switch i.kind {
+	case KindEmail:
+		local, domain, _ := strings.Cut(i.value, "@")
+		name, tld := domain, ""
+		if dot := strings.LastIndexByte(domain, '.'); dot > 0 {
+			name, tld = domain[:dot], domain[dot:]
+		}
+		return maskHead(local, 1) + "@" + maskHead(name, 1) + tld
+	case KindPhone:
+		if len(i.value) > 10 {
+			return i.value[:6] + "***" + i.value[len(i.value)-4:]
+		}
+		return maskHead(i.value, 3)
+	}
+	return maskHead(i.value, 1)
+}
+
+// LogValue در slog همیشه نسخه‌ی ماسک‌شده ثبت می‌شود
+func (i Identifier) LogValue() slog.Value {
+	return slog.StringValue(i.Mask())
+}
+
+func (i Identifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.value)
+}
+
+// UnmarshalJSON مقدار را تجزیه می‌کند؛ رشته‌ی خالی مقدار صفر می‌دهد تا binding:"required" خطا بدهد
+func (i *Identifier) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrInvalid
+	}
+	if strings.TrimSpace(s) == "" {
+		*i = Identifier{}
+		return nil
+	}
+	id, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*i = id
+	return nil
+}
+
+// UnmarshalText برای binding فرم و query
+func (i *Identifier) UnmarshalText(b []byte) error {
+	if strings.TrimSpace(string(b)) == "" {
+		*i = Identifier{}
+		return nil
+	}
+	id, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*i = id
+	return nil
+}
+
+// Value مقدار نرمال‌شده را در پایگاه داده ذخیره می‌کند
+func (i Identifier) Value() (driver.Value, error) {
+	return i.value, nil
+}
+
+// Scan مقادیر قدیمی (مثلاً 0912...) هم در صورت امکان نرمال می‌شوند؛ مقدار غیرقابل تجزیه بدون تغییر نگه داشته می‌شود.
+// فهرست دامنه‌ها بررسی نمی‌شود (داده‌ی ذخیره‌شده قبلاً پذیرفته شده است)
+func (i *Identifier) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*i = Identifier{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("identifier: cannot scan %T", src)
+	}
+	if id, err := Parse(s); err == nil {
+		*i = id
+	} else {
+		*i = Identifier{value: s}
+	}
+	return nil
+}
+
+func maskHead(s string, keep int) string {
+	r := []rune(s)
+	if len(r) <= keep {
+		return strings.Repeat("*", len(r))
+	}
+	return string(r[:keep]) + "***"
+}
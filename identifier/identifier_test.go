@@ -0,0 +1,253 @@
+package identifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/email"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		kind    Kind
+		want    string
+		wantErr error
+	}{
+		{"email", " User@Example.COM ", KindEmail, "user@example.com", nil},
+		{"idn email", "a@مثال.ایران", KindEmail, "a@xn--mgbh0fb.xn--mgba3a4f16a", nil},
+		{"local phone", "09121234567", KindPhone, "+989121234567", nil},
+		{"persian digits phone", "۰۹۱۲۱۲۳۴۵۶۷", KindPhone, "+989121234567", nil},
+		{"disposable email allowed for existing users", "a@mailinator.com", KindEmail, "a@mailinator.com", nil},
+		{"empty", "  ", "", "", ErrInvalid},
+		{"bad phone", "12345", "", "", ErrInvalid},
+		{"bad email", "a@@b", "", "", email.ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := Parse(tt.raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if id.Kind() != tt.kind || id.String() != tt.want {
+				t.Fatalf("Parse = (%s, %q), want (%s, %q)", id.Kind(), id.String(), tt.kind, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNew(t *testing.T) {
+	tests := []struct {
+		raw     string
+		wantErr error
+	}{
+		{"user@gmail.com", nil},
+		{"09121234567", nil},
+		{"a@mailinator.com", email.ErrDisposable},
+	}
+	for _, tt := range tests {
+		if _, err := ParseNew(tt.raw); !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseNew(%q) err = %v, want %v", tt.raw, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var v struct {
+		ID Identifier `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(`{"id":"09121234567"}`), &v); err != nil || v.ID.String() != "+989121234567" {
+		t.Fatalf("got %q, %v", v.ID, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":""}`), &v); err != nil || !v.ID.IsZero() {
+		t.Fatalf("empty: got %q, %v", v.ID, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"nope"}`), &v); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("invalid: err = %v", err)
+	}
+}
+
+func TestValidatorTags(t *testing.T) {
+	v := validator.New()
+	RegisterValidator(v)
+	type register struct {
+		ID Identifier `validate:"required,registrable"`
+	}
+	type login struct {
+		ID Identifier `validate:"required"`
+	}
+	disposable := MustParse("a@mailinator.com")
+	tests := []struct {
+		name  string
+		value interface{}
+		ok    bool
+	}{
+		{"register ok", register{MustParse("user@gmail.com")}, true},
+		{"register phone", register{MustParse("09121234567")}, true},
+		{"register disposable", register{disposable}, false},
+		{"register empty", register{}, false},
+		{"login disposable", login{disposable}, true},
+		{"login empty", login{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.Struct(tt.value); (err == nil) != tt.ok {
+				t.Fatalf("Struct err = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct{ raw, want string }{
+		{"alice@gmail.com", "a***@g***.com"},
+		{"09121234567", "+98912***4567"},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.raw).Mask(); got != tt.want {
+			t.Errorf("Mask(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	tests := []struct {
+		raw     string
+		email   bool
+		phone   bool
+		channel string
+	}{
+		{"user@gmail.com", true, false, consts.ChannelEmail},
+		{"09121234567", false, true, consts.ChannelSMS},
+	}
+	for _, tt := range tests {
+		id := MustParse(tt.raw)
+		if id.IsEmail() != tt.email || id.IsPhone() != tt.phone || id.Channel() != tt.channel {
+			t.Errorf("%q: email=%v phone=%v channel=%q", tt.raw, id.IsEmail(), id.IsPhone(), id.Channel())
+		}
+	}
+	if (Identifier{}).Channel() != "" {
+		t.Fatal("zero value has a channel")
+	}
+	if !MustParse("User@Gmail.com").Equal(MustParse("user@gmail.com")) || !MustParse("۰۹۱۲۱۲۳۴۵۶۷").Equal(MustParse("+989121234567")) {
+		t.Fatal("normalized forms are not equal")
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustParse did not panic")
+		}
+	}()
+	MustParse("nope")
+}
+
+func TestMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		ID Identifier `json:"id"`
+	}{MustParse("09121234567")})
+	if err != nil || string(b) != `{"id":"+989121234567"}` {
+		t.Fatalf("Marshal = %s, %v", b, err)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr error
+	}{
+		{"User@Gmail.com", "user@gmail.com", nil},
+		{" ", "", nil},
+		{"12345", "", ErrInvalid},
+	}
+	for _, tt := range tests {
+		var id Identifier
+		err := id.UnmarshalText([]byte(tt.raw))
+		if !errors.Is(err, tt.wantErr) || id.String() != tt.want {
+			t.Errorf("UnmarshalText(%q) = %q, %v; want %q, %v", tt.raw, id, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestScanValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    string
+		kind    Kind
+		wantErr bool
+	}{
+		{"legacy local phone", "09121234567", "+989121234567", KindPhone, false},
+		{"bytes", []byte("User@Gmail.com"), "user@gmail.com", KindEmail, false},
+		{"blocked domain still loads", "a@mailinator.com", "a@mailinator.com", KindEmail, false},
+		{"unparsable kept as is", "legacy-user", "legacy-user", "", false},
+		{"null", nil, "", "", false},
+		{"wrong type", 42, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id Identifier
+			err := id.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if id.String() != tt.want || id.Kind() != tt.kind {
+				t.Fatalf("Scan = (%s, %q), want (%s, %q)", id.Kind(), id, tt.kind, tt.want)
+			}
+			if v, _ := id.Value(); v != tt.want {
+				t.Fatalf("Value = %v, want %q", v, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("login", "id", MustParse("alice@gmail.com"))
+	if out := buf.String(); strings.Contains(out, "alice") || !strings.Contains(out, "a***@g***.com") {
+		t.Fatalf("log line leaks the identifier: %s", out)
+	}
+}
+
+func TestGinBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	RegisterGinBinding()
+	type request struct {
+		ID Identifier `json:"id" binding:"required,registrable"`
+	}
+	tests := []struct {
+		body string
+		want string
+		ok   bool
+	}{
+		{`{"id":"09121234567"}`, "+989121234567", true},
+		{`{"id":"a@mailinator.com"}`, "", false},
+		{`{"id":""}`, "", false},
+		{`{"id":"nope"}`, "", false},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		var req request
+		err := c.ShouldBindJSON(&req)
+		if (err == nil) != tt.ok || (tt.ok && req.ID.String() != tt.want) {
+			t.Errorf("bind %s = %q, %v; want %q ok=%v", tt.body, req.ID, err, tt.want, tt.ok)
+		}
+	}
+}
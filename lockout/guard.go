@@ -0,0 +1,334 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/i18n"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/alisiahmansouri/exchange-common/util"
+)
+
+// Scope دامنه‌ی قفل
+type Scope string
+
+const (
+	ScopeAccountIP Scope = "account_ip" // یک حساب از یک IP
+	ScopeAccount   Scope = "account"    // یک حساب از همه‌ی IPها؛ قفل نمی‌کند و فقط چالش (کپچا/اثبات کار) را الزامی می‌کند
+	ScopeIP        Scope = "ip"         // یک IP برای همه‌ی حساب‌ها
+	ScopeStuffing  Scope = "stuffing"   // یک IP که حساب‌های متعدد را امتحان می‌کند
+)
+
+// Status وضعیت قفل برای یک تلاش ورود
+type Status struct {
+	Locked   bool
+	Scope    Scope
+	Until    time.Time
+	Wait     time.Duration
+	Failures int
+
+	// ChallengeRequired حساب از IPهای متعدد هدف قرار گرفته؛ تلاش‌ها از IP ناشناس باید کپچا یا اثبات کار داشته باشند
+	ChallengeRequired bool
+}
+
+// LockEvent اطلاعات ارسالی به Notifier هنگام قفل شدن حساب
+type LockEvent struct {
+	Identifier string
+	IP         string
+	Scope      Scope
+	Failures   int
+	Until      time.Time
+}
+
+// Notifier مالک حساب را از قفل شدن آن مطلع می‌کند (ایمیل، پیامک، پوش)؛
+// در مسیر درخواست صدا زده می‌شود، پس ارسال واقعی باید غیرهمزمان باشد
+type Notifier interface {
+	AccountLocked(ctx context.Context, e LockEvent)
+}
+
+// NotifierFunc تابع را به Notifier تبدیل می‌کند
+type NotifierFunc func(ctx context.Context, e LockEvent)
+
+func (f NotifierFunc) AccountLocked(ctx context.Context, e LockEvent) { f(ctx, e) }
+
+type Config struct {
+	IdentifierIPAttempts int           // پیش‌فرض: consts.LockoutIdentifierIPAttempts
+	IdentifierAttempts   int           // پیش‌فرض: consts.LockoutIdentifierAttempts
+	IPAttempts           int           // پیش‌فرض: consts.LockoutIPAttempts
+	BaseDelay            time.Duration // پیش‌فرض: consts.LockoutBaseDelay
+	MaxDelay             time.Duration // پیش‌فرض: consts.LockoutMaxDelay
+	FailureTTL           time.Duration // پیش‌فرض: consts.LockoutFailureTTL
+	StuffingIdentifiers  int           // پیش‌فرض: consts.LockoutStuffingIdentifiers
+	StuffingWindow       time.Duration // پیش‌فرض: consts.LockoutStuffingWindow
+	StuffingDuration     time.Duration // پیش‌فرض: consts.LockoutStuffingDuration
+	TrustedTTL           time.Duration // مدت اعتماد به IP پس از ورود موفق؛ پیش‌فرض: consts.LockoutTrustedTTL
+	Notifier             Notifier      // اختیاری
+	Now                  func() time.Time
+}
+
+// Guard قفل تدریجی ورود: پس از تعداد مجاز شکست، هر شکست بعدی تأخیر را دو برابر می‌کند
+// و قفل پس از پایان تأخیر خودکار باز می‌شود. قفل فقط برای حساب+IP و IP اعمال می‌شود؛
+// شکست‌های یک حساب از IPهای متعدد آن را قفل نمی‌کند (تا مهاجم نتواند مالک را بیرون نگه دارد)
+// و فقط برای IPهایی که پیش‌تر ورود موفق نداشته‌اند چالش را الزامی می‌کند.
+type Guard struct {
+	store Store
+	cfg   Config
+}
+
+func NewGuard(store Store, cfg Config) *Guard {
+	if cfg.IdentifierIPAttempts <= 0 {
+		cfg.IdentifierIPAttempts = consts.LockoutIdentifierIPAttempts
+	}
+	if cfg.IdentifierAttempts <= 0 {
+		cfg.IdentifierAttempts = consts.LockoutIdentifierAttempts
+	}
+	if cfg.IPAttempts <= 0 {
+		cfg.IPAttempts = consts.LockoutIPAttempts
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = consts.LockoutBaseDelay
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = consts.LockoutMaxDelay
+	}
+	if cfg.FailureTTL <= 0 {
+		cfg.FailureTTL = consts.LockoutFailureTTL
+	}
+	if cfg.StuffingIdentifiers <= 0 {
+		cfg.StuffingIdentifiers = consts.LockoutStuffingIdentifiers
+	}
+	if cfg.StuffingWindow <= 0 {
+		cfg.StuffingWindow = consts.LockoutStuffingWindow
+	}
+	if cfg.StuffingDuration <= 0 {
+		cfg.StuffingDuration = consts.LockoutStuffingDuration
+	}
+	if cfg.TrustedTTL <= 0 {
+		cfg.TrustedTTL = consts.LockoutTrustedTTL
+	}
+	if cfg.Now == nil {
+		cfg.Now = util.NowUTC
+	}
+	return &Guard{store: store, cfg: cfg}
+}
+
+// Check پیش از بررسی رمز عبور صدا زده می‌شود؛ در صورت قفل بودن خطای CodeLoginThrottled
+// با زمان انتظار باقی‌مانده (قابل استخراج با errors.As به *ThrottledError) برمی‌گرداند
+//
+// Status.ChallengeRequired بدون خطا برمی‌گردد و فراخواننده باید کپچا یا اثبات کار را الزامی کند.
+func (g *Guard) Check(ctx context.Context, identifier, ip string) (Status, error) {
+	now := g.cfg.Now()
+	var worst Status
+	challenge := false
+	for _, k := range g.keys(identifier, ip) {
+		rec, err := g.store.Get(ctx, k.key)
+		if err != nil {
+			return Status{}, richerror.Wrap(consts.OpLockoutCheck, err, consts.ErrLockoutCheckFail, consts.CodeLockoutCheckFail, richerror.KindInternal)
+		}
+		if k.scope == ScopeAccount {
+			challenge = rec.Failures >= k.free
+			continue
+		}
+		if now.Before(rec.LockedUntil) && rec.LockedUntil.After(worst.Until) {
+			worst = Status{Locked: true, Scope: k.scope, Until: rec.LockedUntil, Wait: rec.LockedUntil.Sub(now), Failures: rec.Failures}
+		}
+	}
+	if challenge {
+		trusted, err := g.trusted(ctx, identifier, ip)
+		if err != nil {
+			return Status{}, richerror.Wrap(consts.OpLockoutCheck, err, consts.ErrLockoutCheckFail, consts.CodeLockoutCheckFail, richerror.KindInternal)
+		}
+		worst.ChallengeRequired = !trusted
+	}
+	if worst.Locked {
+		return worst, throttled(consts.OpLockoutCheck, worst)
+	}
+	return worst, nil
+}
+
+// RecordFailure یک تلاش ناموفق را در همه‌ی دامنه‌ها ثبت می‌کند و وضعیت قفل حاصل را برمی‌گرداند
+func (g *Guard) RecordFailure(ctx context.Context, identifier, ip string) (Status, error) {
+	now := g.cfg.Now()
+	var worst Status
+	var event *LockEvent
+	challenge := false
+	for _, k := range g.keys(identifier, ip) {
+		if k.scope == ScopeStuffing {
+			continue
+		}
+		var rec Record
+		notify := false
+		err := g.store.Update(ctx, k.key, g.cfg.FailureTTL, func(r *Record) {
+			r.Failures++
+			if k.scope == ScopeAccount {
+				// حمله‌ی توزیع‌شده روی حساب: فقط چالش، بدون قفل
+				if r.Failures >= k.free && !r.Notified {
+					r.Notified, notify = true, true
+				}
+			} else if d := g.delay(r.Failures, k.free); d > 0 {
+				r.LockedUntil = maxTime(r.LockedUntil, now.Add(d))
+				if k.scope != ScopeIP && !r.Notified {
+					r.Notified, notify = true, true
+				}
+			}
+			rec = *r
+		})
+		if err != nil {
+			return Status{}, richerror.Wrap(consts.OpLockoutFailure, err, consts.ErrLockoutCheckFail, consts.CodeLockoutCheckFail, richerror.KindInternal)
+		}
+		if notify && event == nil {
+			event = &LockEvent{Identifier: identifier, IP: ip, Scope: k.scope, Failures: rec.Failures, Until: rec.LockedUntil}
+		}
+		if k.scope == ScopeAccount {
+			challenge = rec.Failures >= k.free
+			continue
+		}
+		if now.Before(rec.LockedUntil) && rec.LockedUntil.After(worst.Until) {
+			worst = Status{Locked: true, Scope: k.scope, Until: rec.LockedUntil, Wait: rec.LockedUntil.Sub(now), Failures: rec.Failures}
+		}
+	}
+
+	// یک اعلان برای هر تلاش، حتی اگر هر دو دامنه‌ی حساب همزمان فعال شوند
+	if event != nil && g.cfg.Notifier != nil {
+		g.cfg.Notifier.AccountLocked(ctx, *event)
+	}
+	if challenge {
+		trusted, err := g.trusted(ctx, identifier, ip)
+		if err != nil {
+			return Status{}, richerror.Wrap(consts.OpLockoutFailure, err, consts.ErrLockoutCheckFail, consts.CodeLockoutCheckFail, richerror.KindInternal)
+		}
+		worst.ChallengeRequired = !trusted
+	}
+
+	if ip != "" && identifier != "" {
+		n, err := g.store.AddDistinct(ctx, stuffingSetKey(ip), identifier, g.cfg.StuffingWindow)
+		if err != nil {
+			return Status{}, richerror.Wrap(consts.OpLockoutFailure, err, consts.ErrLockoutCheckFail, consts.CodeLockoutCheckFail, richerror.KindInternal)
+		}
+		if n >= g.cfg.StuffingIdentifiers {
+			until := now.Add(g.cfg.StuffingDuration)
+			err := g.store.Update(ctx, stuffingLockKey(ip), g.cfg.StuffingDuration, func(r *Record) {
+				r.Failures = n
+				r.LockedUntil = maxTime(r.LockedUntil, until)
+			})
+			if err != nil {
+				return Status{}, richerror.Wrap(consts.OpLockoutFailure, err, consts.ErrLockoutCheckFail, consts.CodeLockoutCheckFail, richerror.KindInternal)
+			}
+			if until.After(worst.Until) {
+				worst = Status{Locked: true, Scope: ScopeStuffing, Until: until, Wait: until.Sub(now), Failures: n, ChallengeRequired: worst.ChallengeRequired}
+			}
+		}
+	}
+	return worst, nil
+}
+
+// RecordSuccess پس از ورود موفق شکست‌های حساب را پاک و IP را برای TrustedTTL مورد اعتماد علامت می‌زند؛
+// شمارنده‌های IP باقی می‌مانند
+func (g *Guard) RecordSuccess(ctx context.Context, identifier, ip string) error {
+	if identifier == "" {
+		return nil
+	}
+	if ip != "" {
+		if err := g.store.Delete(ctx, accountIPKey(identifier, ip)); err != nil {
+			return err
+		}
+		err := g.store.Update(ctx, trustedKey(identifier, ip), g.cfg.TrustedTTL, func(r *Record) {
+			r.Trusted = true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return g.store.Delete(ctx, accountKey(identifier))
+}
+
+// Unlock قفل حساب را در همه‌ی دامنه‌های حساب (از جمله حساب+IP برای همه‌ی IPها) دستی باز می‌کند
+// (پشتیبانی یا بازیابی رمز عبور)؛ IPهای مورد اعتماد حفظ می‌شوند
+func (g *Guard) Unlock(ctx context.Context, identifier string) error {
+	if identifier == "" {
+		return nil
+	}
+	if err := g.store.DeletePrefix(ctx, accountIPPrefix(identifier)); err != nil {
+		return err
+	}
+	return g.store.Delete(ctx, accountKey(identifier))
+}
+
+// trusted این IP پیش‌تر ورود موفق به حساب داشته است
+func (g *Guard) trusted(ctx context.Context, identifier, ip string) (bool, error) {
+	if ip == "" {
+		return false, nil
+	}
+	rec, err := g.store.Get(ctx, trustedKey(identifier, ip))
+	return rec.Trusted, err
+}
+
+// delay تأخیر قفل پس از failures شکست: صفر تا free، سپس BaseDelay * 2^(failures-free) تا سقف MaxDelay
+func (g *Guard) delay(failures, free int) time.Duration {
+	if failures < free {
+		return 0
+	}
+	d := g.cfg.BaseDelay
+	for i := free; i < failures && d < g.cfg.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, g.cfg.MaxDelay)
+}
+
+type scopedKey struct {
+	scope Scope
+	key   string
+	free  int
+}
+
+func (g *Guard) keys(identifier, ip string) []scopedKey {
+	keys := make([]scopedKey, 0, 4)
+	if identifier != "" {
+		if ip != "" {
+			keys = append(keys, scopedKey{ScopeAccountIP, accountIPKey(identifier, ip), g.cfg.IdentifierIPAttempts})
+		}
+		keys = append(keys, scopedKey{ScopeAccount, accountKey(identifier), g.cfg.IdentifierAttempts})
+	}
+	if ip != "" {
+		keys = append(keys,
+			scopedKey{ScopeIP, ipKey(ip), g.cfg.IPAttempts},
+			scopedKey{ScopeStuffing, stuffingLockKey(ip), 0},
+		)
+	}
+	return keys
+}
+
+func accountIPPrefix(identifier string) string  { return "lockout:acc_ip:" + identifier + ":" }
+func accountIPKey(identifier, ip string) string { return accountIPPrefix(identifier) + ip }
+func trustedKey(identifier, ip string) string   { return "lockout:trusted:" + identifier + ":" + ip }
+func accountKey(identifier string) string       { return "lockout:acc:" + identifier }
+func ipKey(ip string) string                    { return "lockout:ip:" + ip }
+func stuffingLockKey(ip string) string          { return "lockout:stuff:" + ip }
+func stuffingSetKey(ip string) string           { return "lockout:stuff_ids:" + ip }
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// ThrottledError جزئیات قفل؛ به model.ErrLoginThrottled باز می‌شود
+type ThrottledError struct {
+	Status Status
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s (%s, %s)", model.ErrLoginThrottled, e.Status.Scope, i18n.FormatDuration(i18n.FA, e.Status.Wait))
+}
+
+func (e *ThrottledError) Unwrap() error { return model.ErrLoginThrottled }
+
+// throttled خطای CodeLoginThrottled با پیام شامل زمان انتظار؛ wait برای پیام کاتالوگ زبان‌های دیگر هم فرستاده می‌شود
+func throttled(op string, s Status) error {
+	return richerror.New(op, fmt.Sprintf(consts.ErrLoginThrottledWait, i18n.FormatDuration(i18n.FA, s.Wait)), consts.CodeLoginThrottled, richerror.KindTooManyRequests, &ThrottledError{Status: s}).
+		WithParams(map[string]interface{}{"wait": s.Wait})
+}
@@ -0,0 +1,193 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestGuard(cfg Config) (*Guard, *fakeClock, *[]LockEvent) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	var events []LockEvent
+	cfg.Now = clock.Now
+	cfg.Notifier = NotifierFunc(func(_ context.Context, e LockEvent) { events = append(events, e) })
+	store := NewMemoryStore()
+	store.now = clock.Now
+	return NewGuard(store, cfg), clock, &events
+}
+
+func TestGuardDelay(t *testing.T) {
+	g, _, _ := newTestGuard(Config{BaseDelay: time.Second, MaxDelay: 10 * time.Second})
+	tests := []struct {
+		failures, free int
+		want           time.Duration
+	}{
+		{0, 3, 0},
+		{2, 3, 0},
+		{3, 3, time.Second},
+		{4, 3, 2 * time.Second},
+		{6, 3, 8 * time.Second},
+		{7, 3, 10 * time.Second},
+		{100, 3, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d/%d", tt.failures, tt.free), func(t *testing.T) {
+			if got := g.delay(tt.failures, tt.free); got != tt.want {
+				t.Fatalf("delay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuardAccountIPLock(t *testing.T) {
+	ctx := context.Background()
+	g, clock, events := newTestGuard(Config{IdentifierIPAttempts: 3, BaseDelay: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if st, err := g.RecordFailure(ctx, "a@x.com", "1.1.1.1"); err != nil || st.Locked {
+			t.Fatalf("failure %d: %+v, %v", i+1, st, err)
+		}
+	}
+	st, err := g.RecordFailure(ctx, "a@x.com", "1.1.1.1")
+	if err != nil || !st.Locked || st.Scope != ScopeAccountIP || st.Wait != time.Minute {
+		t.Fatalf("third failure: %+v, %v", st, err)
+	}
+	if len(*events) != 1 {
+		t.Fatalf("events = %d, want 1", len(*events))
+	}
+
+	_, err = g.Check(ctx, "a@x.com", "1.1.1.1")
+	var re *richerror.RichError
+	if !errors.As(err, &re) || re.Code != consts.CodeLoginThrottled || !errors.Is(err, model.ErrLoginThrottled) {
+		t.Fatalf("Check err = %v", err)
+	}
+	if re.Params["wait"] != time.Minute {
+		t.Fatalf("wait param = %v, want %v", re.Params["wait"], time.Minute)
+	}
+	var te *ThrottledError
+	if !errors.As(err, &te) || te.Status.Scope != ScopeAccountIP {
+		t.Fatalf("ThrottledError not extractable: %v", err)
+	}
+
+	// IP دیگر قفل نیست
+	if st, err := g.Check(ctx, "a@x.com", "2.2.2.2"); err != nil || st.Locked {
+		t.Fatalf("other IP: %+v, %v", st, err)
+	}
+
+	// پس از پایان تأخیر خودکار باز می‌شود
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := g.Check(ctx, "a@x.com", "1.1.1.1"); err != nil {
+		t.Fatalf("after delay: %v", err)
+	}
+}
+
+// حمله‌ی توزیع‌شده روی یک حساب نباید مالک را از IP خودش بیرون نگه دارد
+func TestGuardAccountScopeChallengesOnly(t *testing.T) {
+	ctx := context.Background()
+	g, _, events := newTestGuard(Config{IdentifierIPAttempts: 100, IdentifierAttempts: 4})
+
+	if err := g.RecordSuccess(ctx, "a@x.com", "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		ip := fmt.Sprintf("6.6.6.%d", i)
+		st, err := g.RecordFailure(ctx, "a@x.com", ip)
+		if err != nil || st.Locked {
+			t.Fatalf("failure from %s: %+v, %v", ip, st, err)
+		}
+		if want := i+1 >= 4; st.ChallengeRequired != want {
+			t.Fatalf("failure %d: ChallengeRequired = %v, want %v", i+1, st.ChallengeRequired, want)
+		}
+	}
+	if len(*events) != 1 || (*events)[0].Scope != ScopeAccount {
+		t.Fatalf("events = %+v, want one account event", *events)
+	}
+
+	tests := []struct {
+		name      string
+		ip        string
+		challenge bool
+	}{
+		{"trusted owner IP", "10.0.0.1", false},
+		{"unknown IP", "7.7.7.7", true},
+		{"attacker IP", "6.6.6.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := g.Check(ctx, "a@x.com", tt.ip)
+			if err != nil || st.Locked {
+				t.Fatalf("Check: %+v, %v", st, err)
+			}
+			if st.ChallengeRequired != tt.challenge {
+				t.Fatalf("ChallengeRequired = %v, want %v", st.ChallengeRequired, tt.challenge)
+			}
+		})
+	}
+}
+
+func TestGuardStuffing(t *testing.T) {
+	ctx := context.Background()
+	g, _, _ := newTestGuard(Config{StuffingIdentifiers: 3})
+	var st Status
+	var err error
+	for i := 0; i < 3; i++ {
+		st, err = g.RecordFailure(ctx, fmt.Sprintf("u%d@x.com", i), "9.9.9.9")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !st.Locked || st.Scope != ScopeStuffing {
+		t.Fatalf("status = %+v, want stuffing lock", st)
+	}
+	if _, err := g.Check(ctx, "new@x.com", "9.9.9.9"); !errors.Is(err, model.ErrLoginThrottled) {
+		t.Fatalf("Check err = %v, want throttled", err)
+	}
+}
+
+func TestGuardUnlockClearsAllAccountScopes(t *testing.T) {
+	ctx := context.Background()
+	g, _, _ := newTestGuard(Config{IdentifierIPAttempts: 1, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	for _, ip := range []string{"1.1.1.1", "2.2.2.2"} {
+		if st, _ := g.RecordFailure(ctx, "a@x.com", ip); !st.Locked {
+			t.Fatalf("%s not locked", ip)
+		}
+	}
+	// حساب دیگری با پیشوند مشابه نباید پاک شود
+	if st, _ := g.RecordFailure(ctx, "a@x.com.evil", "1.1.1.1"); !st.Locked {
+		t.Fatal("other account not locked")
+	}
+
+	if err := g.Unlock(ctx, "a@x.com"); err != nil {
+		t.Fatal(err)
+	}
+	for _, ip := range []string{"1.1.1.1", "2.2.2.2"} {
+		if _, err := g.Check(ctx, "a@x.com", ip); err != nil {
+			t.Fatalf("%s still locked after Unlock: %v", ip, err)
+		}
+	}
+	if _, err := g.Check(ctx, "a@x.com.evil", "1.1.1.1"); err == nil {
+		t.Fatal("Unlock cleared another account")
+	}
+}
+
+func TestThrottledMessage(t *testing.T) {
+	err := throttled(consts.OpLockoutCheck, Status{Locked: true, Scope: ScopeIP, Wait: 61 * time.Second})
+	var re *richerror.RichError
+	if !errors.As(err, &re) || re.UserMessage != fmt.Sprintf(consts.ErrLoginThrottledWait, "2 دقیقه") {
+		t.Fatalf("user message = %q", re.UserMessage)
+	}
+	var te *ThrottledError
+	if !errors.As(err, &te) || te.Error() != model.ErrLoginThrottled.Error()+" (ip, 2 دقیقه)" {
+		t.Fatalf("ThrottledError = %v", te)
+	}
+}
@@ -0,0 +1,143 @@
+package lockout
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record وضعیت شکست‌های یک کلید (حساب، حساب+IP یا IP)
+type Record struct {
+	Failures    int
+	LockedUntil time.Time
+	Notified    bool // مالک حساب از قفل این سری شکست‌ها مطلع شده است (تا ورود موفق یا انقضای رکورد تکرار نمی‌شود)
+	Trusted     bool // IP پیش‌تر ورود موفق به این حساب داشته است
+}
+
+// Store نگهداری وضعیت قفل‌ها؛ Update باید برای هر کلید اتمیک باشد
+type Store interface {
+	// Get وضعیت کلید (یا مقدار صفر)
+	Get(ctx context.Context, key string) (Record, error)
+	// Update وضعیت کلید را به fn می‌دهد و نتیجه را تا ttl نگه می‌دارد
+	Update(ctx context.Context, key string, ttl time.Duration, fn func(*Record)) error
+	// Delete وضعیت کلید را پاک می‌کند
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix وضعیت همه‌ی کلیدهای شروع‌شده با prefix را پاک می‌کند
+	DeletePrefix(ctx context.Context, prefix string) error
+	// AddDistinct عضو را به مجموعه‌ی کلید اضافه و تعداد اعضای متمایز window اخیر را برمی‌گرداند
+	AddDistinct(ctx context.Context, key, member string, window time.Duration) (int, error)
+}
+
+type memoryRecord struct {
+	rec       Record
+	expiresAt time.Time
+}
+
+// MemoryStore پیاده‌سازی درون‌حافظه‌ای Store برای یک نمونه‌ی سرویس
+type MemoryStore struct {
+	mu        sync.Mutex
+	records   map[string]*memoryRecord
+	sets      map[string]map[string]time.Time
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]*memoryRecord),
+		sets:    make(map[string]map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	if !ok || !s.now().Before(r.expiresAt) {
+		return Record{}, nil
+	}
+	return r.rec, nil
+}
+
+func (s *MemoryStore) Update(_ context.Context, key string, ttl time.Duration, fn func(*Record)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+	r, ok := s.records[key]
+	if !ok || !now.Before(r.expiresAt) {
+		r = &memoryRecord{}
+		s.records[key] = r
+	}
+	fn(&r.rec)
+	r.expiresAt = now.Add(ttl)
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	delete(s.sets, key)
+	return nil
+}
+
+func (s *MemoryStore) DeletePrefix(_ context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.records {
+		if strings.HasPrefix(k, prefix) {
+			delete(s.records, k)
+		}
+	}
+	for k := range s.sets {
+		if strings.HasPrefix(k, prefix) {
+			delete(s.sets, k)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) AddDistinct(_ context.Context, key, member string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	set, ok := s.sets[key]
+	if !ok {
+		set = make(map[string]time.Time)
+		s.sets[key] = set
+	}
+	set[member] = now
+	for m, t := range set {
+		if now.Sub(t) > window {
+			delete(set, m)
+		}
+	}
+	return len(set), nil
+}
+
+// sweep رکوردهای منقضی و مجموعه‌های خالی را حداکثر هر دقیقه یک بار پاک می‌کند
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for k, r := range s.records {
+		if !now.Before(r.expiresAt) {
+			delete(s.records, k)
+		}
+	}
+	for k, set := range s.sets {
+		latest := time.Time{}
+		for _, t := range set {
+			if t.After(latest) {
+				latest = t
+			}
+		}
+		if now.Sub(latest) > 24*time.Hour {
+			delete(s.sets, k)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package model
 
+import "github.com/alisiahmansouri/exchange-common/identifier"
+
 // RefreshTokenRequest is used to request a new access token using a refresh token.
 // @Description Refresh your session with a valid refresh token.
 type RefreshTokenRequest struct {
@@ -7,24 +9,33 @@ type RefreshTokenRequest struct {
 }
 
 // RegisterRequest contains information for user registration.
-// @Description User registration with email/phone, name, password and captcha.
+// @Description User registration with email/phone, name, password and an optional captcha or proof-of-work.
 type RegisterRequest struct {
-	Identifier string `json:"identifier" example:"user@example.com or 09123456789" binding:"required"`      // Email or mobile number
-	FullName   string `json:"full_name" example:"علی منصوری" binding:"required"`                            // Full name of the user
-	Password   string `json:"password" example:"P@ssw0rd123" binding:"required"`                            // User password
-	CaptchaID  string `json:"captcha_id" binding:"required" example:"a1b2c3d4-e5f6-7g8h-9i10-j11k12l13m14"` // Captcha unique ID
-	CaptchaAns string `json:"captcha_ans" binding:"required" example:"aBc123"`                              // Captcha answer
+	Identifier identifier.Identifier `json:"identifier" swaggertype:"string" example:"user@example.com or 09123456789" binding:"required,registrable"` // Email or mobile number (blocked/disposable email domains are rejected)
+	FullName   string                `json:"full_name" example:"علی منصوری" binding:"required" normalize:"text"`                                       // Full name of the user
+	Password   string                `json:"password" example:"P@ssw0rd123" binding:"required"`                                                        // User password
+	CaptchaID  string                `json:"captcha_id" binding:"required_with=CaptchaAns" example:"a1b2c3d4-e5f6-7g8h-9i10-j11k12l13m14"`             // Captcha unique ID (required when the risk evaluator asks for a challenge)
+	CaptchaAns string                `json:"captcha_ans" binding:"required_with=CaptchaID" normalize:"digits,space" example:"aBc123"`                  // Captcha answer
+	PoW        *PoWSolution          `json:"pow,omitempty"`                                                                                            // Proof-of-work solution (instead of captcha)
 }
 
+// HasChallenge reports whether a captcha answer or a PoW solution was sent.
+func (r RegisterRequest) HasChallenge() bool { return r.CaptchaID != "" || r.PoW != nil }
+
 // LoginRequest is used for user authentication.
-// @Description Login with email/phone, password and captcha.
+// @Description Login with email/phone, password and an optional captcha or proof-of-work.
 type LoginRequest struct {
-	Identifier string `json:"identifier" binding:"required" example:"user@example.com or 09123456789"`      // Email or mobile
-	Password   string `json:"password" binding:"required" example:"P@ssw0rd123"`                            // Password
-	CaptchaID  string `json:"captcha_id" binding:"required" example:"9f1b77d3-cb13-4a72-8a64-28de5f82a5c2"` // Captcha ID
-	CaptchaAns string `json:"captcha_ans" binding:"required" example:"aBc123"`                              // Captcha answer
+	Identifier identifier.Identifier `json:"identifier" swaggertype:"string" binding:"required" example:"user@example.com or 09123456789"` // Email or mobile
+	Password   string                `json:"password" binding:"required" example:"P@ssw0rd123"`                                            // Password
+	CaptchaID  string                `json:"captcha_id" binding:"required_with=CaptchaAns" example:"9f1b77d3-cb13-4a72-8a64-28de5f82a5c2"` // Captcha ID (required when the risk evaluator asks for a challenge)
+	CaptchaAns string                `json:"captcha_ans" binding:"required_with=CaptchaID" normalize:"digits,space" example:"aBc123"`      // Captcha answer
+	PoW        *PoWSolution          `json:"pow,omitempty"`                                                                                // Proof-of-work solution (instead of captcha)
+	DeviceID   string                `json:"device_id,omitempty" binding:"omitempty,max=128" example:"c0ffee-1234"`                        // Client device identifier (risk signal)
 }
 
+// HasChallenge reports whether a captcha answer or a PoW solution was sent.
+func (r LoginRequest) HasChallenge() bool { return r.CaptchaID != "" || r.PoW != nil }
+
 // RegisterResponse represents the response after successful registration.
 // @Description User registration response containing user ID and a message.
 type RegisterResponse struct {
@@ -35,16 +46,16 @@ type RegisterResponse struct {
 // Verify2FARequest is used to verify a 2FA code for different purposes (register/login).
 // @Description Verify two-factor authentication code (2FA) for registration or login.
 type Verify2FARequest struct {
-	Code       string `json:"code" binding:"required" example:"123456"`                  // The 2FA code
-	Identifier string `json:"identifier"  binding:"required" example:"user@example.com"` // Email or mobile
-	Purpose    string `json:"purpose" binding:"required" example:"register_2fa"`         // Purpose: "register_2fa" or "login_2fa"
+	Code       string                `json:"code" binding:"required" normalize:"digits,space" example:"123456"`              // The 2FA code or a backup recovery code
+	Identifier identifier.Identifier `json:"identifier" swaggertype:"string"  binding:"required" example:"user@example.com"` // Email or mobile
+	Purpose    string                `json:"purpose" binding:"required" example:"register_2fa"`                              // Purpose: "register_2fa" or "login_2fa"
 }
 
 // ResendVerificationRequest is used to resend a verification code.
 // @Description Resend a verification code for email or phone verification.
 type ResendVerificationRequest struct {
-	Identifier string `json:"identifier" binding:"required" example:"user@example.com"` // Email or mobile
-	Purpose    string `json:"purpose" example:"register_2fa"`                           // Purpose (optional)
+	Identifier identifier.Identifier `json:"identifier" swaggertype:"string" binding:"required" example:"user@example.com"` // Email or mobile
+	Purpose    string                `json:"purpose" example:"register_2fa"`                                                // Purpose (optional)
 }
 
 // TokenResponse represents access and refresh tokens issued by the authentication system.
@@ -60,13 +71,56 @@ type LoginResponse = TokenResponse
 // ForgotPasswordRequest is used for initiating password reset.
 // @Description Request to send a password reset code to email or mobile.
 type ForgotPasswordRequest struct {
-	Identifier string `json:"identifier" binding:"required" example:"user@example.com"` // Email or mobile
+	Identifier identifier.Identifier `json:"identifier" swaggertype:"string" binding:"required" example:"user@example.com"` // Email or mobile
 }
 
 // ResetPasswordRequest is used to reset the user's password using a code.
 // @Description Reset password with identifier (email/phone), code, and new password.
 type ResetPasswordRequest struct {
-	Identifier  string `json:"identifier" binding:"required" example:"user@example.com"` // Email or mobile
-	Code        string `json:"code" binding:"required" example:"123456"`                 // The reset code sent to user
-	NewPassword string `json:"new_password" binding:"required" example:"N3wP@ssw0rd!"`   // New password
+	Identifier  identifier.Identifier `json:"identifier" swaggertype:"string" binding:"required" example:"user@example.com"` // Email or mobile
+	Code        string                `json:"code" binding:"required" normalize:"digits,space" example:"123456"`             // The reset code sent to user
+	NewPassword string                `json:"new_password" binding:"required" example:"N3wP@ssw0rd!"`                        // New password
+}
+
+// TOTPEnrollResponse is returned when a user starts authenticator-app enrollment.
+// @Description Secret and otpauth:// URI to be scanned by an authenticator app.
+type TOTPEnrollResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXP"`                                                           // Base32 secret for manual entry
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Exchange:user@example.com?secret=JBSWY3DPEHPK3PXP"` // otpauth:// URI
+	QRPayload       string `json:"qr_payload" example:"otpauth://totp/Exchange:user@example.com?secret=JBSWY3DPEHPK3PXP"`       // Content to encode in the QR code
+	Message         string `json:"message" example:"Scan the QR code and enter the generated code."`
+}
+
+// TOTPConfirmRequest confirms authenticator-app enrollment with the first generated code.
+// @Description Confirm TOTP enrollment with a code from the authenticator app.
+type TOTPConfirmRequest struct {
+	Code string `json:"code" binding:"required" normalize:"digits,space" example:"123456"` // 6-digit code from the authenticator app
+}
+
+// TOTPDisableRequest disables authenticator-app 2FA.
+// @Description Disable TOTP two-factor authentication (requires a current code and password).
+type TOTPDisableRequest struct {
+	Code     string `json:"code" binding:"required" normalize:"digits,space" example:"123456"` // Current TOTP code
+	Password string `json:"password" binding:"required" example:"P@ssw0rd123"`                 // Account password
+}
+
+// TOTPConfirmResponse is returned after authenticator-app enrollment is confirmed.
+// @Description TOTP enabled; one-time backup codes are shown only once.
+type TOTPConfirmResponse struct {
+	Message     string   `json:"message" example:"Authenticator app enabled."`
+	BackupCodes []string `json:"backup_codes" example:"7KQ2M-XW9PD,3HT8R-NB4CF"` // Plaintext one-time recovery codes
+}
+
+// BackupCodesResponse is returned when backup codes are (re)generated.
+// @Description New one-time backup codes; previous codes are invalidated.
+type BackupCodesResponse struct {
+	Codes     []string `json:"codes" example:"7KQ2M-XW9PD,3HT8R-NB4CF"`
+	Remaining int      `json:"remaining" example:"10"`
+	Message   string   `json:"message" example:"New backup codes generated."`
+}
+
+// BackupCodesStatusResponse reports how many unused backup codes remain.
+// @Description Remaining unused backup recovery codes.
+type BackupCodesStatusResponse struct {
+	Remaining int `json:"remaining" example:"7"`
 }
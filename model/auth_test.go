@@ -0,0 +1,88 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/gin-gonic/gin"
+)
+
+func TestHasChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		captchaID string
+		pow       *PoWSolution
+		want      bool
+	}{
+		{"none", "", nil, false},
+		{"captcha", "9f1b77d3-cb13-4a72-8a64-28de5f82a5c2", nil, true},
+		{"pow", "", &PoWSolution{}, true},
+		{"both", "9f1b77d3-cb13-4a72-8a64-28de5f82a5c2", &PoWSolution{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login := LoginRequest{CaptchaID: tt.captchaID, PoW: tt.pow}
+			register := RegisterRequest{CaptchaID: tt.captchaID, PoW: tt.pow}
+			if login.HasChallenge() != tt.want || register.HasChallenge() != tt.want {
+				t.Fatalf("HasChallenge = %v/%v, want %v", login.HasChallenge(), register.HasChallenge(), tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorResponseWithChallenge(t *testing.T) {
+	tests := []struct {
+		name     string
+		required bool
+	}{
+		{"challenge required", true},
+		{"no challenge", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+			ErrorResponseWithChallenge(c, 401, consts.ErrCaptchaRequired, consts.CodeCaptchaRequired, tt.required)
+			var res Response
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.CaptchaRequired != tt.required || res.ErrorCode != consts.CodeCaptchaRequired || res.Message != consts.ErrCaptchaRequired {
+				t.Fatalf("response = %+v", res)
+			}
+		})
+	}
+}
+
+// RegisterRequest باید بدون فراخوانی identifier.RegisterGinBinding هم bind شود (تگ registrable هنگام import ثبت می‌شود)
+func TestBindRegisterRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		identifier string
+		ok         bool
+	}{
+		{"phone", "09121234567", true},
+		{"email", "user@gmail.com", true},
+		{"disposable email", "a@mailinator.com", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"identifier": tt.identifier, "full_name": "علی", "password": "P@ssw0rd123"})
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			var req RegisterRequest
+			if err := c.ShouldBindJSON(&req); (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -3,22 +3,32 @@ package model
 import (
 	"errors"
 	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/email"
+	"github.com/alisiahmansouri/exchange-common/identifier"
+	"github.com/alisiahmansouri/exchange-common/phone"
 )
 
 // --- خطای عمومی ---
 var (
-	ErrInternal = errors.New("خطای داخلی سرور")
+	ErrInternal       = errors.New("خطای داخلی سرور")
+	ErrPepperRequired = errors.New("کلید سرور (pepper) برای هش کردن الزامی است")
 )
 
 // --- خطاهای ثبت‌نام کاربر ---
+// خطاهای قالب شناسه در پکیج‌های identifier، email و phone تعریف شده‌اند؛ نام‌های قدیمی model
+// برای سازگاری با errors.Is در سرویس‌ها به همان مقادیر اشاره می‌کنند
 var (
-	ErrInvalidIdentifier    = errors.New("شناسه ایمیل یا شماره موبایل نامعتبر است")
+	ErrInvalidIdentifier    = identifier.ErrInvalid
+	ErrInvalidEmailFormat   = email.ErrInvalidFormat
+	ErrInvalidPhone         = phone.ErrInvalidNumber
 	ErrEmailExists          = errors.New("ایمیل وارد شده قبلاً ثبت شده است")
 	ErrPhoneExists          = errors.New("شماره موبایل وارد شده قبلاً ثبت شده است")
 	ErrEmailOrPhoneRequired = errors.New("ایمیل یا شماره موبایل الزامی است")
-	ErrInvalidEmailFormat   = errors.New("فرمت ایمیل نامعتبر است")
-	ErrInvalidPhone         = errors.New("فرمت شماره موبایل نامعتبر است")
 	ErrPasswordHash         = errors.New("خطا در رمزنگاری رمز عبور")
+	ErrPasswordHashFormat   = errors.New("فرمت هش رمز عبور نامعتبر است")
+	ErrPasswordPolicy       = errors.New("رمز عبور با سیاست امنیتی مطابقت ندارد")
 	ErrUserCreate           = errors.New("خطا در ایجاد کاربر")
 )
 
@@ -35,6 +45,7 @@ var (
 	ErrVerificationChannelInvalid       = errors.New("کانال ارسال کد تایید نامعتبر است")
 	ErrVerificationIdentifierInvalid    = errors.New("ایمیل یا موبایل نامعتبر است")
 	ErrVerificationRateLimitExceeded    = errors.New("تعداد ارسال کد بیش از حد مجاز است")
+	ErrVerificationAlphabetInvalid      = errors.New("الفبای کد تایید باید فقط نویسه‌های ASCII داشته باشد")
 	ErrTooManyAttempts                  = errors.New("تعداد تلاش بیش از حد مجاز است")
 )
 
@@ -54,6 +65,28 @@ var (
 	Err2FACodeInvalid = errors.New("کد ورود دو مرحله‌ای نامعتبر است")
 	Err2FACodeUsed    = errors.New("کد ورود دو مرحله‌ای قبلاً استفاده شده است")
 	Err2FACodeExpired = errors.New("کد ورود دو مرحله‌ای منقضی شده است")
+
+	ErrTOTPSecretInvalid    = errors.New("کلید TOTP نامعتبر است")
+	ErrTOTPPeriodInvalid    = errors.New("طول گام TOTP باید مضربی از ثانیه و حداقل یک ثانیه باشد")
+	ErrTOTPDigitsInvalid    = errors.New("تعداد ارقام TOTP باید ۶ یا ۸ باشد")
+	ErrTOTPAlgorithmInvalid = errors.New("الگوریتم TOTP باید SHA1، SHA256 یا SHA512 باشد")
+	ErrTOTPNotEnrolled      = errors.New("برنامه احراز هویت فعال نشده است")
+	ErrTOTPAlreadyEnabled   = errors.New("برنامه احراز هویت قبلاً فعال شده است")
+)
+
+// --- خطاهای توکن (JWT) ---
+var (
+	ErrTokenMalformed     = errors.New("ساختار توکن نامعتبر است")
+	ErrTokenSignature     = errors.New("امضای توکن نامعتبر است")
+	ErrTokenExpired       = errors.New("توکن منقضی شده است")
+	ErrTokenNotYetValid   = errors.New("توکن هنوز معتبر نشده است")
+	ErrTokenKeyUnknown    = errors.New("کلید امضای توکن ناشناخته است")
+	ErrTokenTypeMismatch  = errors.New("نوع توکن با انتظار مطابقت ندارد")
+	ErrTokenClaimsInvalid = errors.New("ادعاهای توکن نامعتبر است")
+	ErrTokenKeyInvalid    = errors.New("کلید امضای توکن نامعتبر است")
+	ErrTokenRevoked       = errors.New("توکن لغو شده است")
+	ErrTokenReused        = errors.New("توکن refresh قبلاً چرخانده شده است")
+	ErrTokenNotFound      = errors.New("توکن یافت نشد")
 )
 
 // --- خطاهای کیف پول ---
@@ -107,6 +140,52 @@ var (
 	ErrOrderMaxOpenOrdersReached = errors.New("حداکثر تعداد سفارش باز مجاز برای کاربر پر شده است")
 )
 
+// --- خطاهای رویداد و Outbox ---
+var (
+	ErrEventPairIDInvalid      = errors.New("شناسه جفت ارز رویداد نامعتبر است")
+	ErrEventTypeUnknown        = errors.New("نوع رویداد ناشناخته است")
+	ErrEventVersionUnsupported = errors.New("نسخه رویداد جدیدتر از نسخه پشتیبانی‌شده است")
+	ErrEventUpcasterMissing    = errors.New("مبدل نسخه رویداد تعریف نشده است")
+	ErrEventAlreadyRegistered  = errors.New("نوع رویداد قبلاً ثبت شده است")
+	ErrEventBufferFull         = errors.New("بافر رویدادهای خارج از ترتیب پر شده است")
+	ErrEventMessageIDInvalid   = errors.New("شناسه پیام رویداد نامعتبر است")
+)
+
+// --- خطاهای پیام‌رسان (Message Bus) ---
+var (
+	ErrBusClosed        = errors.New("پیام‌رسان بسته شده است")
+	ErrBusTopicRequired = errors.New("نام تاپیک الزامی است")
+	ErrBusGroupRequired = errors.New("نام گروه مصرف‌کننده الزامی است")
+	ErrBusHandlerPanic  = errors.New("پردازشگر پیام دچار panic شد")
+)
+
+// --- خطاهای کپچا ---
+var (
+	ErrCaptchaEmpty     = errors.New("پاسخ کپچا خالی است")
+	ErrCaptchaNotFound  = errors.New(consts.ErrCaptchaNotFound)
+	ErrCaptchaWrong     = errors.New(consts.ErrCaptchaInvalid)
+	ErrCaptchaMalformed = errors.New("قالب پاسخ کپچا نامعتبر است")
+	ErrCaptchaIDInvalid = errors.New(consts.ErrCaptchaInvalidID)
+	ErrCaptchaRequired  = errors.New("کپچا یا اثبات کار برای این درخواست الزامی است")
+)
+
+// --- خطاهای محدودیت نرخ ---
+var (
+	ErrRateLimited    = errors.New("محدودیت نرخ درخواست")
+	ErrLoginThrottled = errors.New(consts.ErrLoginThrottled)
+)
+
+// --- خطاهای اثبات کار (Proof-of-Work) ---
+var (
+	ErrPoWMalformed    = errors.New("قالب چالش اثبات کار نامعتبر است")
+	ErrPoWSignature    = errors.New("امضای چالش اثبات کار نامعتبر است")
+	ErrPoWExpired      = errors.New("چالش اثبات کار منقضی شده است")
+	ErrPoWDifficulty   = errors.New("سختی چالش اثبات کار کمتر از حد لازم است")
+	ErrPoWInsufficient = errors.New("هش پاسخ به سختی لازم نمی‌رسد")
+	ErrPoWReplayed     = errors.New("چالش اثبات کار قبلاً استفاده شده است")
+	ErrPoWKeyRequired  = errors.New("کلید امضای چالش اثبات کار الزامی است")
+)
+
 func IsUniqueViolation(err error) bool {
 	if err == nil {
 		return false
@@ -131,3 +210,12 @@ func IsUniqueViolation(err error) bool {
 
 	return false
 }
+
+// --- خطاهای رجیستری کدهای خطا ---
+var (
+	ErrCodeEmpty             = errors.New("کد خطا خالی است")
+	ErrCodeKindInvalid       = errors.New("نوع خطای کد نامعتبر است")
+	ErrCodeAlreadyRegistered = errors.New("کد خطا قبلاً ثبت شده است")
+	ErrCodeUnregistered      = errors.New("کد خطا در رجیستری ثبت نشده است")
+	ErrCodeDuplicateValue    = errors.New("چند ثابت کد خطا مقدار یکسان دارند")
+)
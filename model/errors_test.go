@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/email"
+	"github.com/alisiahmansouri/exchange-common/identifier"
+	"github.com/alisiahmansouri/exchange-common/phone"
+)
+
+// خطاهایی که پیام کاربرشان در consts هم هست باید همان متن را داشته باشند
+func TestSentinelTextsMatchConsts(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ErrLoginThrottled, consts.ErrLoginThrottled},
+		{ErrCaptchaNotFound, consts.ErrCaptchaNotFound},
+		{ErrCaptchaWrong, consts.ErrCaptchaInvalid},
+		{ErrCaptchaIDInvalid, consts.ErrCaptchaInvalidID},
+	}
+	for _, tt := range tests {
+		if tt.err.Error() != tt.want {
+			t.Errorf("%q != %q", tt.err, tt.want)
+		}
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New(`ERROR: duplicate key value violates unique constraint "users_email_key" (SQLSTATE 23505)`), true},
+		{fmt.Errorf("create: %w", errors.New("Error 1062: Duplicate entry 'a' for key 'email'")), true},
+		{errors.New("UNIQUE constraint failed: users.email"), true},
+		{errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		if got := IsUniqueViolation(tt.err); got != tt.want {
+			t.Errorf("IsUniqueViolation(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// نام‌های قدیمی model باید خطای پکیج‌های دامنه را تشخیص دهند
+func TestLegacyIdentifierErrors(t *testing.T) {
+	_, emailErr := email.Parse("a@@b")
+	_, phoneErr := phone.Normalize("12")
+	_, idErr := identifier.Parse(" ")
+	tests := []struct {
+		err    error
+		target error
+	}{
+		{emailErr, ErrInvalidEmailFormat},
+		{phoneErr, ErrInvalidPhone},
+		{idErr, ErrInvalidIdentifier},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.target) {
+			t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.target)
+		}
+	}
+}
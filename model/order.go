@@ -1,9 +1,15 @@
 package model
 
 import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
 	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/textnorm"
 	"github.com/google/uuid"
-	"time"
 )
 
 // ------------------- OrderCreateRequest -------------------
@@ -13,13 +19,65 @@ type OrderCreateRequest struct {
 	PairID        uuid.UUID `json:"pair_id" binding:"required"`
 	Side          string    `json:"side" binding:"required,oneof=buy sell"`
 	OrderType     string    `json:"order_type" binding:"required,oneof=limit market"`
-	Amount        float64   `json:"amount" binding:"required,gt=0"`
-	Price         float64   `json:"price" binding:"required,gte=0"`
+	Amount        float64   `json:"amount" binding:"required,gt=0"` // عدد یا رشته؛ ارقام فارسی پذیرفته می‌شوند (UnmarshalJSON)
+	Price         float64   `json:"price" binding:"required,gte=0"` // مانند Amount
 	ClientOrderID *string   `json:"client_order_id,omitempty"`
 	TimeInForce   *string   `json:"time_in_force,omitempty"`
 	Meta          *string   `json:"meta,omitempty"`
 }
 
+// UnmarshalJSON مقدار و قیمت را علاوه بر عدد JSON به صورت رشته (مثلاً "۱۲٫۵" یا "1,200") هم می‌پذیرد
+func (r *OrderCreateRequest) UnmarshalJSON(data []byte) error {
+	type plain OrderCreateRequest
+	aux := struct {
+		*plain
+		Amount json.RawMessage `json:"amount"`
+		Price  json.RawMessage `json:"price"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if r.Amount, err = parseJSONNumber(aux.Amount); err != nil {
+		return err
+	}
+	if r.Price, err = parseJSONNumber(aux.Price); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseJSONNumber عدد JSON یا رشته‌ی عددی (با ارقام فارسی/عربی و جداکننده‌ی هزارگان) را می‌خواند؛
+// مقدار خالی، null یا "" صفر است تا اعتبارسنجی required آن را رد کند
+func parseJSONNumber(raw json.RawMessage) (float64, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return 0, nil
+	}
+	if raw[0] != '"' {
+		var f float64
+		err := json.Unmarshal(raw, &f)
+		return f, err
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, err
+	}
+	s = textnorm.Number(s)
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	// ParseFloat رشته‌هایی مثل "NaN" و "Inf" را هم می‌پذیرد
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, &strconv.NumError{Func: "ParseFloat", Num: s, Err: strconv.ErrSyntax}
+	}
+	return f, nil
+}
+
 // ------------------- OrderResponse (برای خروجی API) -------------------
 
 type OrderResponse struct {
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderCreateRequestUnmarshalAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantAmount float64
+		wantPrice  float64
+		wantErr    bool
+	}{
+		{"numbers", `{"amount":12.5,"price":100}`, 12.5, 100, false},
+		{"latin strings", `{"amount":"12.5","price":"1,000"}`, 12.5, 1000, false},
+		{"persian strings", `{"amount":"۱۲٫۵","price":"۱٬۰۰۰"}`, 12.5, 1000, false},
+		{"missing and null", `{"price":null}`, 0, 0, false},
+		{"empty string", `{"amount":""}`, 0, 0, false},
+		{"not a number", `{"amount":"abc"}`, 0, 0, true},
+		{"infinity", `{"amount":"Inf"}`, 0, 0, true},
+		{"bool", `{"amount":true}`, 0, 0, true},
+		{"decimal comma", `{"amount":"1,5"}`, 0, 0, true},
+		{"persian bad grouping", `{"amount":"۱٬۵"}`, 0, 0, true},
+		{"grouped with decimals", `{"amount":"1,234.5"}`, 1234.5, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r OrderCreateRequest
+			err := json.Unmarshal([]byte(tt.body), &r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (r.Amount != tt.wantAmount || r.Price != tt.wantPrice) {
+				t.Fatalf("got (%v, %v), want (%v, %v)", r.Amount, r.Price, tt.wantAmount, tt.wantPrice)
+			}
+		})
+	}
+}
+
+func TestOrderCreateRequestUnmarshalOtherFields(t *testing.T) {
+	var r OrderCreateRequest
+	body := `{"side":"buy","order_type":"limit","amount":"۲","client_order_id":"c1"}`
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Side != "buy" || r.OrderType != "limit" || r.Amount != 2 || r.ClientOrderID == nil || *r.ClientOrderID != "c1" {
+		t.Fatalf("got %+v", r)
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+// PasswordViolation describes one failed password policy rule.
+// @Description A single password policy rule that the password does not satisfy.
+type PasswordViolation struct {
+	Rule    string `json:"rule" example:"min_length"`                          // Rule identifier
+	Code    string `json:"code" example:"PASSWORD_TOO_SHORT"`                  // Machine-readable error code
+	Message string `json:"message" example:"رمز عبور کوتاه‌تر از حد مجاز است"` // User-facing message
+	Limit   int    `json:"limit,omitempty" example:"8"`                        // Rule threshold (length, classes, ...)
+}
+
+// PasswordStrengthResponse reports the strength of a candidate password.
+// @Description Password strength estimate and policy violations.
+type PasswordStrengthResponse struct {
+	Score      int                 `json:"score" example:"3"`      // 0 (very weak) .. 4 (very strong)
+	Entropy    float64             `json:"entropy" example:"62.5"` // Estimated entropy in bits
+	Violations []PasswordViolation `json:"violations,omitempty"`   // Failed rules, empty if acceptable
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// PoWChallengeResponse is a signed hashcash challenge.
+// @Description Proof-of-work challenge: find a nonce so that sha256(challenge + ":" + nonce) starts with `difficulty` zero bits.
+type PoWChallengeResponse struct {
+	Challenge  string    `json:"challenge" example:"1.18.1767225600.9f2c4a0b1d3e5f60.kQ2x..."` // Opaque signed challenge
+	Algorithm  string    `json:"algorithm" example:"sha256"`                                   // Hash algorithm
+	Difficulty int       `json:"difficulty" example:"18"`                                      // Required leading zero bits
+	ExpiresAt  time.Time `json:"expires_at" example:"2026-01-01T00:02:00Z"`                    // Challenge expiry
+}
+
+// PoWSolution carries a solved proof-of-work challenge.
+// @Description Solved proof-of-work challenge, an alternative to the image captcha.
+type PoWSolution struct {
+	Challenge string `json:"challenge" binding:"required" example:"1.18.1767225600.9f2c4a0b1d3e5f60.kQ2x..."` // Challenge as issued
+	Nonce     string `json:"nonce" binding:"required,max=64" example:"184467"`                                // Found nonce
+}
@@ -1,6 +1,9 @@
 package model
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/alisiahmansouri/exchange-common/i18n"
+	"github.com/gin-gonic/gin"
+)
 
 type Response struct {
 	Code      int         `json:"code"`                 // HTTP status code
@@ -8,6 +11,9 @@ type Response struct {
 	Data      interface{} `json:"data,omitempty"`       // داده (در صورت موفقیت)
 	Message   string      `json:"message,omitempty"`    // پیام (موفق یا خطا)
 	ErrorCode string      `json:"error_code,omitempty"` // کد یکتا برای خطا (اختیاری)
+	Details   interface{} `json:"details,omitempty"`    // جزئیات ساخت‌یافته‌ی خطا (مثلاً قوانین نقض‌شده)
+
+	CaptchaRequired bool `json:"captcha_required,omitempty"` // درخواست بعدی باید کپچا یا اثبات کار داشته باشد
 }
 
 type ErrorResponseStruct struct {
@@ -38,7 +44,7 @@ func ErrorResponse(c *gin.Context, code int, msg, errorCode string) {
 	res := Response{
 		Code:    code,
 		Success: false,
-		Message: msg,
+		Message: localize(c, msg, errorCode, nil),
 	}
 	if errorCode != "" {
 		// می‌تونی این فیلد رو به ساختار Response اضافه کنی اگر لازم بود
@@ -47,6 +53,68 @@ func ErrorResponse(c *gin.Context, code int, msg, errorCode string) {
 	c.JSON(code, res)
 }
 
+// ErrorResponseWithDetails مانند ErrorResponse به همراه جزئیات ساخت‌یافته برای کلاینت
+func ErrorResponseWithDetails(c *gin.Context, code int, msg, errorCode string, details interface{}) {
+	c.JSON(code, Response{
+		Code:      code,
+		Success:   false,
+		Message:   localize(c, msg, errorCode, nil),
+		ErrorCode: errorCode,
+		Details:   details,
+	})
+}
+
+// ErrorResponseWithChallenge مانند ErrorResponse؛ با captchaRequired کلاینت می‌فهمد تلاش بعدی نیاز به کپچا دارد
+func ErrorResponseWithChallenge(c *gin.Context, code int, msg, errorCode string, captchaRequired bool) {
+	c.JSON(code, Response{
+		Code:            code,
+		Success:         false,
+		Message:         localize(c, msg, errorCode, nil),
+		ErrorCode:       errorCode,
+		CaptchaRequired: captchaRequired,
+	})
+}
+
+// ErrorResponseWithParams مانند ErrorResponse؛ params در پیام کاتالوگ زبان‌های دیگر جایگزین می‌شود
+// تا جزئیاتی مانند زمان انتظار که در msg فارسی آمده از پیام ترجمه‌شده حذف نشود
+func ErrorResponseWithParams(c *gin.Context, code int, msg, errorCode string, params i18n.Params) {
+	c.JSON(code, Response{
+		Code:      code,
+		Success:   false,
+		Message:   localize(c, msg, errorCode, params),
+		ErrorCode: errorCode,
+	})
+}
+
+// LocalizedErrorResponse پیام خطا را از کاتالوگ i18n و به زبان درخواست (با جایگزینی params) می‌سازد
+func LocalizedErrorResponse(c *gin.Context, code int, errorCode string, params i18n.Params) {
+	c.JSON(code, Response{
+		Code:      code,
+		Success:   false,
+		Message:   i18n.Message(i18n.FromGin(c), errorCode, params),
+		ErrorCode: errorCode,
+	})
+}
+
+// localize برای زبان غیر پیش‌فرض پیام کاتالوگ کد را جایگزین msg می‌کند؛ در فارسی msg فراخواننده
+// (که ممکن است جزئیات بیشتری داشته باشد) حفظ می‌شود و فقط اگر خالی باشد از کاتالوگ پر می‌شود
+func localize(c *gin.Context, msg, errorCode string, params i18n.Params) string {
+	if errorCode == "" {
+		return msg
+	}
+	lang := i18n.FromGin(c)
+	if lang == i18n.DefaultLang {
+		if msg == "" {
+			return i18n.Message(lang, errorCode, params)
+		}
+		return msg
+	}
+	if _, ok := i18n.Default.Lookup(lang, errorCode); ok {
+		return i18n.Message(lang, errorCode, params)
+	}
+	return msg
+}
+
 type SimpleMessageResponse struct {
 	Success bool   `json:"success" example:"true"`
 	Message string `json:"message" example:"کپچا معتبر است"`
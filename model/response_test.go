@@ -0,0 +1,85 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+func respond(t *testing.T, lang string, write func(c *gin.Context)) Response {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if lang != "" {
+		c.Request.Header.Set("Accept-Language", lang)
+	}
+	write(c)
+	var res Response
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestErrorResponseLocalization(t *testing.T) {
+	const faMsg = "ورود محدود است؛ ۵ دقیقه دیگر"
+	wait := i18n.Params{"wait": 5 * time.Minute}
+	tests := []struct {
+		name  string
+		lang  string
+		write func(c *gin.Context)
+		want  string
+	}{
+		{
+			"persian keeps caller message", "fa",
+			func(c *gin.Context) {
+				ErrorResponseWithParams(c, 429, faMsg, consts.CodeLoginThrottled, wait)
+			},
+			faMsg,
+		},
+		{
+			"english interpolates params", "en",
+			func(c *gin.Context) {
+				ErrorResponseWithParams(c, 429, faMsg, consts.CodeLoginThrottled, wait)
+			},
+			i18n.Message(i18n.EN, consts.CodeLoginThrottled, wait),
+		},
+		{
+			"english without params drops optional part", "en",
+			func(c *gin.Context) { ErrorResponse(c, 429, faMsg, consts.CodeLoginThrottled) },
+			i18n.Message(i18n.EN, consts.CodeLoginThrottled, nil),
+		},
+		{
+			"persian empty message from catalog", "",
+			func(c *gin.Context) { ErrorResponseWithParams(c, 429, "", consts.CodeLoginThrottled, wait) },
+			i18n.Message(i18n.FA, consts.CodeLoginThrottled, wait),
+		},
+		{
+			"unknown code keeps message", "en",
+			func(c *gin.Context) { ErrorResponse(c, 400, "raw", "NOT_A_CODE") },
+			"raw",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := respond(t, tt.lang, tt.write)
+			if res.Message != tt.want {
+				t.Fatalf("message = %q, want %q", res.Message, tt.want)
+			}
+			if res.Success {
+				t.Fatal("success = true")
+			}
+		})
+	}
+	if got := i18n.Message(i18n.EN, consts.CodeLoginThrottled, wait); got == i18n.Message(i18n.EN, consts.CodeLoginThrottled, nil) {
+		t.Fatalf("english message ignores wait: %q", got)
+	}
+}
@@ -0,0 +1,9 @@
+package model
+
+// ChallengeStatusResponse tells the client whether the next login/register attempt needs a challenge.
+// @Description Whether a captcha (or proof-of-work) must accompany the next attempt.
+type ChallengeStatusResponse struct {
+	CaptchaRequired bool     `json:"captcha_required" example:"true"`                 // A captcha or PoW solution is required
+	PoWDifficulty   int      `json:"pow_difficulty,omitempty" example:"20"`           // Suggested PoW difficulty when using proof-of-work
+	Reasons         []string `json:"reasons,omitempty" example:"identifier_failures"` // Triggered risk signals
+}
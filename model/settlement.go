@@ -6,13 +6,13 @@ import (
 )
 
 const (
-	// نسخه‌ی فعلی پیام رویداد؛ برای سازگاری رو به جلو/عقب
+	// نسخه‌ی فعلی پیام رویداد؛ برای سازگاری رو به جلو/عقب (نسخه‌های قدیمی‌تر با upcaster در event.Registry مهاجرت می‌کنند)
 	SettleTradeEventVersion = 1
 )
 
 // SettleTradeEvent is the event sent from matching engine to settlement service for trade settlement.
 type SettleTradeEvent struct {
-	EventID       uuid.UUID `json:"event_id"`                 // For idempotency & audit (UUIDv5 recommended)
+	EventID       uuid.UUID `json:"event_id"`                 // For idempotency & audit (UUIDv5, see event.StampSettleTrade)
 	Version       int       `json:"version"`                  // For backward/forward compatibility (use SettleTradeEventVersion)
 	PairID        uuid.UUID `json:"pair_id"`                  // Trading pair ID (for sharding & ordering)
 	Sequence      uint64    `json:"sequence"`                 // Monotonic per pair (ordering)
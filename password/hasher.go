@@ -0,0 +1,166 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const argon2idID = "argon2id"
+
+// محدوده‌ی مجاز پارامترهای هش ذخیره‌شده؛ هش دستکاری‌شده نباید باعث panic یا مصرف بی‌رویه‌ی حافظه شود
+const (
+	maxMemory     = 1 << 20 // کیلوبایت (۱ گیگابایت)
+	maxIterations = 64
+	minSaltLength = 8
+	maxSaltLength = 64
+	minKeyLength  = 16
+	maxKeyLength  = 128
+)
+
+// Params پارامترهای argon2id
+type Params struct {
+	Memory      uint32 // کیلوبایت
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultParams پیشنهاد OWASP برای argon2id با حافظه‌ی ۶۴ مگابایت
+var DefaultParams = Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Hasher هش و بررسی رمز عبور؛ هش‌های جدید argon2id در قالب PHC ذخیره می‌شوند و هش‌های قدیمی bcrypt فقط بررسی می‌شوند
+type Hasher struct {
+	params Params
+}
+
+func NewHasher(p Params) *Hasher {
+	if p.Memory == 0 {
+		p.Memory = DefaultParams.Memory
+	}
+	if p.Iterations == 0 {
+		p.Iterations = DefaultParams.Iterations
+	}
+	if p.Parallelism == 0 {
+		p.Parallelism = DefaultParams.Parallelism
+	}
+	if p.SaltLength == 0 {
+		p.SaltLength = DefaultParams.SaltLength
+	}
+	if p.KeyLength == 0 {
+		p.KeyLength = DefaultParams.KeyLength
+	}
+	return &Hasher{params: p}
+}
+
+// Hash رشته‌ی $argon2id$v=19$m=...,t=...,p=...$salt$hash تولید می‌کند
+func (h *Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", model.ErrPasswordHash
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idID, argon2.Version,
+		h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		b64.EncodeToString(salt), b64.EncodeToString(key),
+	), nil
+}
+
+// Verify رمز عبور را با هش ذخیره‌شده (argon2id یا bcrypt) مقایسه می‌کند
+func (h *Hasher) Verify(password, encoded string) (bool, error) {
+	if isBcrypt(encoded) {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		if err != nil {
+			return false, model.ErrPasswordHashFormat
+		}
+		return true, nil
+	}
+
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash هش با الگوریتم یا پارامترهای فعلی ساخته نشده است؛
+// پس از ورود موفق، رمز باید دوباره هش و ذخیره شود.
+func (h *Hasher) NeedsRehash(encoded string) bool {
+	if isBcrypt(encoded) {
+		return true
+	}
+	p, _, _, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return p.Memory != h.params.Memory ||
+		p.Iterations != h.params.Iterations ||
+		p.Parallelism != h.params.Parallelism ||
+		p.SaltLength != h.params.SaltLength ||
+		p.KeyLength != h.params.KeyLength
+}
+
+var b64 = base64.RawStdEncoding
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+func decodeArgon2id(encoded string) (Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != argon2idID {
+		return Params{}, nil, nil, model.ErrPasswordHashFormat
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, model.ErrPasswordHashFormat
+	}
+	var p Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return Params{}, nil, nil, model.ErrPasswordHashFormat
+	}
+	salt, err := b64.Strict().DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, model.ErrPasswordHashFormat
+	}
+	key, err := b64.Strict().DecodeString(parts[5])
+	if err != nil {
+		return Params{}, nil, nil, model.ErrPasswordHashFormat
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+	if !validParams(p) {
+		return Params{}, nil, nil, model.ErrPasswordHashFormat
+	}
+	return p, salt, key, nil
+}
+
+// validParams پارامترها در محدوده‌ای هستند که argon2.IDKey بدون panic و با حافظه‌ی معقول اجرا شود
+func validParams(p Params) bool {
+	return p.Iterations >= 1 && p.Iterations <= maxIterations &&
+		p.Parallelism >= 1 &&
+		p.Memory >= 8*uint32(p.Parallelism) && p.Memory <= maxMemory &&
+		p.SaltLength >= minSaltLength && p.SaltLength <= maxSaltLength &&
+		p.KeyLength >= minKeyLength && p.KeyLength <= maxKeyLength
+}
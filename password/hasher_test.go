@@ -0,0 +1,113 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// پارامترهای سبک تا تست‌ها سریع اجرا شوند
+var testParams = Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHasherHashVerify(t *testing.T) {
+	h := NewHasher(testParams)
+	encoded, err := h.Hash("S3cure-pass")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Fatalf("unexpected encoding %q", encoded)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{"correct", "S3cure-pass", true},
+		{"wrong", "S3cure-pasS", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := h.Verify(tt.password, encoded)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if ok != tt.want {
+				t.Fatalf("Verify = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasherVerifyBcrypt(t *testing.T) {
+	legacy, err := bcrypt.GenerateFromPassword([]byte("old-pass"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHasher(testParams)
+	if ok, err := h.Verify("old-pass", string(legacy)); err != nil || !ok {
+		t.Fatalf("Verify bcrypt = %v, %v", ok, err)
+	}
+	if ok, _ := h.Verify("other", string(legacy)); ok {
+		t.Fatal("wrong password accepted for bcrypt hash")
+	}
+	if !h.NeedsRehash(string(legacy)) {
+		t.Fatal("bcrypt hash should need rehash")
+	}
+}
+
+func TestHasherNeedsRehash(t *testing.T) {
+	h := NewHasher(testParams)
+	encoded, _ := h.Hash("pass")
+	if h.NeedsRehash(encoded) {
+		t.Fatal("fresh hash should not need rehash")
+	}
+	stronger := NewHasher(Params{Memory: 2048, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	if !stronger.NeedsRehash(encoded) {
+		t.Fatal("hash with weaker params should need rehash")
+	}
+}
+
+func TestHasherVerifyMalformed(t *testing.T) {
+	const salt = "c29tZXNhbHRzb21lc2FsdA"                     // 16 بایت
+	const key = "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U" // 32 بایت
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"zero iterations", "$argon2id$v=19$m=65536,t=0,p=2$" + salt + "$" + key},
+		{"zero parallelism", "$argon2id$v=19$m=65536,t=1,p=0$" + salt + "$" + key},
+		{"huge memory", "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + key},
+		{"memory below 8*p", "$argon2id$v=19$m=8,t=1,p=4$" + salt + "$" + key},
+		{"too many iterations", "$argon2id$v=19$m=1024,t=100000,p=1$" + salt + "$" + key},
+		{"short salt", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$" + key},
+		{"short key", "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$a2V5"},
+		{"empty key", "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$"},
+		{"wrong version", "$argon2id$v=16$m=1024,t=1,p=1$" + salt + "$" + key},
+		{"wrong algorithm", "$argon2i$v=19$m=1024,t=1,p=1$" + salt + "$" + key},
+		{"missing parts", "$argon2id$v=19$m=1024,t=1,p=1"},
+		{"bad base64", "$argon2id$v=19$m=1024,t=1,p=1$!!!$" + key},
+		{"garbage", "not-a-hash"},
+	}
+	h := NewHasher(testParams)
+	// همان salt و key با پارامترهای معتبر باید بدون خطا (و با نتیجه‌ی false) بررسی شود
+	if _, err := h.Verify("pass", "$argon2id$v=19$m=1024,t=1,p=1$"+salt+"$"+key); err != nil {
+		t.Fatalf("valid params rejected: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := h.Verify("pass", tt.encoded)
+			if ok || !errors.Is(err, model.ErrPasswordHashFormat) {
+				t.Fatalf("Verify = %v, %v; want false, ErrPasswordHashFormat", ok, err)
+			}
+			if !h.NeedsRehash(tt.encoded) {
+				t.Fatal("malformed hash should need rehash")
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+// شناسه‌ی قوانین سیاست رمز عبور
+const (
+	RuleMinLength  = "min_length"
+	RuleMaxLength  = "max_length"
+	RuleCharClass  = "char_classes"
+	RuleCommon     = "common"
+	RuleIdentifier = "contains_identifier"
+	RuleStrength   = "strength"
+	RuleHistory    = "history"
+)
+
+// Rules تنظیمات سیاست رمز عبور
+type Rules struct {
+	MinLength         int
+	MaxLength         int
+	MinClasses        int     // حداقل انواع کاراکتر (بزرگ، کوچک، عدد، ویژه)
+	MinEntropyBits    float64 // ۰ یعنی بدون بررسی قدرت
+	RejectCommon      bool
+	RejectIdentifiers bool
+	HistorySize       int // تعداد هش‌های اخیر که نباید تکرار شوند
+}
+
+var DefaultRules = Rules{
+	MinLength:         8,
+	MaxLength:         64,
+	MinClasses:        2,
+	MinEntropyBits:    36,
+	RejectCommon:      true,
+	RejectIdentifiers: true,
+	HistorySize:       5,
+}
+
+// Input رمز عبور پیشنهادی و اطلاعات کاربر برای بررسی
+type Input struct {
+	Password       string
+	Email          string
+	Phone          string
+	PreviousHashes []string // جدیدترین اول
+}
+
+// Policy بررسی رمز عبور بر اساس Rules
+type Policy struct {
+	rules  Rules
+	hasher *Hasher
+}
+
+// NewPolicy؛ اگر hasher نال باشد بررسی تاریخچه انجام نمی‌شود
+func NewPolicy(rules Rules, hasher *Hasher) *Policy {
+	return &Policy{rules: rules, hasher: hasher}
+}
+
+// PolicyError خطای نقض سیاست به همراه فهرست قوانین نقض‌شده
+type PolicyError struct {
+	Violations []model.PasswordViolation
+}
+
+func (e *PolicyError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Message
+	}
+	return strings.Join(msgs, "؛ ")
+}
+
+func (e *PolicyError) Unwrap() error { return model.ErrPasswordPolicy }
+
+// Validate در صورت نقض هر قانون *PolicyError برمی‌گرداند
+func (p *Policy) Validate(in Input) error {
+	if v := p.Check(in); len(v) > 0 {
+		return &PolicyError{Violations: v}
+	}
+	return nil
+}
+
+// Check همه‌ی قوانین را بررسی و فهرست نقض‌ها را برمی‌گرداند
+func (p *Policy) Check(in Input) []model.PasswordViolation {
+	var out []model.PasswordViolation
+	pw := in.Password
+	n := len([]rune(pw))
+	r := p.rules
+
+	if r.MinLength > 0 && n < r.MinLength {
+		out = append(out, violation(RuleMinLength, consts.CodePasswordTooShort, consts.ErrPasswordTooShort, r.MinLength))
+	}
+	if r.MaxLength > 0 && n > r.MaxLength {
+		out = append(out, violation(RuleMaxLength, consts.CodePasswordTooLong, consts.ErrPasswordTooLong, r.MaxLength))
+	}
+	if r.MinClasses > 0 && charClasses(pw) < r.MinClasses {
+		out = append(out, violation(RuleCharClass, consts.CodePasswordCharClasses, consts.ErrPasswordCharClasses, r.MinClasses))
+	}
+	common := r.RejectCommon && IsCommon(pw)
+	if common {
+		out = append(out, violation(RuleCommon, consts.CodePasswordCommon, consts.ErrPasswordCommon, 0))
+	}
+	if r.RejectIdentifiers && containsIdentifier(pw, in.Email, in.Phone) {
+		out = append(out, violation(RuleIdentifier, consts.CodePasswordHasIdentifier, consts.ErrPasswordHasIdentifier, 0))
+	}
+	if r.MinEntropyBits > 0 && !common && Entropy(pw) < r.MinEntropyBits {
+		out = append(out, violation(RuleStrength, consts.CodePasswordWeak, consts.ErrPasswordWeak, int(r.MinEntropyBits)))
+	}
+	if r.HistorySize > 0 && p.hasher != nil {
+		for i, h := range in.PreviousHashes {
+			if i >= r.HistorySize {
+				break
+			}
+			if ok, err := p.hasher.Verify(pw, h); err == nil && ok {
+				out = append(out, violation(RuleHistory, consts.CodePasswordReused, consts.ErrPasswordReused, r.HistorySize))
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Strength امتیاز ۰ تا ۴ و آنتروپی تخمینی رمز عبور
+func Strength(pw string) (int, float64) {
+	if IsCommon(pw) {
+		return 0, 0
+	}
+	bits := Entropy(pw)
+	switch {
+	case bits < 28:
+		return 0, bits
+	case bits < 36:
+		return 1, bits
+	case bits < 60:
+		return 2, bits
+	case bits < 80:
+		return 3, bits
+	default:
+		return 4, bits
+	}
+}
+
+// Entropy تخمین آنتروپی (بیت) بر اساس اندازه‌ی مجموعه‌ی کاراکترها؛
+// کاراکترهای تکراری و دنباله‌ای (abc، 123) وزن کمتری می‌گیرند.
+func Entropy(pw string) float64 {
+	runes := []rune(pw)
+	if len(runes) == 0 {
+		return 0
+	}
+	var lower, upper, digit, symbol, other bool
+	for _, c := range runes {
+		switch {
+		case c < unicode.MaxASCII && unicode.IsLower(c):
+			lower = true
+		case c < unicode.MaxASCII && unicode.IsUpper(c):
+			upper = true
+		case c < unicode.MaxASCII && unicode.IsDigit(c):
+			digit = true
+		case c < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	if lower {
+		pool += 26
+	}
+	if upper {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if symbol {
+		pool += 33
+	}
+	if other {
+		pool += 100
+	}
+
+	effective := 1.0
+	for i := 1; i < len(runes); i++ {
+		d := runes[i] - runes[i-1]
+		switch {
+		case d == 0:
+			effective += 0.25
+		case d == 1 || d == -1:
+			effective += 0.5
+		default:
+			effective++
+		}
+	}
+	return effective * math.Log2(float64(pool))
+}
+
+//go:embed common.txt.gz
+var commonGz []byte
+
+// commonSet هنگام init بارگذاری می‌شود؛ فایل embed خراب یا خالی باید همان ابتدا panic دهد،
+// نه اینکه بررسی رمزهای رایج بی‌صدا غیرفعال شود
+var commonSet = mustParseCommon(commonGz)
+
+// IsCommon رمز عبور (یا شکل leet آن) در فهرست رمزهای رایج/نشت‌یافته هست یا نه
+func IsCommon(pw string) bool {
+	p := strings.ToLower(strings.TrimSpace(pw))
+	if _, ok := commonSet[p]; ok {
+		return true
+	}
+	_, ok := commonSet[unleet.Replace(p)]
+	return ok
+}
+
+var unleet = strings.NewReplacer("@", "a", "4", "a", "3", "e", "0", "o", "1", "i", "$", "s", "5", "s", "7", "t")
+
+func mustParseCommon(gz []byte) map[string]struct{} {
+	set, err := parseCommon(gz)
+	if err != nil {
+		panic(fmt.Errorf("password: load common password list: %w", err))
+	}
+	return set
+}
+
+// parseCommon فهرست gzip شده (هر خط یک رمز) را می‌خواند
+func parseCommon(gz []byte) (map[string]struct{}, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	set := make(map[string]struct{})
+	sc := bufio.NewScanner(zr)
+	for sc.Scan() {
+		if w := strings.TrimSpace(sc.Text()); w != "" {
+			set[w] = struct{}{}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(set) == 0 {
+		return nil, errors.New("empty list")
+	}
+	return set, nil
+}
+
+func charClasses(pw string) int {
+	var upper, lower, number, special bool
+	for _, c := range pw {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsNumber(c):
+			number = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c):
+			special = true
+		}
+	}
+	n := 0
+	for _, b := range []bool{upper, lower, number, special} {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+func containsIdentifier(pw, email, phone string) bool {
+	p := strings.ToLower(pw)
+	if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+		if strings.Contains(p, email) {
+			return true
+		}
+		if local, _, ok := strings.Cut(email, "@"); ok && len(local) >= 3 && strings.Contains(p, local) {
+			return true
+		}
+	}
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	// شماره‌ی ملی ۱۰ رقمی (بدون ۰ یا ۹۸) و ۷ رقم آخر
+	if len(digits) >= 10 {
+		if strings.Contains(p, digits[len(digits)-10:]) || strings.Contains(p, digits[len(digits)-7:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func violation(rule, code, msg string, limit int) model.PasswordViolation {
+	return model.PasswordViolation{Rule: rule, Code: code, Message: msg, Limit: limit}
+}
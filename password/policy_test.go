@@ -0,0 +1,118 @@
+package password
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+func gz(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseCommon(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		size    int
+		wantErr bool
+	}{
+		{"embedded list", commonGz, len(commonSet), false},
+		{"lines", gz(t, "a\n b \n\nc\n"), 3, false},
+		{"not gzip", []byte("plain"), 0, true},
+		{"truncated", commonGz[:len(commonGz)/2], 0, true},
+		{"empty", gz(t, "\n\n"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := parseCommon(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(set) != tt.size {
+				t.Fatalf("size = %d, want %d", len(set), tt.size)
+			}
+		})
+	}
+	if len(commonSet) < 1000 {
+		t.Fatalf("embedded list has %d entries", len(commonSet))
+	}
+}
+
+func TestMustParseCommonPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("mustParseCommon did not panic on corrupt data")
+		}
+	}()
+	mustParseCommon([]byte("corrupt"))
+}
+
+func TestIsCommon(t *testing.T) {
+	tests := []struct {
+		pw   string
+		want bool
+	}{
+		{"password", true},
+		{" PASSWORD ", true},
+		{"p@ssw0rd", true},
+		{"Zq8#vLm2!rT9", false},
+	}
+	for _, tt := range tests {
+		if got := IsCommon(tt.pw); got != tt.want {
+			t.Errorf("IsCommon(%q) = %v, want %v", tt.pw, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	h := NewHasher(testParams)
+	oldHash, err := h.Hash("Old-Secret-42!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPolicy(DefaultRules, h)
+	tests := []struct {
+		name  string
+		in    Input
+		rules []string
+	}{
+		{"strong", Input{Password: "Zq8#vLm2!rT9"}, nil},
+		{"short", Input{Password: "Zq8#v"}, []string{RuleMinLength, RuleStrength}},
+		{"common", Input{Password: "password1"}, []string{RuleCommon}},
+		{"single class", Input{Password: "zqxvlmrtwkp"}, []string{RuleCharClass}},
+		{"contains email", Input{Password: "Alice.Gh!2024xq", Email: "alice.gh@gmail.com"}, []string{RuleIdentifier}},
+		{"reused", Input{Password: "Old-Secret-42!", PreviousHashes: []string{oldHash}}, []string{RuleHistory}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range p.Check(tt.in) {
+				got = append(got, v.Rule)
+			}
+			if len(got) != len(tt.rules) {
+				t.Fatalf("violations = %v, want %v", got, tt.rules)
+			}
+			for i := range got {
+				if got[i] != tt.rules[i] {
+					t.Fatalf("violations = %v, want %v", got, tt.rules)
+				}
+			}
+		})
+	}
+	if err := p.Validate(Input{Password: "password"}); !errors.Is(err, model.ErrPasswordPolicy) {
+		t.Fatalf("Validate err = %v, want ErrPasswordPolicy", err)
+	}
+}
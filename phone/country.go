@@ -0,0 +1,62 @@
+package phone
+
+// Country قواعد شماره‌گذاری یک کشور
+type Country struct {
+	ISO            string   // کد دوحرفی ISO 3166
+	CallingCode    string   // پیش‌شماره‌ی بین‌المللی بدون +
+	TrunkPrefix    string   // پیشوند داخلی (مثلاً 0 در ایران)
+	NationalLen    []int    // طول‌های مجاز شماره‌ی ملی (بدون پیشوند داخلی)
+	MobilePrefixes []string // پیشوندهای شماره‌ی ملی موبایل
+}
+
+// countries کشورهای پشتیبانی‌شده؛ Parse کشورهای دیگر را فقط با قواعد عمومی E.164 می‌پذیرد و ParseMobile آن‌ها را رد می‌کند
+var countries = map[string]Country{
+	"IR": {ISO: "IR", CallingCode: "98", TrunkPrefix: "0", NationalLen: []int{10}, MobilePrefixes: iranMobilePrefixes()},
+	"AF": {ISO: "AF", CallingCode: "93", TrunkPrefix: "0", NationalLen: []int{9}, MobilePrefixes: []string{"7"}},
+	"IQ": {ISO: "IQ", CallingCode: "964", TrunkPrefix: "0", NationalLen: []int{10}, MobilePrefixes: []string{"7"}},
+	"TR": {ISO: "TR", CallingCode: "90", TrunkPrefix: "0", NationalLen: []int{10}, MobilePrefixes: []string{"5"}},
+	"AE": {ISO: "AE", CallingCode: "971", TrunkPrefix: "0", NationalLen: []int{9}, MobilePrefixes: []string{"50", "52", "54", "55", "56", "58"}},
+	"GB": {ISO: "GB", CallingCode: "44", TrunkPrefix: "0", NationalLen: []int{10}, MobilePrefixes: []string{"7"}},
+	"DE": {ISO: "DE", CallingCode: "49", TrunkPrefix: "0", NationalLen: []int{10, 11}, MobilePrefixes: []string{"15", "16", "17"}},
+	"US": {ISO: "US", CallingCode: "1", NationalLen: []int{10}},
+	"CA": {ISO: "CA", CallingCode: "1", NationalLen: []int{10}},
+}
+
+// byCallingCode پیش‌شماره به کشور پیش‌فرض آن (برای +1 آمریکا)
+var byCallingCode = func() map[string]string {
+	m := make(map[string]string, len(countries))
+	for iso, c := range countries {
+		if _, ok := m[c.CallingCode]; !ok || iso == "US" {
+			m[c.CallingCode] = iso
+		}
+	}
+	return m
+}()
+
+// LookupCountry قواعد کشور با کد ISO
+func LookupCountry(iso string) (Country, bool) {
+	c, ok := countries[iso]
+	return c, ok
+}
+
+func (c Country) validLength(n int) bool {
+	for _, l := range c.NationalLen {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Country) isMobile(national string) bool {
+	// کشورهایی که موبایل و ثابت را از هم جدا نمی‌کنند (مثل آمریکا)
+	if len(c.MobilePrefixes) == 0 {
+		return true
+	}
+	for _, p := range c.MobilePrefixes {
+		if len(national) >= len(p) && national[:len(p)] == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package phone
+
+// اپراتورهای تلفن همراه ایران
+const (
+	OperatorMCI      = "MCI"      // همراه اول
+	OperatorIrancell = "Irancell" // ایرانسل
+	OperatorRightel  = "Rightel"  // رایتل
+	OperatorShatel   = "Shatel"   // شاتل موبایل
+	OperatorTaliya   = "Taliya"   // تالیا
+	OperatorAptel    = "Aptel"    // آپتل
+)
+
+// iranOperators پیشوند سه‌رقمی شماره‌ی ملی (بدون ۰) به اپراتور
+var iranOperators = map[string]string{
+	"910": OperatorMCI, "911": OperatorMCI, "912": OperatorMCI, "913": OperatorMCI, "914": OperatorMCI,
+	"915": OperatorMCI, "916": OperatorMCI, "917": OperatorMCI, "918": OperatorMCI, "919": OperatorMCI,
+	"990": OperatorMCI, "991": OperatorMCI, "992": OperatorMCI, "993": OperatorMCI, "994": OperatorMCI,
+
+	"900": OperatorIrancell, "901": OperatorIrancell, "902": OperatorIrancell, "903": OperatorIrancell,
+	"904": OperatorIrancell, "905": OperatorIrancell, "930": OperatorIrancell, "933": OperatorIrancell,
+	"935": OperatorIrancell, "936": OperatorIrancell, "937": OperatorIrancell, "938": OperatorIrancell,
+	"939": OperatorIrancell, "941": OperatorIrancell,
+
+	"920": OperatorRightel, "921": OperatorRightel, "922": OperatorRightel, "923": OperatorRightel,
+
+	"998": OperatorShatel,
+	"932": OperatorTaliya,
+	"999": OperatorAptel,
+}
+
+func iranMobilePrefixes() []string {
+	out := make([]string, 0, len(iranOperators))
+	for p := range iranOperators {
+		out = append(out, p)
+	}
+	return out
+}
+
+// IranOperator اپراتور شماره‌ی موبایل ایرانی؛ برای شماره‌های غیرایرانی رشته‌ی خالی
+func (n Number) IranOperator() string {
+	if n.Country != "IR" || len(n.National) < 3 {
+		return ""
+	}
+	return iranOperators[n.National[:3]]
+}
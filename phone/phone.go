@@ -0,0 +1,168 @@
+package phone
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/textnorm"
+)
+
+// DefaultRegion کشور پیش‌فرض برای شماره‌های بدون پیش‌شماره‌ی بین‌المللی
+const DefaultRegion = "IR"
+
+// خطاها فقط اینجا تعریف شده‌اند (نه در model) تا entity بتواند بدون چرخه‌ی import از این پکیج استفاده کند
+var (
+	ErrInvalidNumber = errors.New("فرمت شماره موبایل نامعتبر است")
+	ErrNotMobile     = errors.New("شماره وارد شده موبایل نیست")
+	ErrUnknownRegion = errors.New("پیش‌شماره‌ی کشور پشتیبانی نمی‌شود")
+)
+
+// Number شماره‌ی تجزیه‌شده
+type Number struct {
+	Country     string // کد ISO؛ برای پیش‌شماره‌های ناشناخته خالی است
+	CallingCode string
+	National    string // شماره‌ی ملی بدون پیشوند داخلی
+}
+
+// E164 قالب +<پیش‌شماره><شماره‌ی ملی>
+func (n Number) E164() string {
+	return "+" + n.CallingCode + n.National
+}
+
+// Local قالب داخلی (مثلاً 09121234567)
+func (n Number) Local() string {
+	if c, ok := countries[n.Country]; ok {
+		return c.TrunkPrefix + n.National
+	}
+	return n.E164()
+}
+
+// IsMobile شماره با پیشوندهای موبایل کشورش مطابقت دارد یا نه؛ برای کشورهای ناشناخته false
+func (n Number) IsMobile() bool {
+	c, ok := countries[n.Country]
+	return ok && c.isMobile(n.National)
+}
+
+// Parse شماره را از قالب‌های داخلی (0912...)، بین‌المللی (+98912...، 0098912...) و با ارقام فارسی تجزیه می‌کند
+func Parse(raw, region string) (Number, error) {
+	s := clean(raw)
+	if s == "" {
+		return Number{}, ErrInvalidNumber
+	}
+	switch {
+	case strings.HasPrefix(s, "+"):
+		return parseInternational(s[1:])
+	case strings.HasPrefix(s, "00"):
+		return parseInternational(s[2:])
+	}
+	if !allDigits(s) {
+		return Number{}, ErrInvalidNumber
+	}
+
+	c, ok := countries[region]
+	if !ok {
+		return Number{}, ErrInvalidNumber
+	}
+	if c.TrunkPrefix != "" && strings.HasPrefix(s, c.TrunkPrefix) {
+		s = s[len(c.TrunkPrefix):]
+	} else if strings.HasPrefix(s, c.CallingCode) && c.validLength(len(s)-len(c.CallingCode)) {
+		// پیش‌شماره بدون + (مثلاً 989121234567)
+		s = s[len(c.CallingCode):]
+	}
+	if !c.validLength(len(s)) {
+		return Number{}, ErrInvalidNumber
+	}
+	return Number{Country: c.ISO, CallingCode: c.CallingCode, National: s}, nil
+}
+
+// ParseMobile مانند Parse ولی فقط شماره‌ی موبایل کشورهای پشتیبانی‌شده را می‌پذیرد؛
+// برای پیش‌شماره‌ی ناشناخته ErrUnknownRegion برمی‌گرداند
+func ParseMobile(raw, region string) (Number, error) {
+	n, err := Parse(raw, region)
+	if err != nil {
+		return Number{}, err
+	}
+	if n.Country == "" {
+		return Number{}, ErrUnknownRegion
+	}
+	if !n.IsMobile() {
+		return Number{}, ErrNotMobile
+	}
+	return n, nil
+}
+
+// ParseMobileAnyRegion مانند ParseMobile ولی پیش‌شماره‌های ناشناخته را فقط با قواعد عمومی E.164 می‌پذیرد
+// (موبایل بودن آن‌ها قابل بررسی نیست)
+func ParseMobileAnyRegion(raw, region string) (Number, error) {
+	n, err := Parse(raw, region)
+	if err != nil {
+		return Number{}, err
+	}
+	if n.Country != "" && !n.IsMobile() {
+		return Number{}, ErrNotMobile
+	}
+	return n, nil
+}
+
+// Normalize شماره‌ی موبایل را با کشور پیش‌فرض به E.164 تبدیل می‌کند
+func Normalize(raw string) (string, error) {
+	n, err := ParseMobile(raw, DefaultRegion)
+	if err != nil {
+		return "", err
+	}
+	return n.E164(), nil
+}
+
+// IsValidMobile شماره‌ی موبایل معتبر (با کشور پیش‌فرض) است یا نه
+func IsValidMobile(raw string) bool {
+	_, err := ParseMobile(raw, DefaultRegion)
+	return err == nil
+}
+
+func parseInternational(s string) (Number, error) {
+	// طول کل E.164 بین ۸ تا ۱۵ رقم
+	if !allDigits(s) || len(s) < 8 || len(s) > 15 {
+		return Number{}, ErrInvalidNumber
+	}
+	for l := 1; l <= 3; l++ {
+		iso, ok := byCallingCode[s[:l]]
+		if !ok {
+			continue
+		}
+		c := countries[iso]
+		national := s[l:]
+		// کاربران گاهی ۰ داخلی را بعد از پیش‌شماره هم می‌نویسند (+98 0912...)
+		if c.TrunkPrefix != "" && strings.HasPrefix(national, c.TrunkPrefix) && c.validLength(len(national)-len(c.TrunkPrefix)) {
+			national = national[len(c.TrunkPrefix):]
+		}
+		if !c.validLength(len(national)) {
+			return Number{}, ErrInvalidNumber
+		}
+		return Number{Country: iso, CallingCode: c.CallingCode, National: national}, nil
+	}
+	// پیش‌شماره‌ی ناشناخته: فقط قواعد عمومی E.164
+	return Number{National: s}, nil
+}
+
+func clean(raw string) string {
+	s := textnorm.Digits(strings.TrimSpace(raw))
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')', '.', '\u200c', '\u00a0':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func allDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		region  string
+		e164    string
+		country string
+		wantErr error
+	}{
+		{"local", "09121234567", "IR", "+989121234567", "IR", nil},
+		{"persian digits", "۰۹۱۲ ۱۲۳ ۴۵۶۷", "IR", "+989121234567", "IR", nil},
+		{"arabic-indic digits", "٠٩١٢١٢٣٤٥٦٧", "IR", "+989121234567", "IR", nil},
+		{"plus", "+98 912-123-4567", "IR", "+989121234567", "IR", nil},
+		{"double zero", "00989121234567", "IR", "+989121234567", "IR", nil},
+		{"calling code without plus", "989121234567", "IR", "+989121234567", "IR", nil},
+		{"trunk after calling code", "+98 0912 123 4567", "IR", "+989121234567", "IR", nil},
+		{"no trunk prefix", "9121234567", "IR", "+989121234567", "IR", nil},
+		{"landline", "02112345678", "IR", "+982112345678", "IR", nil},
+		{"other country by plus", "+44 7700 900123", "IR", "+447700900123", "GB", nil},
+		{"plus one is US", "+1 202 555 0143", "IR", "+12025550143", "US", nil},
+		{"unknown calling code", "+86 138 0013 8000", "IR", "+8613800138000", "", nil},
+		{"empty", " ", "IR", "", "", ErrInvalidNumber},
+		{"letters", "0912abc4567", "IR", "", "", ErrInvalidNumber},
+		{"too short", "0912123", "IR", "", "", ErrInvalidNumber},
+		{"too long", "091212345678", "IR", "", "", ErrInvalidNumber},
+		{"unknown region", "09121234567", "XX", "", "", ErrInvalidNumber},
+		{"international too short", "+9812345", "IR", "", "", ErrInvalidNumber},
+		{"international wrong length", "+98912123456", "IR", "", "", ErrInvalidNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := Parse(tt.raw, tt.region)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if n.E164() != tt.e164 || n.Country != tt.country {
+				t.Fatalf("Parse = %s (%s), want %s (%s)", n.E164(), n.Country, tt.e164, tt.country)
+			}
+		})
+	}
+}
+
+func TestParseMobile(t *testing.T) {
+	tests := []struct {
+		raw      string
+		local    string
+		operator string
+		wantErr  error
+	}{
+		{"09121234567", "09121234567", OperatorMCI, nil},
+		{"09351234567", "09351234567", OperatorIrancell, nil},
+		{"09211234567", "09211234567", OperatorRightel, nil},
+		{"+989981234567", "09981234567", OperatorShatel, nil},
+		{"02112345678", "", "", ErrNotMobile},
+		{"09601234567", "", "", ErrNotMobile},
+		{"+44 20 7946 0958", "", "", ErrNotMobile},
+		{"+12025550143", "2025550143", "", nil},
+		{"+33 6 12 34 56 78", "", "", ErrUnknownRegion},
+		{"123", "", "", ErrInvalidNumber},
+	}
+	for _, tt := range tests {
+		n, err := ParseMobile(tt.raw, DefaultRegion)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("ParseMobile(%q) err = %v, want %v", tt.raw, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if n.Local() != tt.local || n.IranOperator() != tt.operator {
+			t.Fatalf("ParseMobile(%q) = %s / %q, want %s / %q", tt.raw, n.Local(), n.IranOperator(), tt.local, tt.operator)
+		}
+	}
+}
+
+func TestParseMobileAnyRegion(t *testing.T) {
+	tests := []struct {
+		raw     string
+		e164    string
+		wantErr error
+	}{
+		{"+33 6 12 34 56 78", "+33612345678", nil},
+		{"09121234567", "+989121234567", nil},
+		{"02112345678", "", ErrNotMobile},
+		{"123", "", ErrInvalidNumber},
+	}
+	for _, tt := range tests {
+		n, err := ParseMobileAnyRegion(tt.raw, DefaultRegion)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("ParseMobileAnyRegion(%q) err = %v, want %v", tt.raw, err, tt.wantErr)
+		}
+		if err == nil && n.E164() != tt.e164 {
+			t.Fatalf("ParseMobileAnyRegion(%q) = %s, want %s", tt.raw, n.E164(), tt.e164)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		ok   bool
+	}{
+		{"۰۹۱۲۱۲۳۴۵۶۷", "+989121234567", true},
+		{"(0912) 123.4567", "+989121234567", true},
+		{"+989121234567", "+989121234567", true},
+		{"02112345678", "", false},
+		{"+8613800138000", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.raw)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("Normalize(%q) = %q, %v; want %q", tt.raw, got, err, tt.want)
+		}
+		if IsValidMobile(tt.raw) != tt.ok {
+			t.Errorf("IsValidMobile(%q) = %v", tt.raw, !tt.ok)
+		}
+	}
+}
+
+func TestLookupCountry(t *testing.T) {
+	c, ok := LookupCountry("IR")
+	if !ok || c.CallingCode != "98" || c.TrunkPrefix != "0" {
+		t.Fatalf("LookupCountry(IR) = %+v, %v", c, ok)
+	}
+	if _, ok := LookupCountry("ZZ"); ok {
+		t.Fatal("LookupCountry(ZZ) found")
+	}
+}
@@ -0,0 +1,204 @@
+package pow
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/alisiahmansouri/exchange-common/util"
+)
+
+// version نسخه‌ی قالب چالش: "1.<difficulty>.<expires unix>.<salt>.<signature>"
+const version = "1"
+
+type Config struct {
+	Key           []byte        // الزامی؛ کلید HMAC امضای چالش‌ها که بین همه‌ی نمونه‌های سرویس یکسان باشد
+	Difficulty    int           // سختی پیش‌فرض؛ پیش‌فرض: consts.PoWDifficulty
+	MinDifficulty int           // کمترین سختی پذیرفتنی در Verify؛ پیش‌فرض: Difficulty
+	TTL           time.Duration // پیش‌فرض: consts.PoWTTL
+	Replay        ReplayStore   // پیش‌فرض: MemoryReplayStore؛ با چند نمونه‌ی سرویس باید ذخیره‌ساز مشترک باشد
+	Now           func() time.Time
+}
+
+// Service صدور و بررسی چالش‌های hashcash بدون نیاز به ذخیره‌سازی
+type Service struct {
+	cfg Config
+}
+
+// NewService بدون Key خطای ErrPoWKeyRequired می‌دهد
+func NewService(cfg Config) (*Service, error) {
+	if len(cfg.Key) == 0 {
+		return nil, model.ErrPoWKeyRequired
+	}
+	if cfg.Difficulty <= 0 {
+		cfg.Difficulty = consts.PoWDifficulty
+	}
+	if cfg.MinDifficulty <= 0 {
+		cfg.MinDifficulty = cfg.Difficulty
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = consts.PoWTTL
+	}
+	if cfg.Now == nil {
+		cfg.Now = util.NowUTC
+	}
+	if cfg.Replay == nil {
+		store := NewMemoryReplayStore()
+		store.now = cfg.Now
+		cfg.Replay = store
+	}
+	return &Service{cfg: cfg}, nil
+}
+
+// Issue چالش با سختی پیش‌فرض صادر می‌کند
+func (s *Service) Issue() (*model.PoWChallengeResponse, error) {
+	return s.IssueWithDifficulty(s.cfg.Difficulty)
+}
+
+// IssueWithDifficulty چالش با سختی دلخواه (مثلاً بالاتر برای درخواست‌های پرخطر) صادر می‌کند؛
+// سختی به بازه‌ی MinDifficulty تا consts.PoWMaxDifficulty محدود می‌شود
+func (s *Service) IssueWithDifficulty(difficulty int) (*model.PoWChallengeResponse, error) {
+	difficulty = max(difficulty, s.cfg.MinDifficulty)
+	difficulty = min(difficulty, consts.PoWMaxDifficulty)
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, richerror.New(consts.OpPoWIssue, consts.ErrPoWIssueFail, consts.CodePoWIssueFail, richerror.KindInternal, err)
+	}
+	expiresAt := s.cfg.Now().Add(s.cfg.TTL).Truncate(time.Second)
+	payload := strings.Join([]string{
+		version,
+		strconv.Itoa(difficulty),
+		strconv.FormatInt(expiresAt.Unix(), 10),
+		hex.EncodeToString(salt),
+	}, ".")
+	return &model.PoWChallengeResponse{
+		Challenge:  payload + "." + s.sign(payload),
+		Algorithm:  consts.PoWAlgorithm,
+		Difficulty: difficulty,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// Verify امضا، انقضا، سختی (حداقل MinDifficulty) و هش پاسخ را بررسی می‌کند و چالش را در ReplayStore مصرف می‌کند
+func (s *Service) Verify(ctx context.Context, sol model.PoWSolution) error {
+	return s.VerifyWithDifficulty(ctx, sol, 0)
+}
+
+// VerifyWithDifficulty مانند Verify؛ چالش باید حداقل سختی required را داشته باشد. اگر چالش با
+// IssueWithDifficulty (مثلاً risk.Assessment.PoWDifficulty) صادر شده همان سختی را بدهید تا کلاینت نتواند
+// چالش ساده‌تر پیش‌فرض را جایگزین کند. required مانند IssueWithDifficulty محدود می‌شود.
+func (s *Service) VerifyWithDifficulty(ctx context.Context, sol model.PoWSolution, required int) error {
+	required = max(required, s.cfg.MinDifficulty)
+	required = min(required, consts.PoWMaxDifficulty)
+
+	c, err := s.parse(sol.Challenge)
+	if err != nil {
+		return verifyErr(err)
+	}
+	if !s.cfg.Now().Before(c.expiresAt) {
+		return verifyErr(model.ErrPoWExpired)
+	}
+	if c.difficulty < required {
+		return verifyErr(model.ErrPoWDifficulty)
+	}
+	if sol.Nonce == "" || len(sol.Nonce) > 64 || LeadingZeroBits(Hash(sol.Challenge, sol.Nonce)) < c.difficulty {
+		return verifyErr(model.ErrPoWInsufficient)
+	}
+	if err := s.cfg.Replay.Use(ctx, c.signature, c.expiresAt); err != nil {
+		if errors.Is(err, model.ErrPoWReplayed) {
+			return verifyErr(err)
+		}
+		return richerror.Wrap(consts.OpPoWVerify, err, consts.ErrPoWInvalid, consts.CodePoWInvalid, richerror.KindInternal)
+	}
+	return nil
+}
+
+type challenge struct {
+	difficulty int
+	expiresAt  time.Time
+	signature  string
+}
+
+func (s *Service) parse(token string) (challenge, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 || parts[0] != version {
+		return challenge{}, model.ErrPoWMalformed
+	}
+	payload := strings.Join(parts[:4], ".")
+	if !hmac.Equal([]byte(parts[4]), []byte(s.sign(payload))) {
+		return challenge{}, model.ErrPoWSignature
+	}
+	difficulty, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return challenge{}, model.ErrPoWMalformed
+	}
+	exp, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return challenge{}, model.ErrPoWMalformed
+	}
+	return challenge{difficulty: difficulty, expiresAt: time.Unix(exp, 0), signature: parts[4]}, nil
+}
+
+func (s *Service) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.cfg.Key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Hash هش پاسخ: sha256(challenge + ":" + nonce)
+func Hash(challenge, nonce string) []byte {
+	sum := sha256.Sum256([]byte(challenge + ":" + nonce))
+	return sum[:]
+}
+
+// LeadingZeroBits تعداد بیت‌های صفر ابتدای هش
+func LeadingZeroBits(h []byte) int {
+	n := 0
+	for _, b := range h {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
+
+// Solve نسخه‌ی Go حل‌کننده (برای کلاینت‌های داخلی و ابزارهای تست)؛ با لغو ctx متوقف می‌شود
+func Solve(ctx context.Context, challenge string, difficulty int) (string, error) {
+	for i := uint64(0); ; i++ {
+		if i&0xffff == 0 {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+		}
+		nonce := strconv.FormatUint(i, 10)
+		if LeadingZeroBits(Hash(challenge, nonce)) >= difficulty {
+			return nonce, nil
+		}
+	}
+}
+
+func verifyErr(err error) error {
+	switch {
+	case errors.Is(err, model.ErrPoWExpired):
+		return richerror.New(consts.OpPoWVerify, consts.ErrPoWExpired, consts.CodePoWExpired, richerror.KindInvalid, err)
+	case errors.Is(err, model.ErrPoWInsufficient):
+		return richerror.New(consts.OpPoWVerify, consts.ErrPoWInsufficient, consts.CodePoWInsufficient, richerror.KindInvalid, err)
+	case errors.Is(err, model.ErrPoWReplayed):
+		return richerror.New(consts.OpPoWVerify, consts.ErrPoWReplayed, consts.CodePoWReplayed, richerror.KindConflict, err)
+	default:
+		return richerror.New(consts.OpPoWVerify, consts.ErrPoWInvalid, consts.CodePoWInvalid, richerror.KindValidation, err)
+	}
+}
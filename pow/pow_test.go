@@ -0,0 +1,152 @@
+package pow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+func newTestService(t *testing.T, now *time.Time) *Service {
+	t.Helper()
+	s, err := NewService(Config{Key: []byte("test-key"), Difficulty: 8, Now: func() time.Time { return *now }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestNewServiceRequiresKey(t *testing.T) {
+	if _, err := NewService(Config{}); !errors.Is(err, model.ErrPoWKeyRequired) {
+		t.Fatalf("err = %v, want ErrPoWKeyRequired", err)
+	}
+	s, err := NewService(Config{Key: []byte("k")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.cfg.Replay.(*MemoryReplayStore); !ok {
+		t.Fatalf("Replay = %T, want *MemoryReplayStore", s.cfg.Replay)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestService(t, &now)
+
+	ch, err := s.Issue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce, err := Solve(ctx, ch.Challenge, ch.Difficulty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := NewService(Config{Key: []byte("other-key"), Difficulty: 8})
+
+	tests := []struct {
+		name     string
+		svc      *Service
+		sol      model.PoWSolution
+		advance  time.Duration
+		wantErr  error
+		wantCode string
+	}{
+		{"valid", s, model.PoWSolution{Challenge: ch.Challenge, Nonce: nonce}, 0, nil, ""},
+		{"replayed", s, model.PoWSolution{Challenge: ch.Challenge, Nonce: nonce}, 0, model.ErrPoWReplayed, consts.CodePoWReplayed},
+		{"wrong key", other, model.PoWSolution{Challenge: ch.Challenge, Nonce: nonce}, 0, model.ErrPoWSignature, consts.CodePoWInvalid},
+		{"malformed", s, model.PoWSolution{Challenge: "1.2.3", Nonce: nonce}, 0, model.ErrPoWMalformed, consts.CodePoWInvalid},
+		{"empty nonce", s, model.PoWSolution{Challenge: ch.Challenge}, 0, model.ErrPoWInsufficient, consts.CodePoWInsufficient},
+		{"expired", s, model.PoWSolution{Challenge: ch.Challenge, Nonce: nonce}, consts.PoWTTL, model.ErrPoWExpired, consts.CodePoWExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			err := tt.svc.Verify(ctx, tt.sol)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			var re *richerror.RichError
+			if err != nil && (!errors.As(err, &re) || re.Code != tt.wantCode) {
+				t.Fatalf("code = %v, want %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+// چالش ساده‌ی پیش‌فرض نباید جای چالش سخت‌تر صادرشده برای درخواست پرخطر پذیرفته شود
+func TestVerifyWithDifficulty(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestService(t, &now)
+
+	solve := func(difficulty int) model.PoWSolution {
+		ch, err := s.IssueWithDifficulty(difficulty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce, err := Solve(ctx, ch.Challenge, ch.Difficulty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return model.PoWSolution{Challenge: ch.Challenge, Nonce: nonce}
+	}
+	tests := []struct {
+		name     string
+		issued   int
+		required int
+		wantErr  error
+	}{
+		{"default challenge, default required", 8, 0, nil},
+		{"default challenge swapped for harder one", 8, 10, model.ErrPoWDifficulty},
+		{"harder challenge", 10, 10, nil},
+		{"harder than required", 10, 9, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.VerifyWithDifficulty(ctx, solve(tt.issued), tt.required); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIssueWithDifficultyClamps(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestService(t, &now)
+	tests := []struct{ in, want int }{
+		{0, 8},
+		{12, 12},
+		{1000, consts.PoWMaxDifficulty},
+	}
+	for _, tt := range tests {
+		ch, err := s.IssueWithDifficulty(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ch.Difficulty != tt.want {
+			t.Errorf("IssueWithDifficulty(%d) = %d, want %d", tt.in, ch.Difficulty, tt.want)
+		}
+	}
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	tests := []struct {
+		h    []byte
+		want int
+	}{
+		{[]byte{0x80}, 0},
+		{[]byte{0x01}, 7},
+		{[]byte{0x00, 0x40}, 9},
+		{[]byte{0x00, 0x00}, 16},
+	}
+	for _, tt := range tests {
+		if got := LeadingZeroBits(tt.h); got != tt.want {
+			t.Errorf("LeadingZeroBits(%x) = %d, want %d", tt.h, got, tt.want)
+		}
+	}
+}
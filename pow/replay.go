@@ -0,0 +1,46 @@
+package pow
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+// ReplayStore جلوگیری از استفاده‌ی دوباره‌ی یک چالش حل‌شده
+type ReplayStore interface {
+	// Use شناسه را تا expiresAt مصرف‌شده علامت می‌زند؛ اگر قبلاً مصرف شده باشد model.ErrPoWReplayed برمی‌گرداند
+	Use(ctx context.Context, id string, expiresAt time.Time) error
+}
+
+// MemoryReplayStore پیاده‌سازی درون‌حافظه‌ای ReplayStore
+type MemoryReplayStore struct {
+	mu        sync.Mutex
+	used      map[string]time.Time
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{used: make(map[string]time.Time), now: time.Now}
+}
+
+func (s *MemoryReplayStore) Use(_ context.Context, id string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if now.Sub(s.lastSweep) >= time.Minute {
+		s.lastSweep = now
+		for k, exp := range s.used {
+			if !now.Before(exp) {
+				delete(s.used, k)
+			}
+		}
+	}
+	if exp, ok := s.used[id]; ok && now.Before(exp) {
+		return model.ErrPoWReplayed
+	}
+	s.used[id] = expiresAt
+	return nil
+}
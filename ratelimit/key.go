@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/identifier"
+	"github.com/alisiahmansouri/exchange-common/textnorm"
+	"github.com/alisiahmansouri/exchange-common/token"
+	"github.com/gin-gonic/gin"
+)
+
+// Key کلید ترکیبی از بخش‌ها، مثلاً Key("login", "ip", ip, "id", identifier)
+func Key(parts ...string) string {
+	return "rl:" + strings.Join(parts, ":")
+}
+
+// KeyFunc بخشی از کلید را از درخواست استخراج می‌کند؛ رشته‌ی خالی یعنی این بخش در دسترس نیست
+type KeyFunc func(c *gin.Context) string
+
+// ByIP آدرس IP کلاینت (بر اساس تنظیمات TrustedProxies در gin)
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByUser شناسه‌ی کاربر از توکن (نیازمند token.Middleware)؛ برای درخواست بدون توکن خالی
+func ByUser(c *gin.Context) string {
+	claims, err := token.ClaimsFromContext(c)
+	if err != nil || claims.Subject == "" {
+		return ""
+	}
+	return "user:" + claims.Subject
+}
+
+// ByEndpoint متد و مسیر ثبت‌شده‌ی route (نه مسیر خام، تا پارامترها کلید جدا نسازند)
+func ByEndpoint(c *gin.Context) string {
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+	return "ep:" + c.Request.Method + " " + path
+}
+
+// ByParam مقدار یک پارامتر مسیر یا query (مثلاً identifier در ارسال مجدد کد)؛ مقدار نرمال می‌شود
+// تا شکل‌های مختلف یک شناسه (۰۹۱۲…، +98912…، ایمیل با حروف بزرگ) سهمیه‌ی جدا نگیرند
+func ByParam(name string) KeyFunc {
+	return func(c *gin.Context) string {
+		v := c.Param(name)
+		if v == "" {
+			v = c.Query(name)
+		}
+		if v = normalizeParam(v); v == "" {
+			return ""
+		}
+		return name + ":" + v
+	}
+}
+
+// normalizeParam ایمیل یا شماره‌ی معتبر را به شکل کانونی identifier و بقیه را با textnorm و حروف کوچک نرمال می‌کند
+func normalizeParam(v string) string {
+	if id, err := identifier.Parse(v); err == nil {
+		return id.String()
+	}
+	return strings.ToLower(textnorm.Text(v))
+}
+
+// UserOrIP کاربر احراز‌شده با شناسه‌اش و در غیر این صورت با IP شناخته می‌شود
+func UserOrIP(c *gin.Context) string {
+	if k := ByUser(c); k != "" {
+		return k
+	}
+	return ByIP(c)
+}
+
+// Compose چند KeyFunc را به یک کلید ترکیبی تبدیل می‌کند؛ بخش‌های خالی حذف می‌شوند
+func Compose(fns ...KeyFunc) KeyFunc {
+	return func(c *gin.Context) string {
+		parts := make([]string, 0, len(fns))
+		for _, fn := range fns {
+			if p := fn(c); p != "" {
+				parts = append(parts, p)
+			}
+		}
+		return strings.Join(parts, ":")
+	}
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestByParam(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"local phone", "09121234567", "identifier:+989121234567"},
+		{"persian digits", "۰۹۱۲۱۲۳۴۵۶۷", "identifier:+989121234567"},
+		{"international phone", "+98 912 123 4567", "identifier:+989121234567"},
+		{"email case", " Ali@Example.COM ", "identifier:ali@example.com"},
+		{"other value", " ABC‌ ", "identifier:abc"},
+		{"empty", "", ""},
+		{"blank", "   ", ""},
+	}
+	gin.SetMode(gin.TestMode)
+	key := ByParam("identifier")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/?identifier="+url.QueryEscape(tt.value), nil)
+			if got := key(c); got != tt.want {
+				t.Fatalf("key = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompose(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.RemoteAddr = "1.2.3.4:5678"
+	if got := Compose(ByUser, ByIP, ByParam("x"))(c); got != "ip:1.2.3.4" {
+		t.Fatalf("Compose = %q", got)
+	}
+}
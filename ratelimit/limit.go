@@ -0,0 +1,163 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Algorithm الگوریتم محدودسازی
+type Algorithm uint8
+
+const (
+	// SlidingWindow حداکثر Rate رویداد در هر Period لغزان (تقریب وزنی دو پنجره‌ی ثابت)؛
+	// مناسب تلاش ورود و ارسال مجدد کد
+	SlidingWindow Algorithm = iota
+	// TokenBucket نرخ پایدار Rate در Period با امکان جهش تا Burst؛ مناسب ثبت سفارش و API
+	TokenBucket
+)
+
+// Limit تعریف یک سهمیه
+type Limit struct {
+	Algorithm Algorithm
+	Rate      int           // تعداد رویداد مجاز در Period
+	Period    time.Duration // طول پنجره یا زمان پر شدن Rate توکن
+	Burst     int           // ظرفیت سطل (فقط TokenBucket)؛ پیش‌فرض: Rate
+}
+
+// PerWindow سهمیه‌ی پنجره‌ی لغزان: n رویداد در هر period
+func PerWindow(n int, period time.Duration) Limit {
+	return Limit{Algorithm: SlidingWindow, Rate: n, Period: period}
+}
+
+// PerSecond سطل توکن با n توکن در ثانیه و ظرفیت burst
+func PerSecond(n, burst int) Limit {
+	return Limit{Algorithm: TokenBucket, Rate: n, Period: time.Second, Burst: burst}
+}
+
+// PerMinute سطل توکن با n توکن در دقیقه و ظرفیت burst
+func PerMinute(n, burst int) Limit {
+	return Limit{Algorithm: TokenBucket, Rate: n, Period: time.Minute, Burst: burst}
+}
+
+// IsZero سهمیه‌ی تعریف‌نشده (بدون محدودیت)
+func (l Limit) IsZero() bool { return l.Rate <= 0 || l.Period <= 0 }
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// ttl مدت نگهداری وضعیت کلید بعد از آخرین استفاده
+func (l Limit) ttl() time.Duration {
+	if l.Algorithm == TokenBucket {
+		// زمان پر شدن کامل سطل
+		return time.Duration(float64(l.Period) * float64(l.burst()) / float64(l.Rate))
+	}
+	return 2 * l.Period
+}
+
+// Result نتیجه‌ی یک بررسی
+type Result struct {
+	Allowed    bool
+	Limit      int           // حداکثر (Rate یا Burst)
+	Remaining  int           // باقی‌مانده پس از این درخواست
+	RetryAfter time.Duration // زمان انتظار تا درخواست بعدی (فقط وقتی Allowed نادرست است)
+	ResetAfter time.Duration // زمان تا بازگشت کامل سهمیه
+}
+
+// State وضعیت یک کلید که Store نگه می‌دارد
+type State struct {
+	// TokenBucket
+	Tokens float64
+	Last   time.Time
+
+	// SlidingWindow
+	WindowStart time.Time
+	Current     int
+	Previous    int
+}
+
+// apply n رویداد را روی وضعیت اعمال می‌کند؛ در صورت رد شدن سهمیه‌ای مصرف نمی‌شود
+func (l Limit) apply(st *State, now time.Time, n int) Result {
+	if l.Algorithm == TokenBucket {
+		return l.tokenBucket(st, now, n)
+	}
+	return l.slidingWindow(st, now, n)
+}
+
+func (l Limit) tokenBucket(st *State, now time.Time, n int) Result {
+	capacity := float64(l.burst())
+	perToken := float64(l.Period) / float64(l.Rate)
+	if st.Last.IsZero() {
+		st.Tokens = capacity
+	} else if elapsed := now.Sub(st.Last); elapsed > 0 {
+		st.Tokens = math.Min(capacity, st.Tokens+float64(elapsed)/perToken)
+	}
+	st.Last = now
+
+	res := Result{Limit: l.burst()}
+	if st.Tokens >= float64(n) {
+		st.Tokens -= float64(n)
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration(math.Ceil((float64(n) - st.Tokens) * perToken))
+	}
+	res.Remaining = int(st.Tokens)
+	res.ResetAfter = time.Duration((capacity - st.Tokens) * perToken)
+	return res
+}
+
+func (l Limit) slidingWindow(st *State, now time.Time, n int) Result {
+	start := now.Truncate(l.Period)
+	switch {
+	case st.WindowStart.Equal(start):
+	case st.WindowStart.Add(l.Period).Equal(start):
+		st.Previous, st.Current = st.Current, 0
+		st.WindowStart = start
+	default:
+		st.Previous, st.Current = 0, 0
+		st.WindowStart = start
+	}
+
+	// وزن پنجره‌ی قبلی به نسبت بخشی از آن که هنوز داخل پنجره‌ی لغزان است
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(l.Period)
+	used := float64(st.Previous)*weight + float64(st.Current)
+
+	res := Result{Limit: l.Rate}
+	if used+float64(n) <= float64(l.Rate) {
+		st.Current += n
+		res.Allowed = true
+		res.Remaining = l.Rate - int(math.Ceil(used+float64(n)))
+	} else {
+		res.Remaining = max(0, l.Rate-int(math.Ceil(used)))
+		res.RetryAfter = l.retryAfter(st, elapsed, n)
+	}
+	// رویدادهای پنجره‌ی جاری تا پایان پنجره‌ی بعد اثر دارند
+	res.ResetAfter = l.Period - elapsed
+	if st.Current > 0 {
+		res.ResetAfter += l.Period
+	}
+	return res
+}
+
+// retryAfter زمانی که با کاهش وزن پنجره‌ی قبلی (یا شروع پنجره‌ی بعد) n رویداد جا می‌شود
+func (l Limit) retryAfter(st *State, elapsed time.Duration, n int) time.Duration {
+	free := float64(l.Rate - st.Current - n)
+	if free >= 0 && st.Previous > 0 {
+		// Previous * (1 - t/Period) <= free
+		t := time.Duration((1 - free/float64(st.Previous)) * float64(l.Period))
+		if t > elapsed {
+			return t - elapsed
+		}
+	}
+	// تا پایان پنجره‌ی جاری صبر و سپس سهم Current به عنوان Previous کم می‌شود
+	wait := l.Period - elapsed
+	if next := float64(l.Rate - n); next >= 0 && st.Current > 0 {
+		t := time.Duration((1 - next/float64(st.Current)) * float64(l.Period))
+		wait += max(0, t)
+	}
+	return wait
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	start := time.Unix(1_700_000_040, 0) // ابتدای یک پنجره‌ی دقیقه‌ای
+	l := PerWindow(3, time.Minute)
+	tests := []struct {
+		name      string
+		at        time.Duration
+		allowed   bool
+		remaining int
+	}{
+		{"first", 0, true, 2},
+		{"second", time.Second, true, 1},
+		{"third", 2 * time.Second, true, 0},
+		{"over limit", 3 * time.Second, false, 0},
+		{"next window keeps weighted previous", time.Minute, false, 0},
+		{"previous weight decays", time.Minute + 30*time.Second, true, 0},
+		{"two windows later resets", 3 * time.Minute, true, 2},
+	}
+	var st State
+	for _, tt := range tests {
+		res := l.apply(&st, start.Add(tt.at), 1)
+		if res.Allowed != tt.allowed || res.Remaining != tt.remaining {
+			t.Fatalf("%s: allowed, remaining = %v, %d; want %v, %d", tt.name, res.Allowed, res.Remaining, tt.allowed, tt.remaining)
+		}
+		if !res.Allowed && res.RetryAfter <= 0 {
+			t.Fatalf("%s: RetryAfter = %v", tt.name, res.RetryAfter)
+		}
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	l := PerSecond(2, 4)
+	tests := []struct {
+		name       string
+		at         time.Duration
+		n          int
+		allowed    bool
+		retryAfter time.Duration
+	}{
+		{"burst", 0, 4, true, 0},
+		{"empty", 0, 1, false, 500 * time.Millisecond},
+		{"refilled one", 500 * time.Millisecond, 1, true, 0},
+		{"refill capped at burst", 10 * time.Second, 5, false, 500 * time.Millisecond},
+		{"full burst again", 10 * time.Second, 4, true, 0},
+	}
+	var st State
+	for _, tt := range tests {
+		res := l.apply(&st, start.Add(tt.at), tt.n)
+		if res.Allowed != tt.allowed || res.RetryAfter != tt.retryAfter {
+			t.Fatalf("%s: allowed, retryAfter = %v, %v; want %v, %v", tt.name, res.Allowed, res.RetryAfter, tt.allowed, tt.retryAfter)
+		}
+	}
+}
+
+func TestResultRetryAfterSeconds(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "1"},
+		{time.Millisecond, "1"},
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+	}
+	for _, tt := range tests {
+		if got := (Result{RetryAfter: tt.d}).RetryAfterSeconds(); got != tt.want {
+			t.Errorf("RetryAfterSeconds(%v) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Update(context.Context, string, time.Duration, func(*State)) error {
+	return errors.New("redis down")
+}
+func (failingStore) Delete(context.Context, string) error { return nil }
+
+func TestLimiterStoreFailure(t *testing.T) {
+	res, err := NewLimiter(failingStore{}).Allow(context.Background(), "k", PerWindow(1, time.Minute))
+	var re *richerror.RichError
+	if !errors.As(err, &re) || re.Code != consts.CodeRateLimitUnavailable || re.UserMessage != consts.ErrRateLimitUnavailable {
+		t.Fatalf("err = %v, want %s", err, consts.CodeRateLimitUnavailable)
+	}
+	if !res.Allowed {
+		t.Fatal("store failure must fail open")
+	}
+	if res, err := NewLimiter(failingStore{}).Allow(context.Background(), "k", Limit{}); err != nil || !res.Allowed {
+		t.Fatalf("zero limit: %+v, %v", res, err)
+	}
+}
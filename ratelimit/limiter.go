@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+// Limiter بررسی سهمیه‌ها روی یک Store مشترک
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+// Allow یک رویداد برای key ثبت می‌کند
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	return l.AllowN(ctx, key, limit, 1)
+}
+
+// AllowN n رویداد (مثلاً وزن یک درخواست دسته‌ای) برای key ثبت می‌کند؛ سهمیه‌ی صفر یعنی بدون محدودیت
+func (l *Limiter) AllowN(ctx context.Context, key string, limit Limit, n int) (Result, error) {
+	if limit.IsZero() {
+		return Result{Allowed: true}, nil
+	}
+	var res Result
+	err := l.store.Update(ctx, key, limit.ttl(), func(st *State) {
+		res = limit.apply(st, l.now(), n)
+	})
+	if err != nil {
+		return Result{Allowed: true}, richerror.Wrap(consts.OpRateLimitAllow, err, consts.ErrRateLimitUnavailable, consts.CodeRateLimitUnavailable, richerror.KindInternal)
+	}
+	return res, nil
+}
+
+// Reset سهمیه‌ی key را بازنشانی می‌کند (مثلاً پس از ورود موفق)
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	return l.store.Delete(ctx, key)
+}
+
+// Err برای درخواست ردشده خطای KindTooManyRequests با پیام و کد داده‌شده برمی‌گرداند؛ برای درخواست مجاز nil
+func (r Result) Err(op, userMsg, code string) error {
+	if r.Allowed {
+		return nil
+	}
+	return richerror.New(op, userMsg, code, richerror.KindTooManyRequests, model.ErrRateLimited)
+}
+
+// RetryAfterSeconds مقدار هدر Retry-After (حداقل ۱ ثانیه)
+func (r Result) RetryAfterSeconds() string {
+	sec := int64((r.RetryAfter + time.Second - 1) / time.Second)
+	return strconv.FormatInt(max(sec, 1), 10)
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/gin-gonic/gin"
+)
+
+// Rule یک قاعده‌ی محدودسازی برای middleware
+type Rule struct {
+	Name    string   // پیشوند کلید، مثلاً "login" یا "order"
+	Key     KeyFunc  // پیش‌فرض: ByIP
+	Quotas  Quotas   // سهمیه بر اساس سطح کاربر
+	Tier    TierFunc // پیش‌فرض: TierFromClaims
+	Code    string   // پیش‌فرض: consts.CodeTooManyRequests
+	Message string   // پیش‌فرض: consts.ErrTooManyRequests
+}
+
+// Middleware قواعد را به ترتیب بررسی می‌کند؛ در صورت رد شدن پاسخ 429 با کد قاعده و هدر Retry-After برمی‌گرداند.
+// خطای Store درخواست را رد نمی‌کند (fail-open) تا قطعی ذخیره‌ساز کل سرویس را از کار نیندازد.
+func Middleware(l *Limiter, rules ...Rule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		remaining, limit := -1, 0
+		for _, r := range rules {
+			keyFn, tierFn := r.Key, r.Tier
+			if keyFn == nil {
+				keyFn = ByIP
+			}
+			if tierFn == nil {
+				tierFn = TierFromClaims
+			}
+			key := keyFn(c)
+			if key == "" {
+				// بدون کلید (مثلاً ByUser برای درخواست ناشناس) قاعده اعمال نمی‌شود تا همه در یک سهمیه‌ی مشترک نیفتند
+				continue
+			}
+			tier := tierFn(c)
+			res, err := l.Allow(c.Request.Context(), Key(r.Name, string(tier), key), r.Quotas.For(tier))
+			if err != nil || res.Limit == 0 {
+				continue
+			}
+			if remaining < 0 || res.Remaining < remaining {
+				remaining, limit = res.Remaining, res.Limit
+			}
+			if !res.Allowed {
+				code, msg := r.Code, r.Message
+				if code == "" {
+					code = consts.CodeTooManyRequests
+				}
+				if msg == "" {
+					msg = consts.ErrTooManyRequests
+				}
+				setHeaders(c, res.Limit, res.Remaining)
+				c.Header("Retry-After", res.RetryAfterSeconds())
+				model.ErrorResponse(c, http.StatusTooManyRequests, msg, code)
+				c.Abort()
+				return
+			}
+		}
+		if remaining >= 0 {
+			setHeaders(c, limit, remaining)
+		}
+		c.Next()
+	}
+}
+
+func setHeaders(c *gin.Context, limit, remaining int) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/x", Middleware(NewLimiter(NewMemoryStore()), Rule{
+		Name:   "test",
+		Quotas: Quotas{Default: PerWindow(2, time.Hour)},
+	}), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		status    int
+		remaining string
+	}{
+		{http.StatusNoContent, "1"},
+		{http.StatusNoContent, "0"},
+		{http.StatusTooManyRequests, "0"},
+	}
+	for i, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		req.RemoteAddr = "1.2.3.4:1"
+		r.ServeHTTP(w, req)
+		if w.Code != tt.status || w.Header().Get("X-RateLimit-Remaining") != tt.remaining {
+			t.Fatalf("request %d: status %d remaining %q; want %d %q", i+1, w.Code, w.Header().Get("X-RateLimit-Remaining"), tt.status, tt.remaining)
+		}
+		if tt.status == http.StatusTooManyRequests {
+			var res model.Response
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || res.ErrorCode != consts.CodeTooManyRequests {
+				t.Fatalf("body = %s, %v", w.Body, err)
+			}
+			if w.Header().Get("Retry-After") == "" {
+				t.Fatal("missing Retry-After")
+			}
+		}
+	}
+
+	// Store خراب درخواست را رد نمی‌کند
+	r2 := gin.New()
+	r2.GET("/x", Middleware(NewLimiter(failingStore{}), Rule{Name: "test", Quotas: Quotas{Default: PerWindow(1, time.Hour)}}),
+		func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	w := httptest.NewRecorder()
+	r2.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("fail-open status = %d", w.Code)
+	}
+}
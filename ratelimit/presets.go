@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"github.com/alisiahmansouri/exchange-common/consts"
+)
+
+// LoginLimit تلاش ورود برای یک شناسه (در سرویس با Key("login", "id", identifier) بررسی شود)
+var LoginLimit = PerWindow(consts.MaxLoginAttempts, consts.LoginAttemptDuration)
+
+// ResendCodeLimit ارسال مجدد کد تایید برای یک شناسه
+var ResendCodeLimit = PerWindow(consts.ResendCodeLimit, consts.ResendCodeWindow)
+
+// LoginRule محدودیت تلاش ورود به ازای IP
+func LoginRule() Rule {
+	return Rule{
+		Name:    "login",
+		Key:     ByIP,
+		Quotas:  Quotas{Default: PerWindow(consts.LoginIPAttempts, consts.LoginAttemptDuration)},
+		Code:    consts.CodeLoginThrottled,
+		Message: consts.ErrLoginThrottled,
+	}
+}
+
+// ResendCodeRule محدودیت ارسال مجدد کد به ازای IP
+func ResendCodeRule() Rule {
+	return Rule{
+		Name:    "resend",
+		Key:     ByIP,
+		Quotas:  Quotas{Default: ResendCodeLimit},
+		Code:    consts.CodeRateLimitExceeded,
+		Message: consts.ErrRateLimitExceeded,
+	}
+}
+
+// OrderRule محدودیت ثبت سفارش به ازای کاربر با سهمیه‌ی وابسته به سطح
+func OrderRule() Rule {
+	return Rule{
+		Name: "order",
+		Key:  UserOrIP,
+		Quotas: Quotas{
+			Default: orderLimit(consts.OrderRateBasic),
+			Tiers: map[Tier]Limit{
+				TierVerified: orderLimit(consts.OrderRateVerified),
+				TierVIP:      orderLimit(consts.OrderRateVIP),
+			},
+		},
+		Code:    consts.CodeOrderTooManyRequests,
+		Message: consts.ErrOrderTooManyRequests,
+	}
+}
+
+func orderLimit(perSecond int) Limit {
+	return PerSecond(perSecond, perSecond*consts.OrderRateBurstSeconds)
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store نگهداری وضعیت کلیدها؛ Update باید برای هر کلید اتمیک باشد
+// (مثلاً در Redis با WATCH/MULTI یا اسکریپت Lua)
+type Store interface {
+	// Update وضعیت کلید (یا مقدار صفر) را به fn می‌دهد و نتیجه را تا ttl نگه می‌دارد
+	Update(ctx context.Context, key string, ttl time.Duration, fn func(*State)) error
+	// Delete وضعیت کلید را پاک می‌کند (مثلاً پس از ورود موفق)
+	Delete(ctx context.Context, key string) error
+}
+
+type memoryEntry struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore پیاده‌سازی درون‌حافظه‌ای Store برای یک نمونه‌ی سرویس
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry), now: time.Now}
+}
+
+func (s *MemoryStore) Update(_ context.Context, key string, ttl time.Duration, fn func(*State)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+	e, ok := s.entries[key]
+	if !ok || !now.Before(e.expiresAt) {
+		e = &memoryEntry{}
+		s.entries[key] = e
+	}
+	fn(&e.state)
+	e.expiresAt = now.Add(ttl)
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Len تعداد کلیدهای نگهداری‌شده
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// sweep کلیدهای منقضی را حداکثر هر دقیقه یک بار پاک می‌کند
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for k, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+}
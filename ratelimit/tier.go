@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/token"
+	"github.com/gin-gonic/gin"
+)
+
+// Tier سطح کاربر برای انتخاب سهمیه
+type Tier string
+
+const (
+	TierAnonymous Tier = consts.TierAnonymous
+	TierBasic     Tier = consts.TierBasic
+	TierVerified  Tier = consts.TierVerified
+	TierVIP       Tier = consts.TierVIP
+)
+
+// ClaimTier نام ادعای سفارشی توکن (Claims.Custom) که سطح کاربر را نگه می‌دارد
+const ClaimTier = "tier"
+
+// TierFunc سطح کاربر درخواست را تعیین می‌کند
+type TierFunc func(c *gin.Context) Tier
+
+// TierFromClaims سطح را از Claims.Custom[ClaimTier] می‌خواند؛
+// بدون توکن TierAnonymous و بدون ادعا TierBasic
+func TierFromClaims(c *gin.Context) Tier {
+	claims, err := token.ClaimsFromContext(c)
+	if err != nil {
+		return TierAnonymous
+	}
+	if t, ok := claims.Custom[ClaimTier].(string); ok && t != "" {
+		return Tier(t)
+	}
+	return TierBasic
+}
+
+// Quotas سهمیه‌ی هر سطح؛ سطح ناشناخته از Default استفاده می‌کند
+type Quotas struct {
+	Default Limit
+	Tiers   map[Tier]Limit
+}
+
+// For سهمیه‌ی سطح t
+func (q Quotas) For(t Tier) Limit {
+	if l, ok := q.Tiers[t]; ok {
+		return l
+	}
+	return q.Default
+}
@@ -31,6 +31,8 @@ type RichError struct {
 	Err         error  // خطای اصلی
 	Caller      string // موقعیت دقیق در کد (مثلاً file.go:42)
 	Msg         string // توضیح فنی برای لاگ
+
+	Params map[string]interface{} // مقادیر جایگزین {name} در پیام کاتالوگ کد (مثلاً wait)
 }
 
 func KindOf(err error) Kind {
@@ -103,6 +105,12 @@ func Wrap(op string, err error, userMsg, code string, kind Kind) *RichError {
 	}
 }
 
+// WithParams مقادیر جایگزین پیام کاتالوگ را تنظیم می‌کند تا پیام به زبان‌های دیگر هم کامل باشد
+func (r *RichError) WithParams(params map[string]interface{}) *RichError {
+	r.Params = params
+	return r
+}
+
 // ابزارهای داخلی
 
 func extractMsg(err error) string {
@@ -0,0 +1,33 @@
+package richerror
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWithParams(t *testing.T) {
+	base := errors.New("throttled")
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+	}{
+		{"nil", nil},
+		{"single", map[string]interface{}{"wait": 30}},
+		{"several", map[string]interface{}{"wait": "1m", "limit": 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := New("op", "msg", "CODE", KindTooManyRequests, base)
+			if got := re.WithParams(tt.params); got != re {
+				t.Fatal("WithParams did not return the receiver")
+			}
+			if !reflect.DeepEqual(re.Params, tt.params) {
+				t.Fatalf("Params = %v, want %v", re.Params, tt.params)
+			}
+			if !errors.Is(re, base) || re.Code != "CODE" {
+				t.Fatalf("WithParams changed the error: %v", re)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package risk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Counter شمارش رویدادها در پنجره‌ی زمانی لغزان
+type Counter interface {
+	// Add یک رویداد ثبت و تعداد رویدادهای window اخیر (شامل همین رویداد) را برمی‌گرداند
+	Add(ctx context.Context, key string, window time.Duration) (int, error)
+	// Count تعداد رویدادهای window اخیر
+	Count(ctx context.Context, key string, window time.Duration) (int, error)
+	// Reset همه‌ی رویدادهای کلید را پاک می‌کند
+	Reset(ctx context.Context, key string) error
+}
+
+// MemoryCounter پیاده‌سازی درون‌حافظه‌ای Counter؛ هر کلید حداکثر maxEvents زمان آخر را نگه می‌دارد
+type MemoryCounter struct {
+	mu        sync.Mutex
+	events    map[string][]time.Time
+	maxEvents int
+	maxWindow time.Duration
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{
+		events:    make(map[string][]time.Time),
+		maxEvents: 1000,
+		now:       time.Now,
+	}
+}
+
+func (c *MemoryCounter) Add(_ context.Context, key string, window time.Duration) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.maxWindow = max(c.maxWindow, window)
+	c.sweep(now)
+	ev := append(c.events[key], now)
+	if len(ev) > c.maxEvents {
+		ev = ev[len(ev)-c.maxEvents:]
+	}
+	c.events[key] = ev
+	return countSince(ev, now.Add(-window)), nil
+}
+
+func (c *MemoryCounter) Count(_ context.Context, key string, window time.Duration) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	return countSince(c.events[key], now.Add(-window)), nil
+}
+
+func (c *MemoryCounter) Reset(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.events, key)
+	return nil
+}
+
+// sweep کلیدهایی که آخرین رویدادشان از بزرگ‌ترین پنجره قدیمی‌تر است را حداکثر هر دقیقه پاک می‌کند
+func (c *MemoryCounter) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < time.Minute {
+		return
+	}
+	c.lastSweep = now
+	for k, ev := range c.events {
+		if len(ev) == 0 || now.Sub(ev[len(ev)-1]) > c.maxWindow {
+			delete(c.events, k)
+		}
+	}
+}
+
+// countSince رویدادها به ترتیب زمان ذخیره شده‌اند
+func countSince(ev []time.Time, since time.Time) int {
+	for i, t := range ev {
+		if t.After(since) {
+			return len(ev) - i
+		}
+	}
+	return 0
+}
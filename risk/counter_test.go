@@ -0,0 +1,85 @@
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestCounter() (*MemoryCounter, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewMemoryCounter()
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestMemoryCounterWindow(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestCounter()
+	window := time.Minute
+
+	tests := []struct {
+		advance time.Duration
+		add     bool
+		want    int
+	}{
+		{0, true, 1},
+		{20 * time.Second, true, 2},
+		{20 * time.Second, true, 3},
+		{19 * time.Second, false, 3},
+		{time.Second, false, 2}, // اولین رویداد دقیقاً یک دقیقه پیش است
+		{40 * time.Second, false, 0},
+		{0, true, 1},
+	}
+	for i, tt := range tests {
+		*now = now.Add(tt.advance)
+		var got int
+		var err error
+		if tt.add {
+			got, err = c.Add(ctx, "k", window)
+		} else {
+			got, err = c.Count(ctx, "k", window)
+		}
+		if err != nil || got != tt.want {
+			t.Fatalf("step %d: got %d, %v; want %d", i, got, err, tt.want)
+		}
+	}
+	if n, _ := c.Count(ctx, "other", window); n != 0 {
+		t.Fatalf("unrelated key count = %d", n)
+	}
+}
+
+func TestMemoryCounterMaxEvents(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestCounter()
+	c.maxEvents = 3
+	for i := 0; i < 5; i++ {
+		*now = now.Add(time.Second)
+		c.Add(ctx, "k", time.Hour)
+	}
+	if n, _ := c.Count(ctx, "k", time.Hour); n != 3 {
+		t.Fatalf("count = %d, want 3", n)
+	}
+}
+
+func TestMemoryCounterResetAndSweep(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestCounter()
+	c.Add(ctx, "a", time.Minute)
+	c.Add(ctx, "b", time.Minute)
+	if err := c.Reset(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := c.Count(ctx, "a", time.Minute); n != 0 {
+		t.Fatalf("count after reset = %d", n)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	c.Add(ctx, "c", time.Minute)
+	if _, ok := c.events["b"]; ok {
+		t.Fatal("stale key was not swept")
+	}
+	if _, ok := c.events["c"]; !ok {
+		t.Fatal("fresh key was swept")
+	}
+}
@@ -0,0 +1,211 @@
+package risk
+
+import (
+	"context"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+// Action نوع درخواست ارزیابی‌شده
+type Action string
+
+const (
+	ActionLogin    Action = "login"
+	ActionRegister Action = "register"
+)
+
+// Reason سیگنال‌های ریسک فعال‌شده
+type Reason string
+
+const (
+	ReasonIdentifierFailures Reason = "identifier_failures"
+	ReasonIPFailures         Reason = "ip_failures"
+	ReasonVelocity           Reason = "velocity"
+	ReasonNewDevice          Reason = "new_device"
+	ReasonUnavailable        Reason = "unavailable" // شمارنده در دسترس نبود؛ به صورت محتاطانه کپچا الزامی می‌شود
+)
+
+// Signals اطلاعات درخواست برای ارزیابی ریسک
+type Signals struct {
+	Action     Action
+	Identifier string // شناسه‌ی نرمال‌شده (ایمیل یا موبایل)
+	IP         string
+	NewDevice  bool // دستگاه (DeviceID یا اثر انگشت) قبلاً برای این کاربر دیده نشده است
+}
+
+// Assessment نتیجه‌ی ارزیابی
+type Assessment struct {
+	Score           int // ۰ تا ۱۰۰
+	CaptchaRequired bool
+	PoWDifficulty   int // سختی پیشنهادی اثبات کار متناسب با امتیاز
+	Reasons         []Reason
+}
+
+// Response نتیجه به شکل پاسخ API
+func (a Assessment) Response() model.ChallengeStatusResponse {
+	res := model.ChallengeStatusResponse{CaptchaRequired: a.CaptchaRequired, PoWDifficulty: a.PoWDifficulty}
+	for _, r := range a.Reasons {
+		res.Reasons = append(res.Reasons, string(r))
+	}
+	return res
+}
+
+type Config struct {
+	IdentifierFailures int           // پیش‌فرض: consts.RiskIdentifierFailures
+	IPFailures         int           // پیش‌فرض: consts.RiskIPFailures
+	FailureWindow      time.Duration // پیش‌فرض: consts.RiskFailureWindow
+	IPVelocity         int           // پیش‌فرض: consts.RiskIPVelocity
+	VelocityWindow     time.Duration // پیش‌فرض: consts.RiskVelocityWindow
+	NewDeviceScore     int           // امتیاز دستگاه جدید؛ پیش‌فرض ۳۰ (به تنهایی کپچا را الزامی نمی‌کند)
+	Threshold          int           // پیش‌فرض: consts.RiskThreshold
+}
+
+// Evaluator تصمیم می‌گیرد تلاش ورود/ثبت‌نام باید کپچا (یا اثبات کار) داشته باشد یا نه
+type Evaluator struct {
+	counter Counter
+	cfg     Config
+}
+
+func NewEvaluator(counter Counter, cfg Config) *Evaluator {
+	if cfg.IdentifierFailures <= 0 {
+		cfg.IdentifierFailures = consts.RiskIdentifierFailures
+	}
+	if cfg.IPFailures <= 0 {
+		cfg.IPFailures = consts.RiskIPFailures
+	}
+	if cfg.FailureWindow <= 0 {
+		cfg.FailureWindow = consts.RiskFailureWindow
+	}
+	if cfg.IPVelocity <= 0 {
+		cfg.IPVelocity = consts.RiskIPVelocity
+	}
+	if cfg.VelocityWindow <= 0 {
+		cfg.VelocityWindow = consts.RiskVelocityWindow
+	}
+	if cfg.NewDeviceScore <= 0 {
+		cfg.NewDeviceScore = 30
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = consts.RiskThreshold
+	}
+	return &Evaluator{counter: counter, cfg: cfg}
+}
+
+// Assess امتیاز ریسک را بدون ثبت رویداد محاسبه می‌کند (مثلاً برای endpoint وضعیت چالش)
+func (e *Evaluator) Assess(ctx context.Context, s Signals) (Assessment, error) {
+	var a Assessment
+	if s.Identifier != "" {
+		n, err := e.counter.Count(ctx, failureKey(s.Action, "id", s.Identifier), e.cfg.FailureWindow)
+		if err != nil {
+			return unavailable(), richerror.Wrap(consts.OpRiskAssess, err, consts.ErrRiskAssessFail, consts.CodeInternalError, richerror.KindInternal)
+		}
+		a.add(ReasonIdentifierFailures, ratio(n, e.cfg.IdentifierFailures, e.cfg.Threshold))
+	}
+	if s.IP != "" {
+		n, err := e.counter.Count(ctx, failureKey(s.Action, "ip", s.IP), e.cfg.FailureWindow)
+		if err != nil {
+			return unavailable(), richerror.Wrap(consts.OpRiskAssess, err, consts.ErrRiskAssessFail, consts.CodeInternalError, richerror.KindInternal)
+		}
+		a.add(ReasonIPFailures, ratio(n, e.cfg.IPFailures, e.cfg.Threshold))
+
+		n, err = e.counter.Count(ctx, attemptKey(s.Action, s.IP), e.cfg.VelocityWindow)
+		if err != nil {
+			return unavailable(), richerror.Wrap(consts.OpRiskAssess, err, consts.ErrRiskAssessFail, consts.CodeInternalError, richerror.KindInternal)
+		}
+		// سرعت عادی امتیازی ندارد؛ فقط عبور از حد مجاز آستانه را پر می‌کند
+		if n > e.cfg.IPVelocity {
+			a.add(ReasonVelocity, e.cfg.Threshold)
+		}
+	}
+	if s.NewDevice {
+		a.add(ReasonNewDevice, e.cfg.NewDeviceScore)
+	}
+	a.Score = min(a.Score, 100)
+	a.CaptchaRequired = a.Score >= e.cfg.Threshold
+	if a.CaptchaRequired {
+		// هر ۱۰ امتیاز بالاتر از آستانه یک بیت به سختی اضافه می‌کند
+		a.PoWDifficulty = min(consts.PoWDifficulty+(a.Score-e.cfg.Threshold)/10, consts.PoWMaxDifficulty)
+	}
+	return a, nil
+}
+
+// Check تلاش را ثبت و ارزیابی می‌کند؛ اگر چالش لازم باشد و hasChallenge نادرست باشد
+// خطای CodeCaptchaRequired برمی‌گردد. در صورت خطای شمارنده کپچا الزامی فرض می‌شود.
+// بررسی درستی پاسخ کپچا/اثبات کار با فراخواننده است؛ اثبات کار را با pow.Service.VerifyWithDifficulty و
+// PoWDifficulty همین ارزیابی بررسی کنید.
+func (e *Evaluator) Check(ctx context.Context, s Signals, hasChallenge bool) (Assessment, error) {
+	if s.IP != "" {
+		if _, err := e.counter.Add(ctx, attemptKey(s.Action, s.IP), e.cfg.VelocityWindow); err != nil {
+			return e.require(unavailable(), hasChallenge)
+		}
+	}
+	// در صورت خطا Assess ارزیابی محتاطانه (unavailable) برمی‌گرداند
+	a, _ := e.Assess(ctx, s)
+	return e.require(a, hasChallenge)
+}
+
+// RecordFailure تلاش ناموفق (رمز اشتباه، کپچای نادرست و ...) را برای شناسه و IP ثبت می‌کند
+func (e *Evaluator) RecordFailure(ctx context.Context, s Signals) error {
+	if s.Identifier != "" {
+		if _, err := e.counter.Add(ctx, failureKey(s.Action, "id", s.Identifier), e.cfg.FailureWindow); err != nil {
+			return err
+		}
+	}
+	if s.IP != "" {
+		if _, err := e.counter.Add(ctx, failureKey(s.Action, "ip", s.IP), e.cfg.FailureWindow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordSuccess شمارنده‌ی شکست شناسه را پاک می‌کند؛ شمارنده‌ی IP برای مقابله با credential stuffing باقی می‌ماند
+func (e *Evaluator) RecordSuccess(ctx context.Context, s Signals) error {
+	if s.Identifier == "" {
+		return nil
+	}
+	return e.counter.Reset(ctx, failureKey(s.Action, "id", s.Identifier))
+}
+
+func (e *Evaluator) require(a Assessment, hasChallenge bool) (Assessment, error) {
+	if a.CaptchaRequired && !hasChallenge {
+		return a, richerror.New(consts.OpRiskCheck, consts.ErrCaptchaRequired, consts.CodeCaptchaRequired, richerror.KindForbidden, model.ErrCaptchaRequired)
+	}
+	return a, nil
+}
+
+func (a *Assessment) add(r Reason, score int) {
+	if score <= 0 {
+		return
+	}
+	a.Score += score
+	a.Reasons = append(a.Reasons, r)
+}
+
+func unavailable() Assessment {
+	return Assessment{
+		Score:           100,
+		CaptchaRequired: true,
+		PoWDifficulty:   consts.PoWDifficulty,
+		Reasons:         []Reason{ReasonUnavailable},
+	}
+}
+
+// ratio امتیاز متناسب با n/limit؛ رسیدن به limit به تنهایی آستانه را پر می‌کند
+func ratio(n, limit, threshold int) int {
+	if n <= 0 {
+		return 0
+	}
+	return n * threshold / limit
+}
+
+func failureKey(action Action, kind, value string) string {
+	return "risk:fail:" + string(action) + ":" + kind + ":" + value
+}
+
+func attemptKey(action Action, ip string) string {
+	return "risk:attempt:" + string(action) + ":ip:" + ip
+}
@@ -0,0 +1,173 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+)
+
+type failingCounter struct{}
+
+var errCounter = errors.New("counter down")
+
+func (failingCounter) Add(context.Context, string, time.Duration) (int, error) { return 0, errCounter }
+func (failingCounter) Count(context.Context, string, time.Duration) (int, error) {
+	return 0, errCounter
+}
+func (failingCounter) Reset(context.Context, string) error { return errCounter }
+
+func codeOf(err error) string {
+	var re *richerror.RichError
+	if errors.As(err, &re) {
+		return re.Code
+	}
+	return ""
+}
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name     string
+		signals  Signals
+		failures int
+		score    int
+		captcha  bool
+		pow      int
+		reasons  []Reason
+	}{
+		{"clean", Signals{Identifier: "a@b.ir"}, 0, 0, false, 0, nil},
+		{"new device only", Signals{NewDevice: true}, 0, 30, false, 0, []Reason{ReasonNewDevice}},
+		{"below identifier limit", Signals{Identifier: "a@b.ir"}, 2, 40, false, 0, []Reason{ReasonIdentifierFailures}},
+		{"identifier limit", Signals{Identifier: "a@b.ir"}, 3, 60, true, consts.PoWDifficulty, []Reason{ReasonIdentifierFailures}},
+		{"failures and new device", Signals{Identifier: "a@b.ir", NewDevice: true}, 2, 70, true, consts.PoWDifficulty + 1, []Reason{ReasonIdentifierFailures, ReasonNewDevice}},
+		{"identifier and ip", Signals{Identifier: "a@b.ir", IP: "1.2.3.4"}, 3, 78, true, consts.PoWDifficulty + 1, []Reason{ReasonIdentifierFailures, ReasonIPFailures}},
+		{"capped", Signals{Identifier: "a@b.ir", IP: "1.2.3.4", NewDevice: true}, 6, 100, true, consts.PoWDifficulty + 4, []Reason{ReasonIdentifierFailures, ReasonIPFailures, ReasonNewDevice}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			e := NewEvaluator(NewMemoryCounter(), Config{})
+			tt.signals.Action = ActionLogin
+			for i := 0; i < tt.failures; i++ {
+				if err := e.RecordFailure(ctx, tt.signals); err != nil {
+					t.Fatal(err)
+				}
+			}
+			a, err := e.Assess(ctx, tt.signals)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.Score != tt.score || a.CaptchaRequired != tt.captcha || a.PoWDifficulty != tt.pow || !reflect.DeepEqual(a.Reasons, tt.reasons) {
+				t.Fatalf("got %+v, want score %d captcha %v pow %d reasons %v", a, tt.score, tt.captcha, tt.pow, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestAssessScopedByAction(t *testing.T) {
+	ctx := context.Background()
+	e := NewEvaluator(NewMemoryCounter(), Config{})
+	s := Signals{Action: ActionLogin, Identifier: "a@b.ir"}
+	for i := 0; i < 3; i++ {
+		e.RecordFailure(ctx, s)
+	}
+	s.Action = ActionRegister
+	if a, _ := e.Assess(ctx, s); a.Score != 0 {
+		t.Fatalf("register score = %d, want 0", a.Score)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	s := Signals{Action: ActionLogin, Identifier: "a@b.ir", IP: "1.2.3.4"}
+
+	tests := []struct {
+		name         string
+		failures     int
+		hasChallenge bool
+		wantCode     string
+	}{
+		{"clean", 0, false, ""},
+		{"risky without challenge", 3, false, consts.CodeCaptchaRequired},
+		{"risky with challenge", 3, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEvaluator(NewMemoryCounter(), Config{})
+			for i := 0; i < tt.failures; i++ {
+				e.RecordFailure(ctx, s)
+			}
+			_, err := e.Check(ctx, s, tt.hasChallenge)
+			if codeOf(err) != tt.wantCode {
+				t.Fatalf("code = %q, want %q (err %v)", codeOf(err), tt.wantCode, err)
+			}
+			if tt.wantCode != "" && !errors.Is(err, model.ErrCaptchaRequired) {
+				t.Fatalf("err = %v, want ErrCaptchaRequired", err)
+			}
+		})
+	}
+}
+
+func TestCheckVelocity(t *testing.T) {
+	ctx := context.Background()
+	e := NewEvaluator(NewMemoryCounter(), Config{IPVelocity: 3})
+	s := Signals{Action: ActionRegister, IP: "1.2.3.4"}
+	for i := 1; i <= 4; i++ {
+		a, err := e.Check(ctx, s, false)
+		if i <= 3 && err != nil {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+		if i == 4 && (codeOf(err) != consts.CodeCaptchaRequired || !reflect.DeepEqual(a.Reasons, []Reason{ReasonVelocity})) {
+			t.Fatalf("attempt %d: %+v, %v", i, a, err)
+		}
+	}
+}
+
+func TestRecordSuccess(t *testing.T) {
+	ctx := context.Background()
+	e := NewEvaluator(NewMemoryCounter(), Config{})
+	s := Signals{Action: ActionLogin, Identifier: "a@b.ir", IP: "1.2.3.4"}
+	for i := 0; i < 3; i++ {
+		e.RecordFailure(ctx, s)
+	}
+	if err := e.RecordSuccess(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := e.Assess(ctx, s)
+	if !reflect.DeepEqual(a.Reasons, []Reason{ReasonIPFailures}) {
+		t.Fatalf("reasons = %v, want only ip failures", a.Reasons)
+	}
+}
+
+func TestCounterUnavailable(t *testing.T) {
+	ctx := context.Background()
+	e := NewEvaluator(failingCounter{}, Config{})
+	s := Signals{Action: ActionLogin, Identifier: "a@b.ir", IP: "1.2.3.4"}
+
+	a, err := e.Assess(ctx, s)
+	if !errors.Is(err, errCounter) || !a.CaptchaRequired || a.Reasons[0] != ReasonUnavailable {
+		t.Fatalf("Assess = %+v, %v", a, err)
+	}
+	if _, err := e.Check(ctx, s, false); codeOf(err) != consts.CodeCaptchaRequired {
+		t.Fatalf("Check without challenge err = %v", err)
+	}
+	if _, err := e.Check(ctx, s, true); err != nil {
+		t.Fatalf("Check with challenge err = %v", err)
+	}
+	if err := e.RecordFailure(ctx, s); !errors.Is(err, errCounter) {
+		t.Fatalf("RecordFailure err = %v", err)
+	}
+}
+
+func TestAssessmentResponse(t *testing.T) {
+	a := Assessment{Score: 70, CaptchaRequired: true, PoWDifficulty: 19, Reasons: []Reason{ReasonVelocity, ReasonNewDevice}}
+	res := a.Response()
+	if !res.CaptchaRequired || res.PoWDifficulty != 19 || !reflect.DeepEqual(res.Reasons, []string{"velocity", "new_device"}) {
+		t.Fatalf("Response = %+v", res)
+	}
+}
@@ -0,0 +1,121 @@
+package textnorm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// InstallGinBinding نرمال‌سازی را به binding پیش‌فرض gin اضافه می‌کند؛ یک بار هنگام راه‌اندازی سرویس صدا زده شود:
+//   - اعداد JSON نوشته‌شده با ارقام فارسی (مثلاً "amount": ۱۲٫۵) قبل از decode به ارقام لاتین تبدیل می‌شوند
+//   - فیلدهای دارای تگ normalize پیش از اعتبارسنجی (برای JSON، فرم و query) نرمال می‌شوند
+func InstallGinBinding() {
+	if _, ok := binding.Validator.(*validator); !ok {
+		binding.Validator = &validator{inner: binding.Validator}
+	}
+	if _, ok := binding.JSON.(jsonBinding); !ok {
+		binding.JSON = jsonBinding{inner: binding.JSON}
+	}
+}
+
+// validator پیش از اعتبارسنجی، فیلدهای تگ‌دار را نرمال می‌کند
+type validator struct {
+	inner binding.StructValidator
+}
+
+func (v *validator) ValidateStruct(obj any) error {
+	Struct(obj)
+	return v.inner.ValidateStruct(obj)
+}
+
+func (v *validator) Engine() any {
+	return v.inner.Engine()
+}
+
+type jsonBinding struct {
+	inner binding.BindingBody
+}
+
+func (jsonBinding) Name() string { return "json" }
+
+func (b jsonBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return b.BindBody(body, obj)
+}
+
+func (b jsonBinding) BindBody(body []byte, obj any) error {
+	return b.inner.BindBody(JSONNumbers(body), obj)
+}
+
+// JSONNumbers ارقام فارسی/عربی، ممیز فارسی و جداکننده‌ی هزارگان (٬) اعداد خارج از رشته‌های JSON را با Number
+// نرمال می‌کند؛ گروه‌بندی نامعتبر (مثلاً ۱٬۵) دست نمی‌خورد تا decode خطا بدهد. محتوای رشته‌ها (مثلاً رمز عبور) دست نمی‌خورد.
+func JSONNumbers(body []byte) []byte {
+	if !hasNonASCII(body) {
+		return body
+	}
+	var out, num bytes.Buffer
+	out.Grow(len(body))
+	flush := func() {
+		if num.Len() > 0 {
+			out.WriteString(Number(num.String()))
+			num.Reset()
+		}
+	}
+	inString, escaped := false, false
+	for i := 0; i < len(body); {
+		r, size := utf8.DecodeRune(body[i:])
+		i += size
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+			out.WriteRune(r)
+			continue
+		}
+		if isNumberRune(r) {
+			num.WriteRune(r)
+			continue
+		}
+		flush()
+		if r == '"' {
+			inString = true
+		}
+		out.WriteRune(r)
+	}
+	flush()
+	return out.Bytes()
+}
+
+// isNumberRune نویسه‌های یک عدد JSON به همراه ارقام فارسی/عربی، ٫ و ٬
+func isNumberRune(r rune) bool {
+	switch r {
+	case '.', '+', '-', 'e', 'E', '٫', '٬':
+		return true
+	}
+	d := digit(r)
+	return d >= '0' && d <= '9'
+}
+
+func hasNonASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package textnorm
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TagName تگ فیلدهای رشته‌ای که باید نرمال شوند، مثلاً `normalize:"digits"` یا `normalize:"text"`
+const TagName = "normalize"
+
+var tagOps = map[string]Op{
+	"digits":    OpDigits,
+	"letters":   OpLetters,
+	"zerowidth": OpZeroWidth,
+	"space":     OpSpace,
+	"number":    OpNumber,
+	"text":      OpText,
+}
+
+// ParseTag مقدار تگ (با جداکننده‌ی ,) را به Op تبدیل می‌کند؛ نام‌های ناشناخته نادیده گرفته می‌شوند
+func ParseTag(tag string) Op {
+	var op Op
+	for _, p := range strings.Split(tag, ",") {
+		op |= tagOps[strings.TrimSpace(p)]
+	}
+	return op
+}
+
+// Struct فیلدهای string و *string دارای تگ normalize را (به صورت بازگشتی در struct، slice و map تو در تو) نرمال می‌کند
+func Struct(obj interface{}) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	walk(v.Elem())
+}
+
+func walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem())
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			f := v.Field(i)
+			if op := ParseTag(sf.Tag.Get(TagName)); op != 0 {
+				apply(f, op)
+				continue
+			}
+			walk(f)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i))
+		}
+	case reflect.Map:
+		// مقدار map قابل آدرس‌دهی نیست؛ روی کپی نرمال و دوباره در map نوشته می‌شود
+		updateMap(v, walk)
+	}
+}
+
+// updateMap fn را روی کپی قابل تغییر هر مقدار map اجرا و نتیجه را جایگزین می‌کند
+func updateMap(m reflect.Value, fn func(reflect.Value)) {
+	if m.IsNil() {
+		return
+	}
+	iter := m.MapRange()
+	for iter.Next() {
+		e := reflect.New(m.Type().Elem()).Elem()
+		e.Set(iter.Value())
+		fn(e)
+		m.SetMapIndex(iter.Key(), e)
+	}
+}
+
+func apply(f reflect.Value, op Op) {
+	switch f.Kind() {
+	case reflect.String:
+		if f.CanSet() {
+			f.SetString(Apply(f.String(), op))
+		}
+	case reflect.Ptr:
+		if !f.IsNil() && f.Elem().Kind() == reflect.String && f.Elem().CanSet() {
+			f.Elem().SetString(Apply(f.Elem().String(), op))
+		}
+	case reflect.Slice:
+		for i := 0; i < f.Len(); i++ {
+			apply(f.Index(i), op)
+		}
+	case reflect.Map:
+		// فقط مقدارها نرمال می‌شوند؛ کلیدها دست نمی‌خورند
+		updateMap(f, func(e reflect.Value) { apply(e, op) })
+	}
+}
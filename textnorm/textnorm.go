@@ -0,0 +1,175 @@
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Op مجموعه‌ی نرمال‌سازی‌ها (قابل ترکیب با |)
+type Op uint8
+
+const (
+	OpDigits    Op = 1 << iota // ارقام فارسی و عربی-هندی به لاتین
+	OpLetters                  // ي/ى عربی به ی فارسی، ك به ک، حذف کشیده (ـ)
+	OpZeroWidth                // حذف نویسه‌های zero-width و نیم‌فاصله‌های اضافی
+	OpSpace                    // تبدیل همه‌ی فاصله‌ها به space و حذف فاصله‌های تکراری و ابتدا/انتها
+	OpNumber                   // OpDigits به همراه ٫ به . و حذف جداکننده‌ی هزارگانِ گروه‌های سه‌رقمی (٬ و ,)
+
+	OpText = OpDigits | OpLetters | OpZeroWidth | OpSpace
+)
+
+const (
+	zwnj = '\u200c' // نیم‌فاصله
+	zwj  = '\u200d'
+)
+
+// Apply نرمال‌سازی‌های op را روی s اعمال می‌کند
+func Apply(s string, op Op) string {
+	if op&OpNumber != 0 {
+		s = Number(s)
+	} else if op&OpDigits != 0 {
+		s = Digits(s)
+	}
+	if op&OpLetters != 0 {
+		s = Letters(s)
+	}
+	if op&OpZeroWidth != 0 {
+		s = ZeroWidth(s)
+	}
+	if op&OpSpace != 0 {
+		s = Space(s)
+	}
+	return s
+}
+
+// Text همه‌ی نرمال‌سازی‌های متنی (ارقام، حروف، zero-width و فاصله)
+func Text(s string) string {
+	return Apply(s, OpText)
+}
+
+// Digits ارقام فارسی (۰-۹) و عربی-هندی (٠-٩) را به ارقام لاتین تبدیل می‌کند
+func Digits(s string) string {
+	return strings.Map(digit, s)
+}
+
+// Number برای مقادیر عددی (مبلغ، مقدار): ارقام لاتین، ممیز فارسی به نقطه و حذف جداکننده‌ی هزارگان
+// (٬ ، , فاصله و نیم‌فاصله). جداکننده فقط وقتی حذف می‌شود که بخش صحیح را به گروه‌های سه‌رقمی تقسیم کند؛
+// در غیر این صورت (مثلاً "1,5" به معنای ۱٫۵) دست نمی‌خورد تا تجزیه‌ی عدد خطا بدهد و ۱۵ خوانده نشود.
+func Number(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '٫' {
+			return '.'
+		}
+		return digit(r)
+	}, strings.TrimSpace(s))
+	if n, ok := ungroup(s); ok {
+		return n
+	}
+	return s
+}
+
+func isGroupSep(r rune) bool {
+	switch r {
+	case '٬', ',', ' ', zwnj:
+		return true
+	}
+	return false
+}
+
+// ungroup جداکننده‌های هزارگان s را حذف می‌کند؛ اگر گروه‌بندی نامعتبر باشد ok نادرست است
+func ungroup(s string) (string, bool) {
+	if !strings.ContainsFunc(s, isGroupSep) {
+		return s, true
+	}
+	sign := ""
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		sign, s = s[:1], s[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	if strings.ContainsFunc(frac, isGroupSep) {
+		return "", false
+	}
+	var groups []string
+	start := 0
+	for i, r := range intPart {
+		if isGroupSep(r) {
+			groups = append(groups, intPart[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	groups = append(groups, intPart[start:])
+	for i, g := range groups {
+		if !isDigits(g) || (i == 0 && len(g) > 3) || (i > 0 && len(g) != 3) {
+			return "", false
+		}
+	}
+	n := sign + strings.Join(groups, "")
+	if hasFrac {
+		n += "." + frac
+	}
+	return n, true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Letters حروف عربی رایج را به معادل فارسی تبدیل می‌کند
+func Letters(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case 'ي', 'ى':
+			return 'ی'
+		case 'ك':
+			return 'ک'
+		case 'ـ':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// ZeroWidth نویسه‌های نامرئی را حذف می‌کند؛ نیم‌فاصله فقط بین دو حرف نگه داشته می‌شود
+func ZeroWidth(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for i, r := range rs {
+		switch r {
+		case '\u200b', zwj, '\u2060', '\ufeff', '\u200e', '\u200f':
+			continue
+		case zwnj:
+			prevLetter := i > 0 && unicode.IsLetter(rs[i-1])
+			nextLetter := i+1 < len(rs) && unicode.IsLetter(rs[i+1])
+			if !prevLetter || !nextLetter {
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Space فاصله‌های یونیکد را به space تبدیل، تکرارها را یکی و ابتدا/انتها را حذف می‌کند
+func Space(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
+
+func digit(r rune) rune {
+	switch {
+	case r >= '۰' && r <= '۹':
+		return '0' + (r - '۰')
+	case r >= '٠' && r <= '٩':
+		return '0' + (r - '٠')
+	}
+	return r
+}
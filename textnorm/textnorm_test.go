@@ -0,0 +1,174 @@
+package textnorm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		op   Op
+		want string
+	}{
+		{"persian digits", "۰۹۱۲۱۲۳۴۵۶۷", OpDigits, "09121234567"},
+		{"arabic digits", "٠١٢٣", OpDigits, "0123"},
+		{"number", " ۱٬۲۰۰٫۵ ", OpNumber, "1200.5"},
+		{"number latin separators", "1,200.5", OpNumber, "1200.5"},
+		{"letters", "علي كريمي", OpLetters, "علی کریمی"},
+		{"kashida", "سـلام", OpLetters, "سلام"},
+		{"zero width", "​سلام‌", OpZeroWidth, "سلام"},
+		{"zwnj between letters kept", "می‌روم", OpZeroWidth, "می‌روم"},
+		{"space", "  a \t\n b  ", OpSpace, "a b"},
+		{"text", " علي​  ۱۲ ", OpText, "علی 12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Apply(tt.in, tt.op); got != tt.want {
+				t.Fatalf("Apply(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// جداکننده فقط بین گروه‌های سه‌رقمی حذف می‌شود؛ "1,5" نباید ۱۵ شود
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"12.5", "12.5"},
+		{"۱۲٫۵", "12.5"},
+		{"1,000", "1000"},
+		{"1,234,567.89", "1234567.89"},
+		{"۱٬۲۳۴٬۵۶۷", "1234567"},
+		{"-1 000", "-1000"},
+		{" 12,345 ", "12345"},
+		{"1,5", "1,5"},
+		{"1,50", "1,50"},
+		{"1,5000", "1,5000"},
+		{"1234,567", "1234,567"},
+		{"1,,000", "1,,000"},
+		{",100", ",100"},
+		{"100,", "100,"},
+		{"1.000,5", "1.000,5"},
+		{"۱٬۵", "1٬5"},
+	}
+	for _, tt := range tests {
+		if got := Number(tt.in); got != tt.want {
+			t.Errorf("Number(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Op
+	}{
+		{"", 0},
+		{"digits", OpDigits},
+		{"digits, space", OpDigits | OpSpace},
+		{"text", OpText},
+		{"unknown", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseTag(tt.tag); got != tt.want {
+			t.Errorf("ParseTag(%q) = %b, want %b", tt.tag, got, tt.want)
+		}
+	}
+}
+
+type inner struct {
+	Code string `normalize:"digits"`
+}
+
+type sample struct {
+	Name     string            `normalize:"text"`
+	Phone    *string           `normalize:"digits"`
+	Tags     []string          `normalize:"digits"`
+	Labels   map[string]string `normalize:"digits"`
+	Raw      string
+	Inner    inner
+	Items    []inner
+	ByKey    map[string]inner
+	ByKeyPtr map[string]*inner
+	Nested   map[string][]inner
+	private  string `normalize:"digits"`
+}
+
+func TestStruct(t *testing.T) {
+	phone := "۰۹۱۲"
+	s := sample{
+		Name:     " علي ",
+		Phone:    &phone,
+		Tags:     []string{"۱", "۲"},
+		Labels:   map[string]string{"۱": "۳"},
+		Raw:      "۱",
+		Inner:    inner{Code: "۴"},
+		Items:    []inner{{Code: "۵"}},
+		ByKey:    map[string]inner{"a": {Code: "۶"}},
+		ByKeyPtr: map[string]*inner{"a": {Code: "۷"}, "nil": nil},
+		Nested:   map[string][]inner{"a": {{Code: "۸"}}},
+		private:  "۹",
+	}
+	Struct(&s)
+
+	tests := []struct {
+		name, got, want string
+	}{
+		{"tagged text", s.Name, "علی"},
+		{"tagged pointer", *s.Phone, "0912"},
+		{"tagged slice", s.Tags[0] + s.Tags[1], "12"},
+		{"tagged map value", s.Labels["۱"], "3"},
+		{"untagged untouched", s.Raw, "۱"},
+		{"nested struct", s.Inner.Code, "4"},
+		{"slice of struct", s.Items[0].Code, "5"},
+		{"map of struct", s.ByKey["a"].Code, "6"},
+		{"map of pointer", s.ByKeyPtr["a"].Code, "7"},
+		{"map of slice", s.Nested["a"][0].Code, "8"},
+		{"unexported untouched", s.private, "۹"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+	if _, ok := s.Labels["۱"]; !ok {
+		t.Error("map keys must not be normalized")
+	}
+	Struct(nil)
+	Struct(s) // غیر اشاره‌گر نادیده گرفته می‌شود
+}
+
+func TestJSONNumbers(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`{"a":1}`, `{"a":1}`},
+		{`{"amount":۱۲٫۵}`, `{"amount":12.5}`},
+		{`{"amount":۱٬۲۰۰}`, `{"amount":1200}`},
+		{`{"password":"۱۲٫۵","n":۳}`, `{"password":"۱۲٫۵","n":3}`},
+		{`{"s":"a\"۱","n":۲}`, `{"s":"a\"۱","n":2}`},
+		{`{"a":[۱,۲],"b":true}`, `{"a":[1,2],"b":true}`},
+		{`{"amount":-۱٬۲۰۰٫۵e۲}`, `{"amount":-1200.5e2}`},
+	}
+	for _, tt := range tests {
+		got := string(JSONNumbers([]byte(tt.in)))
+		if got != tt.want {
+			t.Errorf("JSONNumbers(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+		if !json.Valid([]byte(got)) {
+			t.Errorf("JSONNumbers(%s) produced invalid JSON", tt.in)
+		}
+	}
+}
+
+// گروه‌بندی نامعتبر باید در decode خطا بدهد، نه اینکه جداکننده حذف شود
+func TestJSONNumbersRejectsBadGrouping(t *testing.T) {
+	for _, in := range []string{`{"amount":۱٬۵}`, `{"amount":۱۲٬۳۴}`} {
+		if got := JSONNumbers([]byte(in)); json.Valid(got) {
+			t.Errorf("JSONNumbers(%s) = %s, want invalid JSON", in, got)
+		}
+	}
+}
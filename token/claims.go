@@ -0,0 +1,53 @@
+package token
+
+import (
+	"time"
+)
+
+// انواع توکن (ادعای typ)
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
+// Claims ادعاهای توکن؛ فیلدهای استاندارد RFC 7519 به همراه sid، scopes و typ
+type Claims struct {
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub"` // شناسه کاربر
+	Audience  string   `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat"`
+	ID        string   `json:"jti"`
+	SessionID string   `json:"sid,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	Type      string   `json:"typ"`
+	FamilyID  string   `json:"fam,omitempty"` // خانواده‌ی توکن refresh (برای تشخیص استفاده‌ی مجدد)
+
+	// Custom ادعاهای اختصاصی سرویس (مثلاً tier یا role)
+	Custom map[string]interface{} `json:"ext,omitempty"`
+}
+
+// HasScope بررسی وجود یک scope در توکن
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Expiry زمان انقضای توکن
+func (c *Claims) Expiry() time.Time {
+	return time.Unix(c.ExpiresAt, 0).UTC()
+}
+
+// Session اطلاعات لازم برای صدور توکن‌های یک نشست
+type Session struct {
+	UserID    string
+	SessionID string
+	FamilyID  string
+	Scopes    []string
+	Custom    map[string]interface{}
+}
@@ -0,0 +1,162 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+// الگوریتم‌های امضای پشتیبانی‌شده
+const (
+	AlgHS256 = "HS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// حداقل طول کلید HMAC (بر اساس RFC 7518، به اندازه‌ی خروجی هش)
+const minHMACKeySize = 32
+
+// Key کلید امضا/اعتبارسنجی با شناسه (kid)
+type Key struct {
+	ID        string
+	Algorithm string
+
+	secret  []byte
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+// NewHS256Key کلید متقارن HMAC-SHA256
+func NewHS256Key(kid string, secret []byte) (*Key, error) {
+	if kid == "" || len(secret) < minHMACKeySize {
+		return nil, model.ErrTokenKeyInvalid
+	}
+	return &Key{ID: kid, Algorithm: AlgHS256, secret: append([]byte(nil), secret...)}, nil
+}
+
+// NewEdDSAKey کلید خصوصی Ed25519 (امضا و اعتبارسنجی)
+func NewEdDSAKey(kid string, private ed25519.PrivateKey) (*Key, error) {
+	if kid == "" || len(private) != ed25519.PrivateKeySize {
+		return nil, model.ErrTokenKeyInvalid
+	}
+	return &Key{
+		ID:        kid,
+		Algorithm: AlgEdDSA,
+		private:   private,
+		public:    private.Public().(ed25519.PublicKey),
+	}, nil
+}
+
+// NewEdDSAVerifyKey کلید عمومی Ed25519 (فقط اعتبارسنجی؛ برای سرویس‌هایی که توکن صادر نمی‌کنند)
+func NewEdDSAVerifyKey(kid string, public ed25519.PublicKey) (*Key, error) {
+	if kid == "" || len(public) != ed25519.PublicKeySize {
+		return nil, model.ErrTokenKeyInvalid
+	}
+	return &Key{ID: kid, Algorithm: AlgEdDSA, public: public}, nil
+}
+
+// CanSign کلید قابلیت امضا دارد یا فقط اعتبارسنجی
+func (k *Key) CanSign() bool {
+	switch k.Algorithm {
+	case AlgHS256:
+		return len(k.secret) > 0
+	case AlgEdDSA:
+		return len(k.private) > 0
+	}
+	return false
+}
+
+func (k *Key) sign(input []byte) ([]byte, error) {
+	switch k.Algorithm {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case AlgEdDSA:
+		if len(k.private) == 0 {
+			return nil, model.ErrTokenKeyInvalid
+		}
+		return ed25519.Sign(k.private, input), nil
+	}
+	return nil, model.ErrTokenKeyInvalid
+}
+
+func (k *Key) verify(input, sig []byte) bool {
+	switch k.Algorithm {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(input)
+		return hmac.Equal(mac.Sum(nil), sig)
+	case AlgEdDSA:
+		return ed25519.Verify(k.public, input, sig)
+	}
+	return false
+}
+
+// KeySet مجموعه‌ی کلیدها با یک کلید فعال برای امضا.
+// چرخش کلید: کلید جدید با Rotate فعال می‌شود و کلیدهای قبلی تا حذف صریح
+// (پس از گذشت RefreshTTL) برای اعتبارسنجی توکن‌های قدیمی باقی می‌مانند.
+type KeySet struct {
+	mu     sync.RWMutex
+	active string
+	keys   map[string]*Key
+}
+
+// NewKeySet مجموعه‌ی کلید می‌سازد؛ active می‌تواند nil باشد (سرویس‌های فقط-اعتبارسنج)
+func NewKeySet(active *Key, others ...*Key) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key)}
+	for _, k := range others {
+		ks.keys[k.ID] = k
+	}
+	if active == nil {
+		return ks, nil
+	}
+	if err := ks.Rotate(active); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Rotate کلید جدید را اضافه و به عنوان کلید امضا فعال می‌کند
+func (ks *KeySet) Rotate(k *Key) error {
+	if k == nil || !k.CanSign() {
+		return model.ErrTokenKeyInvalid
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.keys[k.ID] = k
+	ks.active = k.ID
+	return nil
+}
+
+// Add کلید فقط-اعتبارسنجی اضافه می‌کند (مثلاً کلید عمومی سرویس صادرکننده)
+func (ks *KeySet) Add(k *Key) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.keys[k.ID] = k
+}
+
+// Remove کلید بازنشسته را حذف می‌کند؛ کلید فعال قابل حذف نیست
+func (ks *KeySet) Remove(kid string) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if kid != ks.active {
+		delete(ks.keys, kid)
+	}
+}
+
+func (ks *KeySet) signingKey() (*Key, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	k, ok := ks.keys[ks.active]
+	return k, ok
+}
+
+func (ks *KeySet) lookup(kid string) (*Key, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	k, ok := ks.keys[kid]
+	return k, ok
+}
@@ -0,0 +1,233 @@
+package token
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/alisiahmansouri/exchange-common/util"
+)
+
+const (
+	DefaultAccessTTL  = 15 * time.Minute
+	DefaultRefreshTTL = 30 * 24 * time.Hour
+	DefaultClockSkew  = 30 * time.Second
+)
+
+type Config struct {
+	Issuer     string
+	Audience   string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	ClockSkew  time.Duration // تحمل اختلاف ساعت بین سرویس‌ها در بررسی exp/nbf/iat
+	Now        func() time.Time
+}
+
+// Manager صدور و اعتبارسنجی توکن‌های access و refresh
+type Manager struct {
+	keys *KeySet
+	cfg  Config
+}
+
+func NewManager(keys *KeySet, cfg Config) *Manager {
+	if cfg.AccessTTL <= 0 {
+		cfg.AccessTTL = DefaultAccessTTL
+	}
+	if cfg.RefreshTTL <= 0 {
+		cfg.RefreshTTL = DefaultRefreshTTL
+	}
+	if cfg.ClockSkew < 0 {
+		cfg.ClockSkew = 0
+	} else if cfg.ClockSkew == 0 {
+		cfg.ClockSkew = DefaultClockSkew
+	}
+	if cfg.Now == nil {
+		cfg.Now = util.NowUTC
+	}
+	return &Manager{keys: keys, cfg: cfg}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// Issue جفت توکن access/refresh برای یک نشست صادر می‌کند
+func (m *Manager) Issue(s Session) (model.TokenResponse, error) {
+	access, _, err := m.IssueAccess(s)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+	refresh, _, err := m.IssueRefresh(s)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+	return model.TokenResponse{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+// IssueAccess توکن access صادر می‌کند
+func (m *Manager) IssueAccess(s Session) (string, *Claims, error) {
+	return m.issue(s, TypeAccess, m.cfg.AccessTTL)
+}
+
+// IssueRefresh توکن refresh صادر می‌کند
+func (m *Manager) IssueRefresh(s Session) (string, *Claims, error) {
+	return m.issue(s, TypeRefresh, m.cfg.RefreshTTL)
+}
+
+func (m *Manager) issue(s Session, typ string, ttl time.Duration) (string, *Claims, error) {
+	if s.UserID == "" {
+		return "", nil, richerror.New(consts.OpTokenIssue, consts.ErrAuthInvalidUserID, consts.CodeInvalidUserID, richerror.KindInvalid, model.ErrTokenClaimsInvalid)
+	}
+	now := m.cfg.Now()
+	c := &Claims{
+		Issuer:    m.cfg.Issuer,
+		Subject:   s.UserID,
+		Audience:  m.cfg.Audience,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		ID:        util.GenerateUUID(),
+		SessionID: s.SessionID,
+		FamilyID:  s.FamilyID,
+		Scopes:    s.Scopes,
+		Type:      typ,
+		Custom:    s.Custom,
+	}
+	tok, err := m.Sign(c)
+	if err != nil {
+		return "", nil, err
+	}
+	return tok, c, nil
+}
+
+// Sign ادعاهای داده‌شده را با کلید فعال امضا می‌کند
+func (m *Manager) Sign(c *Claims) (string, error) {
+	key, ok := m.keys.signingKey()
+	if !ok {
+		return "", richerror.New(consts.OpTokenIssue, consts.ErrAuthTokenGenFail, consts.CodeAuthTokenGenFail, richerror.KindInternal, model.ErrTokenKeyInvalid)
+	}
+	h, err := json.Marshal(header{Alg: key.Algorithm, Typ: "JWT", Kid: key.ID})
+	if err != nil {
+		return "", richerror.Wrap(consts.OpTokenIssue, err, consts.ErrAuthTokenGenFail, consts.CodeAuthTokenGenFail, richerror.KindInternal)
+	}
+	p, err := json.Marshal(c)
+	if err != nil {
+		return "", richerror.Wrap(consts.OpTokenIssue, err, consts.ErrAuthTokenGenFail, consts.CodeAuthTokenGenFail, richerror.KindInternal)
+	}
+	signingInput := encode(h) + "." + encode(p)
+	sig, err := key.sign([]byte(signingInput))
+	if err != nil {
+		return "", richerror.Wrap(consts.OpTokenIssue, err, consts.ErrAuthTokenGenFail, consts.CodeAuthTokenGenFail, richerror.KindInternal)
+	}
+	return signingInput + "." + encode(sig), nil
+}
+
+// VerifyAccess توکن access را اعتبارسنجی می‌کند
+func (m *Manager) VerifyAccess(tok string) (*Claims, error) {
+	c, err := m.Verify(tok)
+	if err != nil {
+		return nil, err
+	}
+	if c.Type != TypeAccess {
+		return nil, m.invalid(model.ErrTokenTypeMismatch)
+	}
+	return c, nil
+}
+
+// VerifyRefresh توکن refresh را اعتبارسنجی می‌کند؛ همه‌ی خطاها با کد INVALID_REFRESH_TOKEN برمی‌گردند
+func (m *Manager) VerifyRefresh(tok string) (*Claims, error) {
+	c, err := m.Verify(tok)
+	if err == nil && c.Type != TypeRefresh {
+		err = model.ErrTokenTypeMismatch
+	}
+	if err != nil {
+		var re *richerror.RichError
+		if errors.As(err, &re) && re.Code == consts.CodeTokenEmpty {
+			return nil, err
+		}
+		return nil, richerror.Wrap(consts.OpTokenVerify, err, consts.ErrAuthInvalidRefreshToken, consts.CodeInvalidRefreshToken, richerror.KindUnauthorized)
+	}
+	return c, nil
+}
+
+// Verify امضا، الگوریتم، issuer/audience و زمان‌های توکن را بررسی می‌کند (بدون بررسی typ)
+func (m *Manager) Verify(tok string) (*Claims, error) {
+	tok = strings.TrimSpace(tok)
+	if tok == "" {
+		return nil, richerror.New(consts.OpTokenVerify, consts.ErrAuthEmptyToken, consts.CodeTokenEmpty, richerror.KindUnauthorized, nil)
+	}
+	parts := strings.Split(tok, ".")
+	if len(parts) != 3 {
+		return nil, m.invalid(model.ErrTokenMalformed)
+	}
+
+	var h header
+	if err := decodeJSON(parts[0], &h); err != nil {
+		return nil, m.invalid(model.ErrTokenMalformed)
+	}
+	key, ok := m.keys.lookup(h.Kid)
+	if !ok {
+		return nil, m.invalid(model.ErrTokenKeyUnknown)
+	}
+	// الگوریتم از کلید تعیین می‌شود نه از هدر (جلوگیری از alg confusion و alg=none)
+	if h.Alg != key.Algorithm {
+		return nil, m.invalid(model.ErrTokenSignature)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !key.verify([]byte(parts[0]+"."+parts[1]), sig) {
+		return nil, m.invalid(model.ErrTokenSignature)
+	}
+
+	var c Claims
+	if err := decodeJSON(parts[1], &c); err != nil {
+		return nil, m.invalid(model.ErrTokenMalformed)
+	}
+	if c.Subject == "" || c.ExpiresAt == 0 {
+		return nil, m.invalid(model.ErrTokenClaimsInvalid)
+	}
+	if m.cfg.Issuer != "" && c.Issuer != m.cfg.Issuer {
+		return nil, m.invalid(model.ErrTokenClaimsInvalid)
+	}
+	if m.cfg.Audience != "" && c.Audience != m.cfg.Audience {
+		return nil, m.invalid(model.ErrTokenClaimsInvalid)
+	}
+
+	now := m.cfg.Now()
+	skew := m.cfg.ClockSkew
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(skew)) {
+		return nil, richerror.New(consts.OpTokenVerify, consts.ErrAuthTokenExpired, consts.CodeTokenExpired, richerror.KindUnauthorized, model.ErrTokenExpired)
+	}
+	if c.NotBefore != 0 && now.Add(skew).Before(time.Unix(c.NotBefore, 0)) {
+		return nil, m.invalid(model.ErrTokenNotYetValid)
+	}
+	if c.IssuedAt != 0 && now.Add(skew).Before(time.Unix(c.IssuedAt, 0)) {
+		return nil, m.invalid(model.ErrTokenNotYetValid)
+	}
+	return &c, nil
+}
+
+func (m *Manager) invalid(err error) error {
+	return richerror.New(consts.OpTokenVerify, consts.ErrAuthTokenInvalid, consts.CodeTokenInvalid, richerror.KindUnauthorized, err)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeJSON(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
@@ -0,0 +1,277 @@
+package token
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var testSecret = bytes.Repeat([]byte("k"), 32)
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestManager(t *testing.T, cfg Config, keys ...*Key) (*Manager, *clock) {
+	t.Helper()
+	if len(keys) == 0 {
+		k, err := NewHS256Key("hs-1", testSecret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = []*Key{k}
+	}
+	ks, err := NewKeySet(keys[0], keys[1:]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &clock{now: time.Unix(1_700_000_000, 0).UTC()}
+	cfg.Now = c.Now
+	return NewManager(ks, cfg), c
+}
+
+func codeOf(err error) string {
+	var re *richerror.RichError
+	if errors.As(err, &re) {
+		return re.Code
+	}
+	return ""
+}
+
+func TestNewKeys(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(nil)
+	tests := []struct {
+		name    string
+		make    func() (*Key, error)
+		canSign bool
+	}{
+		{"hs256", func() (*Key, error) { return NewHS256Key("a", testSecret) }, true},
+		{"hs256 short secret", func() (*Key, error) { return NewHS256Key("a", testSecret[:31]) }, false},
+		{"hs256 empty kid", func() (*Key, error) { return NewHS256Key("", testSecret) }, false},
+		{"eddsa", func() (*Key, error) { return NewEdDSAKey("b", priv) }, true},
+		{"eddsa bad key", func() (*Key, error) { return NewEdDSAKey("b", priv[:10]) }, false},
+		{"eddsa verify only", func() (*Key, error) { return NewEdDSAVerifyKey("c", priv.Public().(ed25519.PublicKey)) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := tt.make()
+			if err != nil {
+				if !errors.Is(err, model.ErrTokenKeyInvalid) || tt.canSign {
+					t.Fatalf("err = %v", err)
+				}
+				return
+			}
+			if k.CanSign() != tt.canSign {
+				t.Fatalf("CanSign = %v, want %v", k.CanSign(), tt.canSign)
+			}
+		})
+	}
+}
+
+func TestManagerVerify(t *testing.T) {
+	m, c := newTestManager(t, Config{Issuer: "auth", Audience: "exchange", ClockSkew: -1})
+	access, _, err := m.IssueAccess(Session{UserID: "u1", Scopes: []string{"trade"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refresh, _, _ := m.IssueRefresh(Session{UserID: "u1"})
+
+	otherIssuer, _ := newTestManager(t, Config{Issuer: "other", Audience: "exchange"})
+	foreign, _, _ := otherIssuer.IssueAccess(Session{UserID: "u1"})
+	k2, _ := NewHS256Key("hs-2", bytes.Repeat([]byte("x"), 32))
+	unknownKid, _ := newTestManager(t, Config{Issuer: "auth", Audience: "exchange"}, k2)
+	unknown, _, _ := unknownKid.IssueAccess(Session{UserID: "u1"})
+
+	parts := strings.Split(access, ".")
+	noneHeader := encode([]byte(`{"alg":"none","typ":"JWT","kid":"hs-1"}`))
+	tampered := parts[0] + "." + encode([]byte(`{"sub":"admin","exp":9999999999,"typ":"access"}`)) + "." + parts[2]
+
+	tests := []struct {
+		name    string
+		tok     string
+		advance time.Duration
+		code    string
+		wantErr error
+	}{
+		{"valid", access, 0, "", nil},
+		{"empty", "  ", 0, consts.CodeTokenEmpty, nil},
+		{"malformed", "a.b", 0, consts.CodeTokenInvalid, model.ErrTokenMalformed},
+		{"tampered payload", tampered, 0, consts.CodeTokenInvalid, model.ErrTokenSignature},
+		{"alg none", noneHeader + "." + parts[1] + ".", 0, consts.CodeTokenInvalid, model.ErrTokenSignature},
+		{"unknown kid", unknown, 0, consts.CodeTokenInvalid, model.ErrTokenKeyUnknown},
+		{"wrong issuer", foreign, 0, consts.CodeTokenInvalid, model.ErrTokenClaimsInvalid},
+		{"refresh as access", refresh, 0, consts.CodeTokenInvalid, model.ErrTokenTypeMismatch},
+		{"expired", access, DefaultAccessTTL + time.Second, consts.CodeTokenExpired, model.ErrTokenExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := c.now
+			defer func() { c.now = saved }()
+			c.now = c.now.Add(tt.advance)
+
+			claims, err := m.VerifyAccess(tt.tok)
+			if codeOf(err) != tt.code || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Fatalf("err = %v (code %q), want code %q / %v", err, codeOf(err), tt.code, tt.wantErr)
+			}
+			if err == nil && (claims.Subject != "u1" || !claims.HasScope("trade") || claims.HasScope("admin")) {
+				t.Fatalf("claims = %+v", claims)
+			}
+		})
+	}
+
+	if _, err := m.VerifyRefresh(access); codeOf(err) != consts.CodeInvalidRefreshToken {
+		t.Fatalf("VerifyRefresh(access) code = %q", codeOf(err))
+	}
+	if _, _, err := m.IssueAccess(Session{}); codeOf(err) != consts.CodeInvalidUserID {
+		t.Fatalf("empty user code = %q", codeOf(err))
+	}
+}
+
+func TestManagerClockSkew(t *testing.T) {
+	m, c := newTestManager(t, Config{ClockSkew: 30 * time.Second})
+	tok, _, _ := m.IssueAccess(Session{UserID: "u1"})
+	tests := []struct {
+		name    string
+		advance time.Duration
+		ok      bool
+	}{
+		{"issued in the future within skew", -20 * time.Second, true},
+		{"issued in the future beyond skew", -40 * time.Second, false},
+		{"expired within skew", DefaultAccessTTL + 20*time.Second, true},
+		{"expired beyond skew", DefaultAccessTTL + 40*time.Second, false},
+	}
+	start := c.now
+	for _, tt := range tests {
+		c.now = start.Add(tt.advance)
+		if _, err := m.VerifyAccess(tok); (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	old, _ := NewHS256Key("old", testSecret)
+	m, _ := newTestManager(t, Config{}, old)
+	before, _, _ := m.IssueAccess(Session{UserID: "u1"})
+
+	_, priv, _ := ed25519.GenerateKey(nil)
+	next, _ := NewEdDSAKey("new", priv)
+	if err := m.keys.Rotate(next); err != nil {
+		t.Fatal(err)
+	}
+	after, _, _ := m.IssueAccess(Session{UserID: "u1"})
+	if h := strings.Split(after, ".")[0]; !strings.Contains(string(mustDecode(t, h)), `"kid":"new"`) {
+		t.Fatalf("new token not signed with rotated key: %s", mustDecode(t, h))
+	}
+	for _, tok := range []string{before, after} {
+		if _, err := m.VerifyAccess(tok); err != nil {
+			t.Fatalf("verify after rotation: %v", err)
+		}
+	}
+
+	m.keys.Remove("new") // کلید فعال حذف نمی‌شود
+	m.keys.Remove("old")
+	if _, err := m.VerifyAccess(before); !errors.Is(err, model.ErrTokenKeyUnknown) {
+		t.Fatalf("retired key err = %v", err)
+	}
+	if _, err := m.VerifyAccess(after); err != nil {
+		t.Fatalf("active key removed: %v", err)
+	}
+	verifyOnly, _ := NewEdDSAVerifyKey("v", priv.Public().(ed25519.PublicKey))
+	if err := m.keys.Rotate(verifyOnly); !errors.Is(err, model.ErrTokenKeyInvalid) {
+		t.Fatalf("rotate to verify-only key err = %v", err)
+	}
+}
+
+func mustDecode(t *testing.T, seg string) []byte {
+	t.Helper()
+	var raw json.RawMessage
+	if err := decodeJSON(seg, &raw); err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		tok    string
+		code   string
+	}{
+		{"Bearer abc", "abc", ""},
+		{"bearer  abc ", "abc", ""},
+		{"", "", consts.CodeAuthHeaderMissing},
+		{"Basic abc", "", consts.CodeTokenInvalid},
+		{"Bearer ", "", consts.CodeTokenInvalid},
+		{"Bearer    ", "", consts.CodeTokenInvalid},
+	}
+	for _, tt := range tests {
+		tok, err := BearerToken(tt.header)
+		if tok != tt.tok || codeOf(err) != tt.code {
+			t.Errorf("BearerToken(%q) = %q, %q; want %q, %q", tt.header, tok, codeOf(err), tt.tok, tt.code)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	m, _ := newTestManager(t, Config{})
+	userID := uuid.New()
+	access, _, _ := m.IssueAccess(Session{UserID: userID.String()})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/me", Middleware(m), func(c *gin.Context) {
+		id, err := UserIDFromContext(c)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.String(http.StatusOK, id.String())
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+		code   string
+	}{
+		{"valid", "Bearer " + access, http.StatusOK, ""},
+		{"missing", "", http.StatusUnauthorized, consts.CodeAuthHeaderMissing},
+		{"garbage", "Bearer x.y.z", http.StatusUnauthorized, consts.CodeTokenInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			r.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status == http.StatusOK {
+				if w.Body.String() != userID.String() {
+					t.Fatalf("body = %s", w.Body)
+				}
+				return
+			}
+			var res model.Response
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || res.ErrorCode != tt.code {
+				t.Fatalf("body = %s, %v", w.Body, err)
+			}
+		})
+	}
+}
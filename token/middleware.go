@@ -0,0 +1,83 @@
+package token
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ContextKeyClaims کلید ذخیره‌ی Claims در gin.Context
+const ContextKeyClaims = "token.claims"
+
+// Middleware توکن access هدر Authorization را اعتبارسنجی و Claims را در context ذخیره می‌کند
+func Middleware(m *Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw, err := BearerToken(c.GetHeader("Authorization"))
+		if err == nil {
+			var claims *Claims
+			claims, err = m.VerifyAccess(raw)
+			if err == nil {
+				c.Set(ContextKeyClaims, claims)
+				c.Next()
+				return
+			}
+		}
+
+		var re *richerror.RichError
+		if errors.As(err, &re) {
+			model.ErrorResponse(c, http.StatusUnauthorized, re.UserMessage, re.Code)
+		} else {
+			model.ErrorResponse(c, http.StatusUnauthorized, consts.ErrAuthTokenInvalid, consts.CodeTokenInvalid)
+		}
+		c.Abort()
+	}
+}
+
+// BearerToken توکن را از مقدار هدر Authorization استخراج می‌کند
+func BearerToken(authHeader string) (string, error) {
+	authHeader = strings.TrimSpace(authHeader)
+	if authHeader == "" {
+		return "", richerror.New(consts.OpTokenMiddleware, consts.ErrAuthNoAuthHeader, consts.CodeAuthHeaderMissing, richerror.KindUnauthorized, nil)
+	}
+	const prefix = "bearer "
+	if len(authHeader) < len(prefix) || !strings.EqualFold(authHeader[:len(prefix)], prefix) {
+		return "", richerror.New(consts.OpTokenMiddleware, consts.ErrAuthTokenInvalid, consts.CodeTokenInvalid, richerror.KindUnauthorized, model.ErrTokenMalformed)
+	}
+	tok := strings.TrimSpace(authHeader[len(prefix):])
+	if tok == "" {
+		return "", richerror.New(consts.OpTokenMiddleware, consts.ErrAuthEmptyToken, consts.CodeTokenEmpty, richerror.KindUnauthorized, nil)
+	}
+	return tok, nil
+}
+
+// ClaimsFromContext Claims ذخیره‌شده توسط Middleware را برمی‌گرداند
+func ClaimsFromContext(c *gin.Context) (*Claims, error) {
+	v, ok := c.Get(ContextKeyClaims)
+	if !ok {
+		return nil, richerror.New(consts.OpTokenMiddleware, consts.ErrAuthTokenNotFound, consts.CodeTokenNotFoundInContext, richerror.KindUnauthorized, nil)
+	}
+	claims, ok := v.(*Claims)
+	if !ok {
+		return nil, richerror.New(consts.OpTokenMiddleware, consts.ErrAuthTokenNotFound, consts.CodeTokenNotFoundInContext, richerror.KindUnauthorized, nil)
+	}
+	return claims, nil
+}
+
+// UserIDFromContext شناسه‌ی کاربر (sub) را به صورت UUID برمی‌گرداند
+func UserIDFromContext(c *gin.Context) (uuid.UUID, error) {
+	claims, err := ClaimsFromContext(c)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, richerror.Wrap(consts.OpTokenMiddleware, err, consts.ErrAuthUserIDTypeInvalid, consts.CodeUserIDTypeInvalid, richerror.KindUnauthorized)
+	}
+	return id, nil
+}
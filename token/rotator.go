@@ -0,0 +1,152 @@
+package token
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/google/uuid"
+)
+
+// Rotator صدور توکن با خانواده‌ی refresh، چرخش در هر refresh و تشخیص استفاده‌ی مجدد
+type Rotator struct {
+	m     *Manager
+	store RevocationStore
+}
+
+func NewRotator(m *Manager, store RevocationStore) *Rotator {
+	return &Rotator{m: m, store: store}
+}
+
+// Login خانواده‌ی جدید می‌سازد و اولین جفت توکن را صادر می‌کند
+func (r *Rotator) Login(ctx context.Context, s Session) (model.TokenResponse, error) {
+	userID, err := uuid.Parse(s.UserID)
+	if err != nil {
+		return model.TokenResponse{}, richerror.Wrap(consts.OpTokenIssue, err, consts.ErrAuthInvalidUserID, consts.CodeInvalidUserID, richerror.KindInvalid)
+	}
+	familyID := uuid.New()
+	s.FamilyID = familyID.String()
+
+	resp, refresh, err := r.issuePair(s)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+	family := entity.RefreshTokenFamily{ID: familyID, UserID: userID, SessionID: s.SessionID}
+	if err := r.store.CreateFamily(ctx, family, refreshEntity(refresh, familyID, userID)); err != nil {
+		return model.TokenResponse{}, richerror.Wrap(consts.OpTokenIssue, err, consts.ErrAuthTokenGenFail, consts.CodeAuthTokenGenFail, richerror.KindInternal)
+	}
+	return resp, nil
+}
+
+// Refresh توکن refresh را مصرف و جفت جدید در همان خانواده صادر می‌کند.
+// ارائه‌ی توکنی که قبلاً چرخانده شده کل خانواده را لغو می‌کند.
+func (r *Rotator) Refresh(ctx context.Context, refreshToken string) (model.TokenResponse, error) {
+	c, familyID, userID, err := r.verify(consts.OpTokenRefresh, refreshToken)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+	family, err := r.store.GetFamily(ctx, familyID)
+	if err != nil {
+		return model.TokenResponse{}, r.storeErr(consts.OpTokenRefresh, err)
+	}
+	if family.IsRevoked() {
+		return model.TokenResponse{}, richerror.New(consts.OpTokenRefresh, consts.ErrAuthInvalidRefreshToken, consts.CodeInvalidRefreshToken, richerror.KindUnauthorized, model.ErrTokenRevoked)
+	}
+
+	resp, next, err := r.issuePair(Session{
+		UserID:    c.Subject,
+		SessionID: c.SessionID,
+		FamilyID:  c.FamilyID,
+		Scopes:    c.Scopes,
+		Custom:    c.Custom,
+	})
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+
+	oldID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return model.TokenResponse{}, richerror.Wrap(consts.OpTokenRefresh, err, consts.ErrAuthInvalidRefreshToken, consts.CodeInvalidRefreshToken, richerror.KindUnauthorized)
+	}
+	err = r.store.Rotate(ctx, familyID, oldID, refreshEntity(next, familyID, userID))
+	if errors.Is(err, model.ErrTokenReused) {
+		if rerr := r.store.RevokeFamily(ctx, familyID, RevokeReasonReuse); rerr != nil {
+			return model.TokenResponse{}, richerror.Wrap(consts.OpTokenRefresh, rerr, consts.ErrAuthRevokeFail, consts.CodeJWTRevokeError, richerror.KindInternal)
+		}
+		return model.TokenResponse{}, richerror.New(consts.OpTokenRefresh, consts.ErrAuthRefreshTokenReused, consts.CodeRefreshTokenReused, richerror.KindUnauthorized, model.ErrTokenReused)
+	}
+	if err != nil {
+		return model.TokenResponse{}, r.storeErr(consts.OpTokenRefresh, err)
+	}
+	return resp, nil
+}
+
+// Logout خانواده‌ی توکن refresh داده‌شده را لغو می‌کند؛
+// توکن access جاری لغو نمی‌شود و تا پایان AccessTTL معتبر است.
+func (r *Rotator) Logout(ctx context.Context, refreshToken string) error {
+	_, familyID, _, err := r.verify(consts.OpTokenLogout, refreshToken)
+	if err != nil {
+		return err
+	}
+	if err := r.store.RevokeFamily(ctx, familyID, RevokeReasonLogout); err != nil {
+		return r.storeErr(consts.OpTokenLogout, err)
+	}
+	return nil
+}
+
+// LogoutEverywhere همه‌ی خانواده‌های فعال کاربر را لغو می‌کند (توکن‌های access تا پایان AccessTTL معتبر می‌مانند)
+func (r *Rotator) LogoutEverywhere(ctx context.Context, userID uuid.UUID) (int, error) {
+	n, err := r.store.RevokeUser(ctx, userID, RevokeReasonLogoutAll)
+	if err != nil {
+		return 0, richerror.Wrap(consts.OpTokenLogout, err, consts.ErrAuthRevokeFail, consts.CodeJWTRevokeError, richerror.KindInternal)
+	}
+	return n, nil
+}
+
+func (r *Rotator) verify(op, tok string) (*Claims, uuid.UUID, uuid.UUID, error) {
+	c, err := r.m.VerifyRefresh(tok)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, err
+	}
+	familyID, err := uuid.Parse(c.FamilyID)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, richerror.New(op, consts.ErrAuthInvalidRefreshToken, consts.CodeInvalidRefreshToken, richerror.KindUnauthorized, model.ErrTokenClaimsInvalid)
+	}
+	userID, err := uuid.Parse(c.Subject)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, richerror.New(op, consts.ErrAuthInvalidRefreshToken, consts.CodeInvalidRefreshToken, richerror.KindUnauthorized, model.ErrTokenClaimsInvalid)
+	}
+	return c, familyID, userID, nil
+}
+
+func (r *Rotator) issuePair(s Session) (model.TokenResponse, *Claims, error) {
+	access, _, err := r.m.IssueAccess(s)
+	if err != nil {
+		return model.TokenResponse{}, nil, err
+	}
+	refresh, rc, err := r.m.IssueRefresh(s)
+	if err != nil {
+		return model.TokenResponse{}, nil, err
+	}
+	return model.TokenResponse{AccessToken: access, RefreshToken: refresh}, rc, nil
+}
+
+func (r *Rotator) storeErr(op string, err error) error {
+	if errors.Is(err, model.ErrTokenNotFound) {
+		return richerror.Wrap(op, err, consts.ErrAuthInvalidRefreshToken, consts.CodeInvalidRefreshToken, richerror.KindUnauthorized)
+	}
+	return richerror.Wrap(op, err, consts.ErrAuthRevokeFail, consts.CodeJWTRevokeError, richerror.KindInternal)
+}
+
+func refreshEntity(c *Claims, familyID, userID uuid.UUID) entity.RefreshToken {
+	id, _ := uuid.Parse(c.ID)
+	return entity.RefreshToken{
+		ID:        id,
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: c.Expiry(),
+	}
+}
@@ -0,0 +1,139 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+func newTestRotator(t *testing.T) (*Rotator, *MemoryRevocationStore) {
+	t.Helper()
+	m, c := newTestManager(t, Config{})
+	store := NewMemoryRevocationStore()
+	store.now = c.Now
+	return NewRotator(m, store), store
+}
+
+func TestRotatorRefreshAndReuse(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRotator(t)
+	userID := uuid.New()
+
+	first, err := r.Login(ctx, Session{UserID: userID.String(), SessionID: "s1", Scopes: []string{"trade"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := r.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+	c, err := r.m.VerifyAccess(second.AccessToken)
+	if err != nil || c.SessionID != "s1" || !c.HasScope("trade") {
+		t.Fatalf("rotated access claims = %+v, %v", c, err)
+	}
+
+	steps := []struct {
+		name    string
+		tok     string
+		code    string
+		wantErr error
+	}{
+		{"reuse of rotated token", first.RefreshToken, consts.CodeRefreshTokenReused, model.ErrTokenReused},
+		{"family revoked after reuse", second.RefreshToken, consts.CodeInvalidRefreshToken, model.ErrTokenRevoked},
+		{"access token rejected", second.AccessToken, consts.CodeInvalidRefreshToken, model.ErrTokenTypeMismatch},
+		{"garbage", "x.y.z", consts.CodeInvalidRefreshToken, model.ErrTokenMalformed},
+	}
+	for _, s := range steps {
+		_, err := r.Refresh(ctx, s.tok)
+		if codeOf(err) != s.code || !errors.Is(err, s.wantErr) {
+			t.Fatalf("%s: err = %v (code %q), want %q / %v", s.name, err, codeOf(err), s.code, s.wantErr)
+		}
+	}
+}
+
+func TestRotatorLogout(t *testing.T) {
+	ctx := context.Background()
+	r, store := newTestRotator(t)
+	userID := uuid.New()
+
+	a, _ := r.Login(ctx, Session{UserID: userID.String()})
+	b, _ := r.Login(ctx, Session{UserID: userID.String()})
+	c, _ := r.Login(ctx, Session{UserID: userID.String()})
+
+	if err := r.Logout(ctx, a.RefreshToken); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Refresh(ctx, a.RefreshToken); !errors.Is(err, model.ErrTokenRevoked) {
+		t.Fatalf("refresh after logout err = %v", err)
+	}
+	n, err := r.LogoutEverywhere(ctx, userID)
+	if err != nil || n != 2 {
+		t.Fatalf("LogoutEverywhere = %d, %v; want 2", n, err)
+	}
+	for _, tok := range []string{b.RefreshToken, c.RefreshToken} {
+		if _, err := r.Refresh(ctx, tok); !errors.Is(err, model.ErrTokenRevoked) {
+			t.Fatalf("refresh after logout everywhere err = %v", err)
+		}
+	}
+	for _, f := range store.families {
+		if f.RevokeReason == nil {
+			t.Fatalf("family %s not revoked", f.ID)
+		}
+	}
+
+	if _, err := r.Login(ctx, Session{UserID: "not-a-uuid"}); codeOf(err) != consts.CodeInvalidUserID {
+		t.Fatalf("invalid user code = %q", codeOf(err))
+	}
+}
+
+func TestMemoryRevocationStoreRotate(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryRevocationStore()
+	fam := entity.RefreshTokenFamily{ID: uuid.New(), UserID: uuid.New()}
+	t1 := entity.RefreshToken{ID: uuid.New(), FamilyID: fam.ID, UserID: fam.UserID}
+	if err := s.CreateFamily(ctx, fam, t1); err != nil {
+		t.Fatal(err)
+	}
+	t2 := entity.RefreshToken{ID: uuid.New(), FamilyID: fam.ID, UserID: fam.UserID}
+	t3 := entity.RefreshToken{ID: uuid.New(), FamilyID: fam.ID, UserID: fam.UserID}
+
+	tests := []struct {
+		name    string
+		family  uuid.UUID
+		old     uuid.UUID
+		next    entity.RefreshToken
+		wantErr error
+	}{
+		{"rotate", fam.ID, t1.ID, t2, nil},
+		{"rotate again", fam.ID, t1.ID, t3, model.ErrTokenReused},
+		{"other family", uuid.New(), t2.ID, t3, model.ErrTokenNotFound},
+		{"unknown token", fam.ID, uuid.New(), t3, model.ErrTokenNotFound},
+		{"chain continues", fam.ID, t2.ID, t3, nil},
+	}
+	for _, tt := range tests {
+		if err := s.Rotate(ctx, tt.family, tt.old, tt.next); !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if got := s.tokens[t1.ID]; got.ReplacedBy == nil || *got.ReplacedBy != t2.ID {
+		t.Fatalf("t1 ReplacedBy = %v", got.ReplacedBy)
+	}
+
+	if err := s.RevokeFamily(ctx, fam.ID, RevokeReasonLogout); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RevokeFamily(ctx, fam.ID, RevokeReasonReuse); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := s.GetFamily(ctx, fam.ID); *f.RevokeReason != RevokeReasonLogout {
+		t.Fatalf("reason overwritten: %s", *f.RevokeReason)
+	}
+	if _, err := s.GetFamily(ctx, uuid.New()); !errors.Is(err, model.ErrTokenNotFound) {
+		t.Fatalf("GetFamily unknown err = %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package token
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+// دلایل لغو خانواده‌ی توکن
+const (
+	RevokeReasonLogout    = "logout"
+	RevokeReasonLogoutAll = "logout_all"
+	RevokeReasonReuse     = "reuse_detected"
+)
+
+// RevocationStore وضعیت خانواده‌ها و توکن‌های refresh را نگه می‌دارد.
+// لغو فقط refresh را می‌بندد؛ توکن‌های access صادرشده تا پایان AccessTTL معتبر می‌مانند.
+type RevocationStore interface {
+	CreateFamily(ctx context.Context, family entity.RefreshTokenFamily, first entity.RefreshToken) error
+	GetFamily(ctx context.Context, familyID uuid.UUID) (entity.RefreshTokenFamily, error)
+	// Rotate توکن oldID را به صورت اتمیک با next جایگزین می‌کند.
+	// اگر oldID قبلاً چرخانده شده باشد model.ErrTokenReused و اگر وجود نداشته باشد model.ErrTokenNotFound برمی‌گردد.
+	Rotate(ctx context.Context, familyID, oldID uuid.UUID, next entity.RefreshToken) error
+	// RevokeFamily خانواده را لغو می‌کند؛ لغو دوباره بی‌اثر است و خانواده‌ی ناموجود model.ErrTokenNotFound برمی‌گرداند
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, reason string) error
+	// RevokeUser همه‌ی خانواده‌های فعال کاربر را لغو و تعداد آن‌ها را برمی‌گرداند
+	RevokeUser(ctx context.Context, userID uuid.UUID, reason string) (int, error)
+}
+
+// MemoryRevocationStore پیاده‌سازی درون‌حافظه‌ای (تست و سرویس تک‌نمونه‌ای)
+type MemoryRevocationStore struct {
+	mu       sync.Mutex
+	families map[uuid.UUID]entity.RefreshTokenFamily
+	tokens   map[uuid.UUID]entity.RefreshToken
+	now      func() time.Time
+}
+
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		families: make(map[uuid.UUID]entity.RefreshTokenFamily),
+		tokens:   make(map[uuid.UUID]entity.RefreshToken),
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryRevocationStore) CreateFamily(_ context.Context, family entity.RefreshTokenFamily, first entity.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	family.CreatedAt, family.UpdatedAt = now, now
+	first.CreatedAt = now
+	s.families[family.ID] = family
+	s.tokens[first.ID] = first
+	return nil
+}
+
+func (s *MemoryRevocationStore) GetFamily(_ context.Context, familyID uuid.UUID) (entity.RefreshTokenFamily, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.families[familyID]
+	if !ok {
+		return entity.RefreshTokenFamily{}, model.ErrTokenNotFound
+	}
+	return f, nil
+}
+
+func (s *MemoryRevocationStore) Rotate(_ context.Context, familyID, oldID uuid.UUID, next entity.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.tokens[oldID]
+	if !ok || old.FamilyID != familyID {
+		return model.ErrTokenNotFound
+	}
+	if old.RotatedAt != nil {
+		return model.ErrTokenReused
+	}
+	now := s.now()
+	old.RotatedAt = &now
+	old.ReplacedBy = &next.ID
+	s.tokens[oldID] = old
+	next.CreatedAt = now
+	s.tokens[next.ID] = next
+	return nil
+}
+
+func (s *MemoryRevocationStore) RevokeFamily(_ context.Context, familyID uuid.UUID, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.families[familyID]
+	if !ok {
+		return model.ErrTokenNotFound
+	}
+	if f.RevokedAt == nil {
+		now := s.now()
+		f.RevokedAt = &now
+		f.RevokeReason = &reason
+		f.UpdatedAt = now
+		s.families[familyID] = f
+	}
+	return nil
+}
+
+func (s *MemoryRevocationStore) RevokeUser(_ context.Context, userID uuid.UUID, reason string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	n := 0
+	for id, f := range s.families {
+		if f.UserID != userID || f.RevokedAt != nil {
+			continue
+		}
+		r := reason
+		f.RevokedAt = &now
+		f.RevokeReason = &r
+		f.UpdatedAt = now
+		s.families[id] = f
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,104 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GormRevocationStore پیاده‌سازی RevocationStore روی جداول refresh_token_families و refresh_tokens
+type GormRevocationStore struct {
+	db *gorm.DB
+}
+
+func NewGormRevocationStore(db *gorm.DB) *GormRevocationStore {
+	return &GormRevocationStore{db: db}
+}
+
+func (s *GormRevocationStore) CreateFamily(ctx context.Context, family entity.RefreshTokenFamily, first entity.RefreshToken) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&family).Error; err != nil {
+			return err
+		}
+		return tx.Create(&first).Error
+	})
+}
+
+func (s *GormRevocationStore) GetFamily(ctx context.Context, familyID uuid.UUID) (entity.RefreshTokenFamily, error) {
+	var f entity.RefreshTokenFamily
+	err := s.db.WithContext(ctx).Where("id = ?", familyID).Take(&f).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return f, model.ErrTokenNotFound
+	}
+	return f, err
+}
+
+func (s *GormRevocationStore) Rotate(ctx context.Context, familyID, oldID uuid.UUID, next entity.RefreshToken) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// به‌روزرسانی شرطی: فقط اولین refresh موفق می‌شود، درخواست همزمان دوم reuse تشخیص داده می‌شود
+		res := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND family_id = ? AND rotated_at IS NULL", oldID, familyID).
+			Updates(map[string]interface{}{
+				"rotated_at":  time.Now(),
+				"replaced_by": next.ID,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entity.RefreshToken{}).
+				Where("id = ? AND family_id = ?", oldID, familyID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return model.ErrTokenNotFound
+			}
+			return model.ErrTokenReused
+		}
+		return tx.Create(&next).Error
+	})
+}
+
+func (s *GormRevocationStore) RevokeFamily(ctx context.Context, familyID uuid.UUID, reason string) error {
+	res := s.db.WithContext(ctx).Model(&entity.RefreshTokenFamily{}).
+		Where("id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{
+			"revoked_at":    time.Now(),
+			"revoke_reason": reason,
+			"updated_at":    time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		// مثل MemoryRevocationStore: لغو دوباره بی‌اثر است ولی خانواده‌ی ناموجود خطا دارد
+		var count int64
+		if err := s.db.WithContext(ctx).Model(&entity.RefreshTokenFamily{}).
+			Where("id = ?", familyID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return model.ErrTokenNotFound
+		}
+	}
+	return nil
+}
+
+func (s *GormRevocationStore) RevokeUser(ctx context.Context, userID uuid.UUID, reason string) (int, error) {
+	res := s.db.WithContext(ctx).Model(&entity.RefreshTokenFamily{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at":    time.Now(),
+			"revoke_reason": reason,
+			"updated_at":    time.Now(),
+		})
+	return int(res.RowsAffected), res.Error
+}
@@ -0,0 +1,70 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+// newFakeFamilyDB جدول refresh_token_families را با callbackهای gorm در حالت DryRun شبیه‌سازی می‌کند؛
+// مقدار هر کلید نشان می‌دهد خانواده لغو شده است یا نه
+func newFakeFamilyDB(t *testing.T, revoked map[uuid.UUID]bool) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	familyID := func(tx *gorm.DB) uuid.UUID {
+		for _, v := range tx.Statement.Vars {
+			if id, ok := v.(uuid.UUID); ok {
+				return id
+			}
+		}
+		return uuid.Nil
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("test:revoke", func(tx *gorm.DB) {
+		id := familyID(tx)
+		if done, ok := revoked[id]; ok && !done {
+			revoked[id] = true
+			tx.RowsAffected = 1
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("test:count", func(tx *gorm.DB) {
+		if _, ok := revoked[familyID(tx)]; ok {
+			*tx.Statement.Dest.(*int64) = 1
+			tx.RowsAffected = 1
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestGormRevokeFamily(t *testing.T) {
+	ctx := context.Background()
+	active, revoked := uuid.New(), uuid.New()
+	s := NewGormRevocationStore(newFakeFamilyDB(t, map[uuid.UUID]bool{active: false, revoked: true}))
+
+	tests := []struct {
+		name    string
+		family  uuid.UUID
+		wantErr error
+	}{
+		{"active", active, nil},
+		{"revoke again", active, nil},
+		{"already revoked", revoked, nil},
+		{"unknown family", uuid.New(), model.ErrTokenNotFound},
+	}
+	for _, tt := range tests {
+		if err := s.RevokeFamily(ctx, tt.family, RevokeReasonLogout); !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
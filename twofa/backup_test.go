@@ -0,0 +1,87 @@
+package twofa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+func TestNewBackupCodesRequiresPepper(t *testing.T) {
+	if _, err := NewBackupCodes(nil); !errors.Is(err, model.ErrPepperRequired) {
+		t.Fatalf("err = %v, want ErrPepperRequired", err)
+	}
+}
+
+func TestGenerateBackupCodes(t *testing.T) {
+	codes, err := GenerateBackupCodes(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != DefaultBackupCodeCount {
+		t.Fatalf("len = %d, want %d", len(codes), DefaultBackupCodeCount)
+	}
+	seen := map[string]bool{}
+	for _, c := range codes {
+		if len(c) != backupCodeLength+1 || c[backupCodeLength/2] != '-' {
+			t.Fatalf("bad format %q", c)
+		}
+		if !IsBackupCode(c) {
+			t.Fatalf("IsBackupCode(%q) = false", c)
+		}
+		if seen[c] {
+			t.Fatalf("duplicate code %q", c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestIsBackupCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"ABCDE-FGHJK", true},
+		{"abcde fghjk", true},
+		{"ABCDEFGHJK", true},
+		{"123456", false},
+		{"ABCDE-FGHJ0", false}, // 0 در الفبا نیست
+		{"ABCDE-FGHJKL", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsBackupCode(tt.code); got != tt.want {
+			t.Errorf("IsBackupCode(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestBackupCodesHash(t *testing.T) {
+	b, err := NewBackupCodes([]byte("pepper-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := NewBackupCodes([]byte("pepper-2"))
+	u1, u2 := uuid.New(), uuid.New()
+
+	h := b.Hash(u1, "ABCDE-FGHJK")
+	if len(h) != 64 {
+		t.Fatalf("hash length = %d, want 64", len(h))
+	}
+	tests := []struct {
+		name string
+		got  string
+		same bool
+	}{
+		{"normalized input", b.Hash(u1, " abcde fghjk "), true},
+		{"other user", b.Hash(u2, "ABCDE-FGHJK"), false},
+		{"other pepper", other.Hash(u1, "ABCDE-FGHJK"), false},
+		{"other code", b.Hash(u1, "ABCDE-FGHJM"), false},
+	}
+	for _, tt := range tests {
+		if (tt.got == h) != tt.same {
+			t.Errorf("%s: equal = %v, want %v", tt.name, tt.got == h, tt.same)
+		}
+	}
+}
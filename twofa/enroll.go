@@ -0,0 +1,90 @@
+package twofa
+
+import (
+	"errors"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Enroll کلید جدید می‌سازد و اطلاعات اسکن QR را برمی‌گرداند؛ credential تا Confirm غیرفعال است
+func (t *TOTP) Enroll(userID uuid.UUID, account string) (*entity.TOTPCredential, model.TOTPEnrollResponse, error) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, model.TOTPEnrollResponse{}, richerror.Wrap(consts.OpTwoFATOTPEnroll, err, consts.ErrTOTPEnrollFail, consts.CodeTOTPEnrollFail, richerror.KindInternal)
+	}
+	cred := &entity.TOTPCredential{
+		UserID:    userID,
+		Secret:    secret,
+		Digits:    t.cfg.Digits,
+		Period:    int(t.cfg.Period.Seconds()),
+		Algorithm: t.cfg.Algorithm,
+	}
+	uri := t.ProvisioningURI(account, cred)
+	return cred, model.TOTPEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: uri,
+		QRPayload:       uri,
+		Message:         consts.MsgTOTPEnrollStarted,
+	}, nil
+}
+
+// Confirm اولین کد برنامه را با Digits و Period همان credential بررسی و آن را فعال می‌کند؛
+// پس از ذخیره، کدهای پشتیبان با BackupCodes.Regenerate ساخته و در TOTPConfirmResponse برگردانده می‌شوند.
+func (t *TOTP) Confirm(cred *entity.TOTPCredential, code string) error {
+	if cred.IsConfirmed() {
+		return richerror.New(consts.OpTwoFATOTPConfirm, consts.ErrTOTPAlreadyEnabled, consts.CodeTOTPAlreadyEnabled, richerror.KindConflict, model.ErrTOTPAlreadyEnabled)
+	}
+	step, err := t.validate(cred.Secret, code, cred.LastUsedStep, t.credParams(cred))
+	if err != nil {
+		return codeError(consts.OpTwoFATOTPConfirm, err)
+	}
+	now := t.cfg.Now()
+	cred.ConfirmedAt = &now
+	cred.LastUsedStep = step
+	return nil
+}
+
+// Verify کد ورود را با Digits و Period خود credential فعال بررسی می‌کند و LastUsedStep را جلو می‌برد.
+// برای سرویس‌های چندنمونه‌ای گام برگشتی باید با ConsumeStep ثبت شود.
+func (t *TOTP) Verify(cred *entity.TOTPCredential, code string) (int64, error) {
+	if cred == nil || !cred.IsConfirmed() {
+		return 0, richerror.New(consts.OpTwoFATOTPVerify, consts.ErrTOTPNotEnrolled, consts.CodeTOTPNotEnrolled, richerror.KindForbidden, model.ErrTOTPNotEnrolled)
+	}
+	step, err := t.validate(cred.Secret, code, cred.LastUsedStep, t.credParams(cred))
+	if err != nil {
+		return 0, codeError(consts.OpTwoFATOTPVerify, err)
+	}
+	cred.LastUsedStep = step
+	return step, nil
+}
+
+// ConsumeStep گام پذیرفته‌شده را به صورت شرطی ثبت می‌کند؛
+// اگر درخواست همزمان همان گام (یا گام بعدی) را زودتر ثبت کرده باشد model.Err2FACodeUsed برمی‌گردد.
+func ConsumeStep(tx *gorm.DB, credID uuid.UUID, step int64) error {
+	res := tx.Model(&entity.TOTPCredential{}).
+		Where("id = ? AND last_used_step < ?", credID, step).
+		Update("last_used_step", step)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.Err2FACodeUsed
+	}
+	return nil
+}
+
+func codeError(op string, err error) error {
+	switch {
+	case errors.Is(err, model.Err2FACodeUsed):
+		return richerror.New(op, consts.ErrVerificationUsed, consts.CodeVerificationUsed, richerror.KindUnauthorized, err)
+	case errors.Is(err, model.ErrTOTPSecretInvalid):
+		return richerror.New(op, consts.ErrTOTPEnrollFail, consts.CodeTOTPEnrollFail, richerror.KindInternal, err)
+	default:
+		return richerror.New(op, consts.Err2FACodeInvalid, consts.CodeInvalid2FACode, richerror.KindUnauthorized, err)
+	}
+}
@@ -0,0 +1,236 @@
+package twofa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/util"
+)
+
+// الگوریتم‌های HMAC پشتیبانی‌شده در RFC 6238
+const (
+	AlgorithmSHA1   = "SHA1"
+	AlgorithmSHA256 = "SHA256"
+	AlgorithmSHA512 = "SHA512"
+)
+
+const (
+	DefaultDigits     = 6
+	DefaultPeriod     = 30 * time.Second
+	DefaultSkew       = 1 // تعداد گام‌های قابل قبول قبل/بعد از گام فعلی (اختلاف ساعت گوشی)
+	DefaultSecretSize = 20
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type TOTPConfig struct {
+	Issuer    string        // نام نمایشی در برنامه احراز هویت
+	Digits    int           // ۶ یا ۸ برای credentialهای جدید؛ credentialهای موجود با Digits خودشان بررسی می‌شوند
+	Period    time.Duration // برای credentialهای جدید؛ مضربی از ثانیه و حداقل یک ثانیه
+	Skew      int
+	Algorithm string // برای credentialهای جدید؛ پیش‌فرض AlgorithmSHA1
+	Now       func() time.Time
+}
+
+// TOTP تولید و اعتبارسنجی کدهای یک‌بارمصرف زمانی (RFC 6238)
+type TOTP struct {
+	cfg TOTPConfig
+}
+
+// NewTOTP برای Digits غیر از ۶ و ۸، الگوریتم ناشناخته یا Period کمتر از یک ثانیه (یا غیرمضرب ثانیه) خطا می‌دهد
+func NewTOTP(cfg TOTPConfig) (*TOTP, error) {
+	switch cfg.Digits {
+	case 0:
+		cfg.Digits = DefaultDigits
+	case 6, 8:
+	default:
+		return nil, model.ErrTOTPDigitsInvalid
+	}
+	if cfg.Period == 0 {
+		cfg.Period = DefaultPeriod
+	}
+	if cfg.Period < time.Second || cfg.Period%time.Second != 0 {
+		return nil, model.ErrTOTPPeriodInvalid
+	}
+	if cfg.Skew < 0 {
+		cfg.Skew = 0
+	} else if cfg.Skew == 0 {
+		cfg.Skew = DefaultSkew
+	}
+	switch cfg.Algorithm {
+	case "":
+		cfg.Algorithm = AlgorithmSHA1
+	case AlgorithmSHA1, AlgorithmSHA256, AlgorithmSHA512:
+	default:
+		return nil, model.ErrTOTPAlgorithmInvalid
+	}
+	if cfg.Now == nil {
+		cfg.Now = util.NowUTC
+	}
+	return &TOTP{cfg: cfg}, nil
+}
+
+// params تعداد رقم، طول گام و الگوریتم یک کلید
+type params struct {
+	digits    int
+	period    time.Duration
+	algorithm string
+}
+
+func (t *TOTP) defaults() params {
+	return params{digits: t.cfg.Digits, period: t.cfg.Period, algorithm: t.cfg.Algorithm}
+}
+
+// credParams مقادیر ذخیره‌شده در credential؛ تغییر بعدی TOTPConfig روی کلیدهای ثبت‌شده اثری ندارد.
+// Algorithm خالی (credential ساخته‌شده پیش از ستون Algorithm) همان پیش‌فرض ستون یعنی SHA1 است.
+func (t *TOTP) credParams(cred *entity.TOTPCredential) params {
+	p := t.defaults()
+	if cred.Digits == 6 || cred.Digits == 8 {
+		p.digits = cred.Digits
+	}
+	if cred.Period > 0 {
+		p.period = time.Duration(cred.Period) * time.Second
+	}
+	switch cred.Algorithm {
+	case AlgorithmSHA1, AlgorithmSHA256, AlgorithmSHA512:
+		p.algorithm = cred.Algorithm
+	default:
+		p.algorithm = AlgorithmSHA1
+	}
+	return p
+}
+
+// GenerateSecret کلید تصادفی base32 (بدون padding) می‌سازد
+func GenerateSecret() (string, error) {
+	b := make([]byte, DefaultSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return b32.EncodeToString(b), nil
+}
+
+// Step گام زمانی متناظر با at با طول گام پیش‌فرض
+func (t *TOTP) Step(at time.Time) int64 {
+	return step(at, t.cfg.Period)
+}
+
+func step(at time.Time, period time.Duration) int64 {
+	return at.Unix() / int64(period/time.Second)
+}
+
+// Code کد گام زمانی at را با تنظیمات پیش‌فرض تولید می‌کند
+func (t *TOTP) Code(secret string, at time.Time) (string, error) {
+	return t.code(secret, at, t.defaults())
+}
+
+// CredentialCode کد گام زمانی at را با Digits، Period و Algorithm ذخیره‌شده در credential تولید می‌کند
+func (t *TOTP) CredentialCode(cred *entity.TOTPCredential, at time.Time) (string, error) {
+	return t.code(cred.Secret, at, t.credParams(cred))
+}
+
+func (t *TOTP) code(secret string, at time.Time, p params) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, step(at, p.period), p), nil
+}
+
+// Validate کد را با تنظیمات پیش‌فرض در پنجره‌ی ±Skew بررسی و گام منطبق را برمی‌گرداند.
+// گام‌های کوچک‌تر یا مساوی lastStep رد می‌شوند تا یک کد دو بار پذیرفته نشود.
+func (t *TOTP) Validate(secret, code string, lastStep int64) (int64, error) {
+	return t.validate(secret, code, lastStep, t.defaults())
+}
+
+func (t *TOTP) validate(secret, code string, lastStep int64, p params) (int64, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != p.digits {
+		return 0, model.Err2FACodeInvalid
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, err
+	}
+	now := step(t.cfg.Now(), p.period)
+	for i := -t.cfg.Skew; i <= t.cfg.Skew; i++ {
+		s := now + int64(i)
+		if subtle.ConstantTimeCompare([]byte(hotp(key, s, p)), []byte(code)) != 1 {
+			continue
+		}
+		if s <= lastStep {
+			return 0, model.Err2FACodeUsed
+		}
+		return s, nil
+	}
+	return 0, model.Err2FACodeInvalid
+}
+
+// ProvisioningURI آدرس otpauth:// credential برای اسکن در برنامه احراز هویت
+func (t *TOTP) ProvisioningURI(account string, cred *entity.TOTPCredential) string {
+	p := t.credParams(cred)
+	label := url.PathEscape(account)
+	if t.cfg.Issuer != "" {
+		label = url.PathEscape(t.cfg.Issuer) + ":" + label
+	}
+	q := url.Values{}
+	q.Set("secret", cred.Secret)
+	if t.cfg.Issuer != "" {
+		q.Set("issuer", t.cfg.Issuer)
+	}
+	q.Set("algorithm", p.algorithm)
+	q.Set("digits", strconv.Itoa(p.digits))
+	q.Set("period", strconv.Itoa(int(p.period/time.Second)))
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+func hotp(key []byte, counter int64, p params) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(hashFunc(p.algorithm), key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation (RFC 4226 بخش 5.3)
+	off := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[off:off+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < p.digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", p.digits, bin%mod)
+}
+
+func hashFunc(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case AlgorithmSHA256:
+		return sha256.New
+	case AlgorithmSHA512:
+		return sha512.New
+	default:
+		return sha1.New
+	}
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	s = strings.TrimRight(s, "=")
+	key, err := b32.DecodeString(s)
+	if err != nil || len(key) == 0 {
+		return nil, model.ErrTOTPSecretInvalid
+	}
+	return key, nil
+}
@@ -0,0 +1,190 @@
+package twofa
+
+import (
+	"encoding/base32"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+// کلید RFC 6238 پیوست B برای SHA1
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func newTestTOTP(t *testing.T, cfg TOTPConfig, now *time.Time) *TOTP {
+	t.Helper()
+	cfg.Now = func() time.Time { return *now }
+	totp, err := NewTOTP(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return totp
+}
+
+func TestNewTOTPConfig(t *testing.T) {
+	tests := []struct {
+		cfg     TOTPConfig
+		wantErr error
+	}{
+		{TOTPConfig{}, nil},
+		{TOTPConfig{Period: time.Second}, nil},
+		{TOTPConfig{Period: 60 * time.Second}, nil},
+		{TOTPConfig{Period: -time.Second}, model.ErrTOTPPeriodInvalid},
+		{TOTPConfig{Period: 500 * time.Millisecond}, model.ErrTOTPPeriodInvalid},
+		{TOTPConfig{Period: 1500 * time.Millisecond}, model.ErrTOTPPeriodInvalid},
+		{TOTPConfig{Digits: 6}, nil},
+		{TOTPConfig{Digits: 8}, nil},
+		{TOTPConfig{Digits: 7}, model.ErrTOTPDigitsInvalid},
+		{TOTPConfig{Digits: -1}, model.ErrTOTPDigitsInvalid},
+		{TOTPConfig{Algorithm: AlgorithmSHA512}, nil},
+		{TOTPConfig{Algorithm: "MD5"}, model.ErrTOTPAlgorithmInvalid},
+	}
+	for _, tt := range tests {
+		if _, err := NewTOTP(tt.cfg); !errors.Is(err, tt.wantErr) {
+			t.Errorf("NewTOTP(%+v) err = %v, want %v", tt.cfg, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	now := time.Unix(0, 0)
+	totp := newTestTOTP(t, TOTPConfig{Digits: 8}, &now)
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+	}
+	for _, tt := range tests {
+		got, err := totp.Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+// تغییر TOTPConfig پس از ثبت‌نام نباید credentialهای موجود را از کار بیندازد
+func TestTOTPVerifyUsesCredentialParams(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	confirmed := now
+	cred := &entity.TOTPCredential{UserID: uuid.New(), Secret: rfcSecret, Digits: 8, Period: 60, Algorithm: AlgorithmSHA256, ConfirmedAt: &confirmed}
+
+	enrolledWith := newTestTOTP(t, TOTPConfig{Digits: 8, Period: time.Minute, Algorithm: AlgorithmSHA256}, &now)
+	code, err := enrolledWith.CredentialCode(cred, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sha1Code, _ := newTestTOTP(t, TOTPConfig{Digits: 8, Period: time.Minute}, &now).Code(rfcSecret, now); sha1Code == code {
+		t.Fatal("SHA256 and SHA1 codes are equal")
+	}
+	current := newTestTOTP(t, TOTPConfig{}, &now) // ۶ رقم، ۳۰ ثانیه و SHA1
+
+	if _, err := current.Validate(cred.Secret, code, 0); err == nil {
+		t.Fatal("default config accepted an 8-digit code")
+	}
+	step, err := current.Verify(cred, code)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if want := now.Unix() / 60; step != want {
+		t.Fatalf("step = %d, want %d", step, want)
+	}
+	if _, err := current.Verify(cred, code); !errors.Is(err, model.Err2FACodeUsed) {
+		t.Fatalf("replay: err = %v, want Err2FACodeUsed", err)
+	}
+}
+
+func TestTOTPValidate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	totp := newTestTOTP(t, TOTPConfig{}, &now)
+	code := func(at time.Time) string {
+		c, err := totp.Code(rfcSecret, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	cur := totp.Step(now)
+	tests := []struct {
+		name     string
+		code     string
+		lastStep int64
+		wantErr  error
+	}{
+		{"current", code(now), 0, nil},
+		{"previous step within skew", code(now.Add(-DefaultPeriod)), 0, nil},
+		{"outside skew", code(now.Add(-3 * DefaultPeriod)), 0, model.Err2FACodeInvalid},
+		{"already used", code(now), cur, model.Err2FACodeUsed},
+		{"wrong length", "12345", 0, model.Err2FACodeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := totp.Validate(rfcSecret, tt.code, tt.lastStep); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := totp.Validate("!!!", "123456", 0); !errors.Is(err, model.ErrTOTPSecretInvalid) {
+		t.Fatalf("bad secret: err = %v", err)
+	}
+}
+
+func TestTOTPConfirm(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	totp := newTestTOTP(t, TOTPConfig{Issuer: "Exchange"}, &now)
+	cred, resp, err := totp.Enroll(uuid.New(), "user@gmail.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.Digits != DefaultDigits || cred.Period != 30 || cred.Algorithm != AlgorithmSHA1 || resp.Secret != cred.Secret {
+		t.Fatalf("cred = %+v", cred)
+	}
+	if _, err := totp.Verify(cred, "000000"); !errors.Is(err, model.ErrTOTPNotEnrolled) {
+		t.Fatalf("unconfirmed Verify err = %v", err)
+	}
+	code, _ := totp.CredentialCode(cred, now)
+	if err := totp.Confirm(cred, code); err != nil {
+		t.Fatal(err)
+	}
+	if !cred.IsConfirmed() || cred.LastUsedStep != totp.Step(now) {
+		t.Fatalf("cred after confirm = %+v", cred)
+	}
+	if err := totp.Confirm(cred, code); !errors.Is(err, model.ErrTOTPAlreadyEnabled) {
+		t.Fatalf("second Confirm err = %v", err)
+	}
+}
+
+func TestProvisioningURIUsesCredential(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	totp := newTestTOTP(t, TOTPConfig{Issuer: "Exchange"}, &now)
+	tests := []struct {
+		name string
+		cred *entity.TOTPCredential
+		want string
+	}{
+		{
+			"stored params",
+			&entity.TOTPCredential{Secret: "JBSWY3DPEHPK3PXP", Digits: 8, Period: 60, Algorithm: AlgorithmSHA512},
+			"otpauth://totp/Exchange:user@gmail.com?algorithm=SHA512&digits=8&issuer=Exchange&period=60&secret=JBSWY3DPEHPK3PXP",
+		},
+		{
+			"legacy row without algorithm",
+			&entity.TOTPCredential{Secret: "JBSWY3DPEHPK3PXP", Digits: 6, Period: 30},
+			"otpauth://totp/Exchange:user@gmail.com?algorithm=SHA1&digits=6&issuer=Exchange&period=30&secret=JBSWY3DPEHPK3PXP",
+		},
+	}
+	for _, tt := range tests {
+		if got := totp.ProvisioningURI("user@gmail.com", tt.cred); got != tt.want {
+			t.Errorf("%s: URI = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package util
 import (
 	"crypto/rand"
 	"errors"
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/email"
+	"github.com/alisiahmansouri/exchange-common/phone"
 	"github.com/google/uuid"
-	"regexp"
 	"strings"
 	"time"
 	"unicode"
@@ -19,18 +21,37 @@ func GenerateUUID() string {
 }
 
 func GenerateVerificationCode() (string, error) {
-	const digits = "0123456789"
-	length := 6
-	bytes := make([]byte, length)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
-	}
-	for i := 0; i < length; i++ {
-		bytes[i] = digits[bytes[i]%byte(len(digits))]
+	return GenerateRandomString("0123456789", 6)
+}
+
+// GenerateRandomString رشته‌ی تصادفی از alphabet با توزیع یکنواخت می‌سازد؛
+// بایت‌های بزرگ‌تر از بزرگ‌ترین مضرب طول alphabet دور ریخته می‌شوند تا b%n سوگیری نداشته باشد.
+func GenerateRandomString(alphabet string, length int) (string, error) {
+	n := len(alphabet)
+	if n == 0 || n > 256 || length <= 0 {
+		return "", errors.New("invalid alphabet or length")
+	}
+	limit := 256 - 256%n
+	out := make([]byte, 0, length)
+	buf := make([]byte, length*2)
+	for len(out) < length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if int(b) >= limit {
+				continue
+			}
+			out = append(out, alphabet[int(b)%n])
+			if len(out) == length {
+				break
+			}
+		}
 	}
-	return string(bytes), nil
+	return string(out), nil
 }
 
+// Deprecated: از password.Policy استفاده کنید که قوانین قابل تنظیم و خطای ساخت‌یافته دارد.
 func ValidatePassword(password string) error {
 	if len(password) < 8 {
 		return errors.New("رمز عبور باید حداقل ۸ کاراکتر داشته باشد")
@@ -82,34 +103,9 @@ func ValidatePassword(password string) error {
 	return nil
 }
 
-func ValidateEmail(email string) bool {
-	const emailRegex = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-
-	// بررسی طول ایمیل
-	if len(email) > 254 {
-		return false
-	}
-
-	re := regexp.MustCompile(emailRegex)
-	if !re.MatchString(email) {
-		return false
-	}
-
-	// بررسی دامنه‌های غیرفعال
-	blacklistedDomains := []string{"example.com", "test.com"}
-	parts := strings.Split(email, "@")
-	if len(parts) != 2 {
-		return false
-	}
-	domain := strings.ToLower(parts[1])
-
-	for _, d := range blacklistedDomains {
-		if domain == d {
-			return false
-		}
-	}
-
-	return true
+// ValidateEmail قالب ایمیل و فهرست دامنه‌های مسدود/موقت را با email.DefaultValidator بررسی می‌کند
+func ValidateEmail(e string) bool {
+	return email.Valid(e)
 }
 
 func Validate2FACode(code string) bool {
@@ -119,18 +115,28 @@ func Validate2FACode(code string) bool {
 	return true
 }
 
-func ValidatePhone(phone string) bool {
-	re := regexp.MustCompile(`^09\d{9}$`)
-	return re.MatchString(phone)
+// ValidatePhone شماره‌ی موبایل (داخلی، بین‌المللی یا با ارقام فارسی) معتبر است یا نه
+func ValidatePhone(p string) bool {
+	return phone.IsValidMobile(p)
 }
+
+// NormalizeEmail ایمیل را به حروف کوچک و دامنه‌ی punycode تبدیل می‌کند؛ ورودی نامعتبر فقط trim و lowercase می‌شود
 func NormalizeEmail(s string) string {
+	if e, err := email.Normalize(s); err == nil {
+		return e
+	}
 	return strings.TrimSpace(strings.ToLower(s))
 }
 
+// NormalizePhone شماره را به قالب E.164 (مثلاً +989121234567) تبدیل می‌کند؛ ورودی نامعتبر فقط trim می‌شود
 func NormalizePhone(s string) string {
+	if n, err := phone.Normalize(s); err == nil {
+		return n
+	}
 	return strings.TrimSpace(s)
 }
 
+// Deprecated: از identifier.Parse و متد Channel استفاده کنید.
 func Get2FAChannelByIdentifier(identifier string) string {
 	identifier = strings.TrimSpace(identifier)
 	if identifier == "" {
@@ -139,12 +145,12 @@ func Get2FAChannelByIdentifier(identifier string) string {
 
 	// اگر ایمیل بود
 	if ValidateEmail(identifier) {
-		return "email"
+		return consts.ChannelEmail
 	}
 
 	// اگر موبایل بود
 	if ValidatePhone(identifier) {
-		return "sms"
+		return consts.ChannelSMS
 	}
 
 	return ""
@@ -0,0 +1,124 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+)
+
+func TestGenerateRandomString(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		length   int
+		wantErr  bool
+	}{
+		{"digits", "0123456789", 6, false},
+		{"single rune alphabet", "x", 4, false},
+		{"full byte alphabet", strings.Repeat("a", 256), 8, false},
+		{"empty alphabet", "", 6, true},
+		{"alphabet too large", strings.Repeat("a", 257), 6, true},
+		{"zero length", "01", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := GenerateRandomString(tt.alphabet, tt.length)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(s) != tt.length {
+				t.Fatalf("len = %d, want %d", len(s), tt.length)
+			}
+			for _, c := range s {
+				if !strings.ContainsRune(tt.alphabet, c) {
+					t.Fatalf("%q contains %q outside the alphabet", s, c)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateVerificationCode(t *testing.T) {
+	seen := map[byte]bool{}
+	for i := 0; i < 200; i++ {
+		code, err := GenerateVerificationCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(code) != 6 || strings.Trim(code, "0123456789") != "" {
+			t.Fatalf("bad code %q", code)
+		}
+		seen[code[0]] = true
+	}
+	if len(seen) < 10 {
+		t.Fatalf("only %d distinct leading digits in 200 codes", len(seen))
+	}
+}
+
+func TestPhoneHelpers(t *testing.T) {
+	tests := []struct {
+		raw       string
+		valid     bool
+		normalize string
+	}{
+		{"09121234567", true, "+989121234567"},
+		{"+98 912 123 4567", true, "+989121234567"},
+		{"۰۹۱۲۱۲۳۴۵۶۷", true, "+989121234567"},
+		{"02112345678", false, "02112345678"},   // ثابت، نه موبایل
+		{"+33612345678", false, "+33612345678"}, // پیش‌شماره‌ی پشتیبانی‌نشده
+		{" 12345 ", false, "12345"},
+	}
+	for _, tt := range tests {
+		if got := ValidatePhone(tt.raw); got != tt.valid {
+			t.Errorf("ValidatePhone(%q) = %v, want %v", tt.raw, got, tt.valid)
+		}
+		if got := NormalizePhone(tt.raw); got != tt.normalize {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", tt.raw, got, tt.normalize)
+		}
+	}
+}
+
+func TestEmailHelpers(t *testing.T) {
+	tests := []struct {
+		raw       string
+		valid     bool
+		normalize string
+	}{
+		{"Ali@Company.IR", true, "ali@company.ir"},
+		{"user@پست.ایران", true, "user@xn--pgbr8t.xn--mgba3a4f16a"},
+		{"user@example.com", false, "user@example.com"},
+		{"user@10minutemail.com", false, "user@10minutemail.com"},
+		{" Not An Email ", false, "not an email"},
+	}
+	for _, tt := range tests {
+		if got := ValidateEmail(tt.raw); got != tt.valid {
+			t.Errorf("ValidateEmail(%q) = %v, want %v", tt.raw, got, tt.valid)
+		}
+		if got := NormalizeEmail(tt.raw); got != tt.normalize {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.raw, got, tt.normalize)
+		}
+	}
+}
+
+func TestGet2FAChannelByIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		want       string
+	}{
+		{"ali@company.ir", consts.ChannelEmail},
+		{"09121234567", consts.ChannelSMS},
+		{"+989121234567", consts.ChannelSMS},
+		{"user@example.com", ""},
+		{"   ", ""},
+		{"abc", ""},
+	}
+	for _, tt := range tests {
+		if got := Get2FAChannelByIdentifier(tt.identifier); got != tt.want {
+			t.Errorf("Get2FAChannelByIdentifier(%q) = %q, want %q", tt.identifier, got, tt.want)
+		}
+	}
+}
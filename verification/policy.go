@@ -0,0 +1,222 @@
+package verification
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/alisiahmansouri/exchange-common/textnorm"
+)
+
+// Purpose هدف کد تایید
+type Purpose string
+
+const (
+	PurposeEmailVerification Purpose = consts.PurposeEmailVerification
+	PurposePhoneVerification Purpose = consts.PurposePhoneVerification
+	PurposeLogin2FA          Purpose = consts.PurposeLogin2FA
+	PurposeRegister2FA       Purpose = consts.PurposeRegister2FA
+	PurposeForgetPassword    Purpose = consts.PurposeForgetPassword
+)
+
+func (p Purpose) String() string { return string(p) }
+
+const (
+	DigitAlphabet         = "0123456789"
+	DefaultCodeLength     = 6
+	DefaultMaxAttempts    = 5
+	DefaultResendCooldown = time.Minute
+)
+
+// Policy قواعد صدور و بررسی کد برای یک هدف
+type Policy struct {
+	TTL            time.Duration
+	Length         int
+	Alphabet       string   // فقط نویسه‌های ASCII (کد بایت‌به‌بایت تولید می‌شود)
+	Channels       []string // کانال‌های مجاز؛ کد برنامه احراز هویت با twofa.TOTP بررسی می‌شود نه با Service
+	MaxAttempts    int
+	ResendCooldown time.Duration
+}
+
+// AllowsChannel کانال برای این هدف مجاز است یا نه
+func (p Policy) AllowsChannel(channel string) bool {
+	for _, c := range p.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeCode فاصله‌ها را حذف، ارقام فارسی را لاتین و برای الفبای تک‌حالته (مثلاً فقط حروف بزرگ) حالت حروف را یکسان می‌کند؛
+// اگر طول کد با Length نخواند یا نویسه‌ای خارج از Alphabet داشته باشد false برمی‌گرداند
+func (p Policy) NormalizeCode(code string) (string, bool) {
+	code = textnorm.Digits(strings.TrimSpace(code))
+	switch {
+	case strings.ToUpper(p.Alphabet) == p.Alphabet:
+		code = strings.ToUpper(code)
+	case strings.ToLower(p.Alphabet) == p.Alphabet:
+		code = strings.ToLower(code)
+	}
+	if len(code) != p.Length {
+		return "", false
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(p.Alphabet, r) {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.TTL <= 0 {
+		p.TTL = consts.Default2FAExpireMinutes * time.Minute
+	}
+	if p.Length <= 0 {
+		p.Length = DefaultCodeLength
+	}
+	if p.Alphabet == "" {
+		p.Alphabet = DigitAlphabet
+	}
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+	if p.ResendCooldown < 0 {
+		p.ResendCooldown = 0
+	}
+	return p
+}
+
+// Registry نگاشت هدف به سیاست؛ تنها مرجع اعتبار هدف و کانال
+type Registry struct {
+	mu       sync.RWMutex
+	policies map[Purpose]Policy
+}
+
+func NewRegistry() *Registry {
+	return &Registry{policies: make(map[Purpose]Policy)}
+}
+
+// Register سیاست یک هدف را ثبت یا جایگزین می‌کند؛ Alphabet دارای نویسه‌ی غیر ASCII
+// ثبت نمی‌شود و model.ErrVerificationAlphabetInvalid برمی‌گرداند
+func (r *Registry) Register(p Purpose, policy Policy) error {
+	for i := 0; i < len(policy.Alphabet); i++ {
+		if policy.Alphabet[i] >= utf8.RuneSelf {
+			return model.ErrVerificationAlphabetInvalid
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies[p] = policy.withDefaults()
+	return nil
+}
+
+// Policy سیاست هدف؛ برای هدف ناشناخته ErrInvalidPurpose برمی‌گرداند
+func (r *Registry) Policy(p Purpose) (Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	policy, ok := r.policies[p]
+	if !ok {
+		return Policy{}, richerror.New(consts.OpVerificationIssue, consts.ErrInvalidPurpose, consts.CodeInvalidPurpose, richerror.KindValidation, model.ErrVerificationPurposeInvalid)
+	}
+	return policy, nil
+}
+
+// ParsePurpose رشته‌ی ورودی (مثلاً Verify2FARequest.Purpose) را به Purpose ثبت‌شده تبدیل می‌کند
+func (r *Registry) ParsePurpose(s string) (Purpose, error) {
+	p := Purpose(s)
+	if _, err := r.Policy(p); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// Channels همه‌ی کانال‌های مجاز حداقل یک هدف، به ترتیب الفبا
+func (r *Registry) Channels() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := make(map[string]bool)
+	var out []string
+	for _, policy := range r.policies {
+		for _, c := range policy.Channels {
+			if !seen[c] {
+				seen[c] = true
+				out = append(out, c)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ValidChannel کانال برای حداقل یک هدف ثبت‌شده مجاز است یا نه (جایگزین consts.ValidChannels)
+func (r *Registry) ValidChannel(channel string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, policy := range r.policies {
+		if policy.AllowsChannel(channel) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckChannel مجاز بودن کانال برای هدف را بررسی می‌کند
+func (r *Registry) CheckChannel(p Purpose, channel string) (Policy, error) {
+	policy, err := r.Policy(p)
+	if err != nil {
+		return Policy{}, err
+	}
+	if !policy.AllowsChannel(channel) {
+		return Policy{}, richerror.New(consts.OpVerificationIssue, consts.ErrInvalidChannel, consts.CodeInvalidChannel, richerror.KindValidation, model.ErrVerificationChannelInvalid)
+	}
+	return policy, nil
+}
+
+// CheckSendChannel مانند CheckChannel ولی فقط کانال‌هایی را می‌پذیرد که کد از طریق آن‌ها ارسال می‌شود؛
+// کد TOTP توسط برنامه‌ی کاربر تولید می‌شود و قابل ارسال نیست
+func (r *Registry) CheckSendChannel(p Purpose, channel string) (Policy, error) {
+	if channel == consts.ChannelTOTP {
+		return Policy{}, richerror.New(consts.OpVerificationIssue, consts.ErrInvalidChannel, consts.CodeInvalidChannel, richerror.KindValidation, model.ErrVerificationChannelInvalid)
+	}
+	return r.CheckChannel(p, channel)
+}
+
+// DefaultRegistry سیاست‌های پیش‌فرض اهداف تعریف‌شده در consts
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(PurposeEmailVerification, Policy{
+		TTL:            15 * time.Minute,
+		Channels:       []string{consts.ChannelEmail},
+		ResendCooldown: DefaultResendCooldown,
+	})
+	r.Register(PurposePhoneVerification, Policy{
+		TTL:            consts.Default2FAExpireMinutes * time.Minute,
+		Channels:       []string{consts.ChannelSMS},
+		ResendCooldown: DefaultResendCooldown,
+	})
+	r.Register(PurposeLogin2FA, Policy{
+		TTL:            consts.Default2FAExpireMinutes * time.Minute,
+		Channels:       []string{consts.ChannelEmail, consts.ChannelSMS},
+		ResendCooldown: DefaultResendCooldown,
+	})
+	r.Register(PurposeRegister2FA, Policy{
+		TTL:            consts.TwoFADurationMinutes * time.Minute,
+		Channels:       []string{consts.ChannelEmail, consts.ChannelSMS},
+		ResendCooldown: DefaultResendCooldown,
+	})
+	r.Register(PurposeForgetPassword, Policy{
+		TTL:            10 * time.Minute,
+		Length:         8,
+		Channels:       []string{consts.ChannelEmail, consts.ChannelSMS},
+		MaxAttempts:    3,
+		ResendCooldown: 2 * time.Minute,
+	})
+	return r
+}
@@ -0,0 +1,131 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/identifier"
+	"github.com/alisiahmansouri/exchange-common/model"
+)
+
+func TestPolicyNormalizeCode(t *testing.T) {
+	digits := Policy{Length: 6}.withDefaults()
+	upper := Policy{Length: 6, Alphabet: "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"}.withDefaults()
+	mixed := Policy{Length: 4, Alphabet: "aB"}.withDefaults()
+	tests := []struct {
+		name   string
+		policy Policy
+		in     string
+		want   string
+		ok     bool
+	}{
+		{"digits", digits, "123456", "123456", true},
+		{"persian digits", digits, "۱۲۳۴۵۶", "123456", true},
+		{"trimmed", digits, " 123456\n", "123456", true},
+		{"letter in digit alphabet", digits, "12345a", "", false},
+		{"too short", digits, "12345", "", false},
+		{"upper alphabet lowercased input", upper, "abc234", "ABC234", true},
+		{"upper alphabet excluded char", upper, "ABC10I", "", false},
+		{"mixed case is case sensitive", mixed, "aBaB", "aBaB", true},
+		{"mixed case wrong case", mixed, "AbAb", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.policy.NormalizeCode(tt.in)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("NormalizeCode(%q) = (%q, %v), want (%q, %v)", tt.in, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRegistryChannels(t *testing.T) {
+	r := DefaultRegistry()
+	if got, want := r.Channels(), []string{consts.ChannelEmail, consts.ChannelSMS}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Channels = %v, want %v", got, want)
+	}
+	tests := []struct {
+		purpose Purpose
+		channel string
+		check   bool // CheckChannel
+		send    bool // CheckSendChannel
+	}{
+		{PurposeLogin2FA, consts.ChannelTOTP, false, false},
+		{PurposeLogin2FA, consts.ChannelSMS, true, true},
+		{PurposeEmailVerification, consts.ChannelSMS, false, false},
+		{PurposeForgetPassword, "pigeon", false, false},
+	}
+	for _, tt := range tests {
+		_, err := r.CheckChannel(tt.purpose, tt.channel)
+		if (err == nil) != tt.check {
+			t.Errorf("CheckChannel(%s, %s) err = %v, want ok=%v", tt.purpose, tt.channel, err, tt.check)
+		}
+		_, err = r.CheckSendChannel(tt.purpose, tt.channel)
+		if (err == nil) != tt.send {
+			t.Errorf("CheckSendChannel(%s, %s) err = %v, want ok=%v", tt.purpose, tt.channel, err, tt.send)
+		}
+	}
+	if !r.ValidChannel(consts.ChannelSMS) || r.ValidChannel(consts.ChannelTOTP) || r.ValidChannel("pigeon") {
+		t.Error("ValidChannel mismatch")
+	}
+	if _, err := r.ParsePurpose("nope"); !errors.Is(err, model.ErrVerificationPurposeInvalid) {
+		t.Errorf("ParsePurpose err = %v", err)
+	}
+}
+
+// الفبای چندبایتی با تولید بایت‌به‌بایت کد نامعتبر می‌سازد و باید هنگام ثبت رد شود
+func TestRegisterAlphabet(t *testing.T) {
+	tests := []struct {
+		alphabet string
+		wantErr  error
+	}{
+		{"", nil},
+		{"ABCDEFGHJKLMNPQRSTUVWXYZ", nil},
+		{"۰۱۲۳۴۵۶۷۸۹", model.ErrVerificationAlphabetInvalid},
+		{"abcé", model.ErrVerificationAlphabetInvalid},
+	}
+	for _, tt := range tests {
+		r := NewRegistry()
+		if err := r.Register(PurposeLogin2FA, Policy{Alphabet: tt.alphabet}); !errors.Is(err, tt.wantErr) {
+			t.Fatalf("Register(%q) err = %v, want %v", tt.alphabet, err, tt.wantErr)
+		}
+		if _, err := r.Policy(PurposeLogin2FA); (err == nil) != (tt.wantErr == nil) {
+			t.Fatalf("Register(%q): policy registered = %v", tt.alphabet, err == nil)
+		}
+	}
+}
+
+func TestVerifyCustomAlphabet(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	reg := NewRegistry()
+	if err := reg.Register(PurposeEmailVerification, Policy{Length: 8, Alphabet: "ABCDEFGHJKLMNPQRSTUVWXYZ", Channels: []string{consts.ChannelEmail}}); err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStore()
+	s, err := NewService(store, Config{Pepper: []byte("p"), Policies: reg, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := identifier.MustParse("user@gmail.com")
+	code, _, err := s.Issue(ctx, IssueRequest{Identifier: id, Purpose: PurposeEmailVerification, Channel: consts.ChannelEmail})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// کد نامعتبر از نظر الفبا تلاشی مصرف نمی‌کند
+	if _, err := s.Verify(ctx, id, PurposeEmailVerification, "12345678"); !errors.Is(err, model.ErrVerificationCodeInvalid) {
+		t.Fatalf("digits: err = %v", err)
+	}
+	vc, _ := store.Latest(ctx, id.String(), PurposeEmailVerification.String())
+	if vc.Attempts != 0 {
+		t.Fatalf("attempts = %d, want 0", vc.Attempts)
+	}
+	if _, err := s.Verify(ctx, id, PurposeEmailVerification, " "+strings.ToLower(code)+" "); err != nil {
+		t.Fatalf("lowercase code rejected: %v", err)
+	}
+}
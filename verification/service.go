@@ -0,0 +1,165 @@
+package verification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/identifier"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/alisiahmansouri/exchange-common/richerror"
+	"github.com/alisiahmansouri/exchange-common/util"
+	"github.com/google/uuid"
+)
+
+type Config struct {
+	Pepper   []byte    // الزامی؛ کلید سرور برای HMAC کد (بدون آن هش کدهای ۶ رقمی با brute-force قابل بازیابی است)
+	Policies *Registry // پیش‌فرض: DefaultRegistry
+	Now      func() time.Time
+}
+
+// IssueRequest درخواست صدور کد تایید
+type IssueRequest struct {
+	UserID     uuid.UUID
+	Identifier identifier.Identifier
+	Purpose    Purpose
+	Channel    string
+}
+
+// Service صدور و بررسی کدهای تایید (ایمیل/پیامک) برای همه‌ی سرویس‌ها
+type Service struct {
+	store Store
+	cfg   Config
+}
+
+// NewService بدون Pepper خطای ErrPepperRequired می‌دهد
+func NewService(store Store, cfg Config) (*Service, error) {
+	if len(cfg.Pepper) == 0 {
+		return nil, model.ErrPepperRequired
+	}
+	if cfg.Policies == nil {
+		cfg.Policies = DefaultRegistry()
+	}
+	if cfg.Now == nil {
+		cfg.Now = util.NowUTC
+	}
+	return &Service{store: store, cfg: cfg}, nil
+}
+
+// Issue کد جدید تولید و ذخیره می‌کند؛ کد متنی فقط برای ارسال برگردانده می‌شود
+func (s *Service) Issue(ctx context.Context, req IssueRequest) (string, *entity.VerificationCode, error) {
+	if req.Identifier.IsZero() {
+		return "", nil, richerror.New(consts.OpVerificationIssue, consts.ErrInvalidEmailOrPhone, consts.CodeInvalidEmailOrPhone, richerror.KindValidation, model.ErrVerificationIdentifierInvalid)
+	}
+	policy, err := s.cfg.Policies.CheckSendChannel(req.Purpose, req.Channel)
+	if err != nil {
+		return "", nil, err
+	}
+	now := s.cfg.Now()
+
+	last, err := s.store.Latest(ctx, req.Identifier.String(), req.Purpose.String())
+	if err != nil && !errors.Is(err, model.ErrVerificationCodeNotFound) {
+		return "", nil, richerror.Wrap(consts.OpVerificationIssue, err, consts.ErrVerificationIssueFail, consts.CodeVerificationIssueFail, richerror.KindInternal)
+	}
+	if last != nil && now.Before(last.CreatedAt.Add(policy.ResendCooldown)) {
+		return "", nil, richerror.New(consts.OpVerificationIssue, consts.ErrVerificationResendWait, consts.CodeResendCooldown, richerror.KindTooManyRequests, model.ErrVerificationRateLimitExceeded)
+	}
+
+	code, err := util.GenerateRandomString(policy.Alphabet, policy.Length)
+	if err != nil {
+		return "", nil, richerror.New(consts.OpVerificationIssue, consts.ErrVerificationIssueFail, consts.CodeVerificationIssueFail, richerror.KindInternal, model.ErrVerificationCodeNotGenerated)
+	}
+	vc := &entity.VerificationCode{
+		UserID:     req.UserID,
+		Identifier: req.Identifier,
+		HashedCode: s.hash(req.Identifier.String(), req.Purpose, code),
+		ExpiresAt:  now.Add(policy.TTL),
+		Purpose:    req.Purpose.String(),
+		Channel:    req.Channel,
+		CreatedAt:  now,
+	}
+	if err := s.store.Replace(ctx, vc); err != nil {
+		return "", nil, richerror.Wrap(consts.OpVerificationIssue, err, consts.ErrVerificationIssueFail, consts.CodeVerificationIssueFail, richerror.KindInternal)
+	}
+	return code, vc, nil
+}
+
+// Verify کد واردشده را بررسی و در صورت صحت مصرف می‌کند
+func (s *Service) Verify(ctx context.Context, id identifier.Identifier, purpose Purpose, code string) (*entity.VerificationCode, error) {
+	policy, err := s.cfg.Policies.Policy(purpose)
+	if err != nil {
+		return nil, err
+	}
+	code, ok := policy.NormalizeCode(code)
+	if !ok {
+		return nil, verifyErr(model.ErrVerificationCodeInvalid)
+	}
+
+	vc, err := s.store.Latest(ctx, id.String(), purpose.String())
+	if errors.Is(err, model.ErrVerificationCodeNotFound) {
+		return nil, verifyErr(err)
+	}
+	if err != nil {
+		return nil, richerror.Wrap(consts.OpVerificationVerify, err, consts.ErrVerificationCheckFail, consts.CodeVerificationCheckFail, richerror.KindInternal)
+	}
+	if vc.IsUsed {
+		return nil, verifyErr(model.ErrVerificationCodeAlreadyUsed)
+	}
+	if !s.cfg.Now().Before(vc.ExpiresAt) {
+		return nil, verifyErr(model.ErrVerificationCodeExpired)
+	}
+
+	// تلاش پیش از مقایسه و به صورت اتمی رزرو می‌شود تا درخواست‌های هم‌زمان از سقف عبور نکنند
+	attempts, err := s.store.IncrementAttempts(ctx, vc.ID, policy.MaxAttempts)
+	if errors.Is(err, model.ErrTooManyAttempts) {
+		return nil, verifyErr(err)
+	}
+	if err != nil {
+		return nil, richerror.Wrap(consts.OpVerificationVerify, err, consts.ErrVerificationCheckFail, consts.CodeVerificationCheckFail, richerror.KindInternal)
+	}
+
+	if !hmac.Equal([]byte(s.hash(id.String(), purpose, code)), []byte(vc.HashedCode)) {
+		if attempts >= policy.MaxAttempts {
+			return nil, verifyErr(model.ErrTooManyAttempts)
+		}
+		return nil, verifyErr(model.ErrVerificationCodeHashMismatch)
+	}
+
+	if err := s.store.MarkUsed(ctx, vc.ID); err != nil {
+		if errors.Is(err, model.ErrVerificationCodeAlreadyUsed) {
+			return nil, verifyErr(err)
+		}
+		return nil, richerror.Wrap(consts.OpVerificationVerify, err, consts.ErrVerificationCheckFail, consts.CodeVerificationCheckFail, richerror.KindInternal)
+	}
+	vc.IsUsed = true
+	return vc, nil
+}
+
+// hash کد را با HMAC-SHA256 و pepper سرور، وابسته به شناسه و هدف، هش می‌کند
+func (s *Service) hash(identifier string, purpose Purpose, code string) string {
+	mac := hmac.New(sha256.New, s.cfg.Pepper)
+	mac.Write([]byte(identifier))
+	mac.Write([]byte{0})
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyErr(err error) error {
+	switch {
+	case errors.Is(err, model.ErrVerificationCodeAlreadyUsed):
+		return richerror.New(consts.OpVerificationVerify, consts.ErrVerificationUsed, consts.CodeVerificationUsed, richerror.KindConflict, err)
+	case errors.Is(err, model.ErrVerificationCodeExpired):
+		return richerror.New(consts.OpVerificationVerify, consts.ErrVerificationExpired, consts.CodeVerificationExpired, richerror.KindInvalid, err)
+	case errors.Is(err, model.ErrTooManyAttempts):
+		return richerror.New(consts.OpVerificationVerify, consts.ErrTooManyAttempts, consts.CodeTooManyAttempts, richerror.KindTooManyRequests, err)
+	default:
+		return richerror.New(consts.OpVerificationVerify, consts.ErrInvalidOrExpiredCode, consts.CodeInvalidOrExpiredCode, richerror.KindInvalid, err)
+	}
+}
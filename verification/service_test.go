@@ -0,0 +1,143 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/consts"
+	"github.com/alisiahmansouri/exchange-common/identifier"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+)
+
+func newTestService(t *testing.T, now *time.Time) *Service {
+	t.Helper()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return *now }
+	s, err := NewService(store, Config{Pepper: []byte("pepper"), Now: store.now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func issue(t *testing.T, s *Service, id identifier.Identifier, purpose Purpose, channel string) string {
+	t.Helper()
+	code, _, err := s.Issue(context.Background(), IssueRequest{UserID: uuid.New(), Identifier: id, Purpose: purpose, Channel: channel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestNewServiceRequiresPepper(t *testing.T) {
+	if _, err := NewService(NewMemoryStore(), Config{}); !errors.Is(err, model.ErrPepperRequired) {
+		t.Fatalf("err = %v, want ErrPepperRequired", err)
+	}
+}
+
+func TestIssue(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestService(t, &now)
+	id := identifier.MustParse("user@gmail.com")
+	tests := []struct {
+		name    string
+		req     IssueRequest
+		wantErr error
+	}{
+		{"ok", IssueRequest{Identifier: id, Purpose: PurposeEmailVerification, Channel: consts.ChannelEmail}, nil},
+		{"cooldown", IssueRequest{Identifier: id, Purpose: PurposeEmailVerification, Channel: consts.ChannelEmail}, model.ErrVerificationRateLimitExceeded},
+		{"no identifier", IssueRequest{Purpose: PurposeLogin2FA, Channel: consts.ChannelEmail}, model.ErrVerificationIdentifierInvalid},
+		{"totp channel", IssueRequest{Identifier: id, Purpose: PurposeLogin2FA, Channel: consts.ChannelTOTP}, model.ErrVerificationChannelInvalid},
+		{"channel not allowed", IssueRequest{Identifier: id, Purpose: PurposeEmailVerification, Channel: consts.ChannelSMS}, model.ErrVerificationChannelInvalid},
+		{"unknown purpose", IssueRequest{Identifier: id, Purpose: "nope", Channel: consts.ChannelEmail}, model.ErrVerificationPurposeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, vc, err := s.Issue(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (len(code) != DefaultCodeLength || vc.HashedCode == code) {
+				t.Fatalf("code = %q, hashed = %q", code, vc.HashedCode)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestService(t, &now)
+	id := identifier.MustParse("09121234567")
+	code := issue(t, s, id, PurposeLogin2FA, consts.ChannelSMS)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{"wrong length", "123", model.ErrVerificationCodeInvalid},
+		{"wrong code", wrong, model.ErrVerificationCodeHashMismatch},
+		{"correct", " " + code + " ", nil},
+		{"reuse", code, model.ErrVerificationCodeAlreadyUsed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Verify(ctx, id, PurposeLogin2FA, tt.code); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	now = now.Add(time.Hour)
+	code = issue(t, s, id, PurposeLogin2FA, consts.ChannelSMS)
+	now = now.Add(consts.Default2FAExpireMinutes * time.Minute)
+	if _, err := s.Verify(ctx, id, PurposeLogin2FA, code); !errors.Is(err, model.ErrVerificationCodeExpired) {
+		t.Fatalf("expired: err = %v", err)
+	}
+}
+
+func TestVerifyMaxAttemptsConcurrent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestService(t, &now)
+	id := identifier.MustParse("user@gmail.com")
+	code := issue(t, s, id, PurposeForgetPassword, consts.ChannelEmail)
+	policy, _ := s.cfg.Policies.Policy(PurposeForgetPassword)
+	wrong := "00000000"
+	if code == wrong {
+		wrong = "11111111"
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	mismatches := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Verify(ctx, id, PurposeForgetPassword, wrong)
+			if errors.Is(err, model.ErrVerificationCodeHashMismatch) || (err != nil && !errors.Is(err, model.ErrTooManyAttempts)) {
+				mu.Lock()
+				mismatches++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	// آخرین تلاش مجاز ErrTooManyAttempts برمی‌گرداند؛ پس حداکثر MaxAttempts-1 پاسخ «کد اشتباه» داریم
+	if mismatches > policy.MaxAttempts-1 {
+		t.Fatalf("mismatches = %d, want <= %d", mismatches, policy.MaxAttempts-1)
+	}
+	if _, err := s.Verify(ctx, id, PurposeForgetPassword, code); !errors.Is(err, model.ErrTooManyAttempts) {
+		t.Fatalf("correct code after lockout: err = %v, want ErrTooManyAttempts", err)
+	}
+}
@@ -0,0 +1,173 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/alisiahmansouri/exchange-common/entity"
+	"github.com/alisiahmansouri/exchange-common/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store ذخیره‌سازی کدهای تایید
+type Store interface {
+	// Latest آخرین کد صادرشده برای شناسه و هدف را برمی‌گرداند (یا model.ErrVerificationCodeNotFound)
+	Latest(ctx context.Context, identifier, purpose string) (*entity.VerificationCode, error)
+	// Replace کدهای مصرف‌نشده‌ی قبلی همان شناسه و هدف را باطل و کد جدید را ذخیره می‌کند
+	Replace(ctx context.Context, code *entity.VerificationCode) error
+	// IncrementAttempts فقط اگر تعداد تلاش‌ها کمتر از maxAttempts باشد آن را به صورت اتمی یکی زیاد و تعداد جدید را برمی‌گرداند؛
+	// در غیر این صورت model.ErrTooManyAttempts
+	IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error)
+	// MarkUsed کد را مصرف می‌کند؛ اگر قبلاً مصرف شده باشد model.ErrVerificationCodeAlreadyUsed برمی‌گردد
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+}
+
+// MemoryStore پیاده‌سازی درون‌حافظه‌ای Store
+type MemoryStore struct {
+	mu    sync.Mutex
+	codes map[uuid.UUID]*entity.VerificationCode
+	now   func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{codes: make(map[uuid.UUID]*entity.VerificationCode), now: time.Now}
+}
+
+func (s *MemoryStore) Latest(_ context.Context, identifier, purpose string) (*entity.VerificationCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest *entity.VerificationCode
+	for _, c := range s.codes {
+		if c.Identifier.String() != identifier || c.Purpose != purpose {
+			continue
+		}
+		if latest == nil || c.CreatedAt.After(latest.CreatedAt) {
+			latest = c
+		}
+	}
+	if latest == nil {
+		return nil, model.ErrVerificationCodeNotFound
+	}
+	cp := *latest
+	return &cp, nil
+}
+
+func (s *MemoryStore) Replace(_ context.Context, code *entity.VerificationCode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, c := range s.codes {
+		if c.Identifier.Equal(code.Identifier) && c.Purpose == code.Purpose && !c.IsUsed {
+			delete(s.codes, id)
+		}
+	}
+	code.ID = uuid.New()
+	if code.CreatedAt.IsZero() {
+		code.CreatedAt = s.now()
+	}
+	cp := *code
+	s.codes[code.ID] = &cp
+	return nil
+}
+
+func (s *MemoryStore) IncrementAttempts(_ context.Context, id uuid.UUID, maxAttempts int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.codes[id]
+	if !ok {
+		return 0, model.ErrVerificationCodeNotFound
+	}
+	if c.Attempts >= maxAttempts {
+		return c.Attempts, model.ErrTooManyAttempts
+	}
+	c.Attempts++
+	return c.Attempts, nil
+}
+
+func (s *MemoryStore) MarkUsed(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.codes[id]
+	if !ok {
+		return model.ErrVerificationCodeNotFound
+	}
+	if c.IsUsed {
+		return model.ErrVerificationCodeAlreadyUsed
+	}
+	c.IsUsed = true
+	return nil
+}
+
+// GormStore پیاده‌سازی Store روی جدول verification_codes
+type GormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+func (s *GormStore) Latest(ctx context.Context, identifier, purpose string) (*entity.VerificationCode, error) {
+	var c entity.VerificationCode
+	err := s.db.WithContext(ctx).
+		Where("identifier = ? AND purpose = ?", identifier, purpose).
+		Order("created_at DESC").
+		Take(&c).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, model.ErrVerificationCodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (s *GormStore) Replace(ctx context.Context, code *entity.VerificationCode) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("identifier = ? AND purpose = ? AND is_used = ?", code.Identifier, code.Purpose, false).
+			Delete(&entity.VerificationCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(code).Error
+	})
+}
+
+func (s *GormStore) IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error) {
+	var attempts int
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.VerificationCode{}).
+			Where("id = ? AND attempts < ?", id, maxAttempts).
+			Update("attempts", gorm.Expr("attempts + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		var c entity.VerificationCode
+		if err := tx.Select("attempts").Where("id = ?", id).Take(&c).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrVerificationCodeNotFound
+			}
+			return err
+		}
+		attempts = c.Attempts
+		if res.RowsAffected == 0 {
+			return model.ErrTooManyAttempts
+		}
+		return nil
+	})
+	return attempts, err
+}
+
+func (s *GormStore) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	res := s.db.WithContext(ctx).Model(&entity.VerificationCode{}).
+		Where("id = ? AND is_used = ?", id, false).
+		Update("is_used", true)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrVerificationCodeAlreadyUsed
+	}
+	return nil
+}